@@ -4,18 +4,25 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/microsoft/wcnspect/common"
 	"github.com/microsoft/wcnspect/pkg/client"
+	"github.com/microsoft/wcnspect/pkg/comprise"
 	"github.com/microsoft/wcnspect/pkg/k8sapi"
 	pb "github.com/microsoft/wcnspect/rpc"
+	"google.golang.org/protobuf/proto"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/spf13/cobra"
 )
@@ -28,9 +35,38 @@ type captureCmd struct {
 	ports     []string
 	macs      []string
 
+	ethertypes []string
+	vlans      []uint
+	heartbeat  bool
+	encap      bool
+	vxlanPort  uint32
+
+	filter     string
+	filterSets []*pb.Filters
+
+	// Client-side search
+	match  string
+	grep   string
+	search *client.Search
+
 	packetType   string
 	countersOnly bool
 	namespace    string
+	outputPcap   string
+	toNode       string
+
+	// Pod targets
+	selector      string
+	deployments   []string
+	services      []string
+	allNamespaces bool
+
+	// Service targets
+	service   *k8sapi.ServiceEndpoints
+	nodePorts bool
+
+	merge         bool
+	reorderWindow time.Duration
 
 	*baseBuilderCmd
 }
@@ -42,41 +78,68 @@ func (b *commandsBuilder) newCaptureCmd() *captureCmd {
 		Use:   "capture",
 		Short: "The 'capture' command will run a packet capture on all windows nodes.",
 		Long: `The 'capture' command will run a packet capture on all windows nodes. For example:
-	'wcnspect capture pods {pod1,pod2} --protocols TCP -d 10'.`,
+	'wcnspect capture pods {pod1,pod2} --protocols TCP -d 10' or 'wcnspect capture pods --selector app=web -d 10'.`,
 	}
 
-	captureTypes := []string{"all", "nodes", "pods"}
+	captureTypes := []string{"all", "nodes", "pods", "service"}
 	captureHelp := map[string]string{
-		"all":   "Runs on all windows nodes in the AKS cluster.",
-		"nodes": "Specify which nodes wcnspect should send requests to using comma-separated node names.",
-		"pods":  "Specify which pods the capture should filter on. Supports up to two comma-separated pod names.",
+		"all":     "Runs on all windows nodes in the AKS cluster.",
+		"nodes":   "Specify which nodes wcnspect should send requests to using comma-separated node names.",
+		"pods":    "Specify which pods the capture should filter on using comma-separated pod names, --selector, --deployment or --service.",
+		"service": "Capture traffic to a service's VIPs and backends on the nodes hosting its backends.",
 	}
 	for _, name := range captureTypes {
 		subcmd := &cobra.Command{
 			Use:   name,
 			Short: captureHelp[name],
-			Run: func(cmd *cobra.Command, args []string) {
-				cc.printCapture(cmd.Name(), args)
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return cc.printCapture(cmd.Name(), args)
 			},
 		}
 		cmd.AddCommand(subcmd)
+
+		if name == "pods" {
+			subcmd.Flags().StringVarP(&cc.selector, "selector", "l", "", "Filter on the pods matching this label selector.")
+			subcmd.Flags().StringSliceVar(&cc.deployments, "deployment", []string{}, "Filter on the pods of these deployments.")
+			subcmd.Flags().StringSliceVar(&cc.services, "service", []string{}, "Filter on the pods backing these services.")
+			subcmd.Flags().BoolVarP(&cc.allNamespaces, "all-namespaces", "A", false, "Match --selector against pods in every namespace.")
+		}
+
+		if name == "service" {
+			subcmd.Use = "service {name}"
+			subcmd.Args = cobra.ExactArgs(1)
+			subcmd.Flags().BoolVar(&cc.nodePorts, "node-ports", false, "Also capture NodePort traffic to the service on every Windows node.")
+		}
 	}
 	cmd.PersistentFlags().Int32VarP(&cc.time, "time", "d", 0, "Time to run packet capture for (in seconds). Runs indefinitely given 0.")
 
-	cmd.PersistentFlags().StringSliceVarP(&cc.ips, "ips", "i", []string{}, "Match source or destination IP address. CIDR supported.")
-	cmd.PersistentFlags().StringSliceVarP(&cc.protocols, "protocols", "t", []string{}, "Match by transport protocol. Can be TCP, UDP, ICMP, and/or TCP_{tcp flag}.")
-	cmd.PersistentFlags().StringSliceVarP(&cc.ports, "ports", "r", []string{}, "Match source or destination port number.")
-	cmd.PersistentFlags().StringSliceVarP(&cc.macs, "macs", "m", []string{}, "Match source or destination MAC address.")
+	cmd.PersistentFlags().StringSliceVarP(&cc.ips, "ips", "i", []string{}, "Match source or destination IP address. CIDR supported. Several addresses match traffic to or from any of them; join two with '=' to match traffic between them only.")
+	cmd.PersistentFlags().StringSliceVarP(&cc.protocols, "protocols", "t", []string{}, "Match by transport protocol. Can be TCP, UDP, ICMP, ICMPv6, TCP_{tcp flag}, and/or an IP protocol number.")
+	cmd.PersistentFlags().StringSliceVarP(&cc.ports, "ports", "r", []string{}, "Match source or destination port number. Several ports match traffic to or from any of them; join two with '=' to match traffic between them only.")
+	cmd.PersistentFlags().StringSliceVarP(&cc.macs, "macs", "m", []string{}, "Match source or destination MAC address. Several addresses match traffic to or from any of them; join two with '=' to match traffic between them only.")
+	cmd.PersistentFlags().StringSliceVar(&cc.ethertypes, "ethertypes", []string{}, "Match by data link protocol. Can be IPv4, IPv6, ARP, and/or an ethertype number.")
+	cmd.PersistentFlags().UintSliceVar(&cc.vlans, "vlans", []uint{}, "Match by VLAN ID.")
+	cmd.PersistentFlags().BoolVar(&cc.heartbeat, "heartbeat", false, "Match RCP heartbeat messages over UDP port 3343.")
+	cmd.PersistentFlags().BoolVar(&cc.encap, "encap", false, "Match the other filters against both the inner and outer headers of VXLAN, GRE and NVGRE packets.")
+	cmd.PersistentFlags().Uint32Var(&cc.vxlanPort, "vxlan-port", 0, "VXLAN port matched with --encap, 4789 by default.")
+	cmd.PersistentFlags().StringVar(&cc.filter, "filter", "", "Match packets by a BPF-like expression, e.g. 'tcp and host 10.0.0.5 and port 443 or udp port 53'. Can't be used with the other filter flags, except --encap and --vxlan-port.")
+
+	cmd.PersistentFlags().StringVar(&cc.match, "match", "", "Only show packets matching a Wireshark-like expression, e.g. 'tcp.flags.syn && len>1000'. Applied by wcnspect to the packets captured, so it can narrow what's shown more finely than the capture filters.")
+	cmd.PersistentFlags().StringVar(&cc.grep, "grep", "", "Only show packets whose output matches a regular expression, highlighting the matches when printing to a terminal.")
 
 	cmd.PersistentFlags().StringVar(&cc.packetType, "type", "all", "Select which packets to capture. Can be all, flow, or drop.")
 	cmd.PersistentFlags().BoolVar(&cc.countersOnly, "counters-only", false, "Collect packet counters only. No packet logging.")
 	cmd.PersistentFlags().StringVarP(&cc.namespace, "namespace", "n", common.DefaultNamespace, "Specify Kubernetes namespace to filter pods on.")
+	cmd.PersistentFlags().BoolVar(&cc.merge, "merge", false, "Merge output from all nodes into a single timeline ordered by packet timestamp, tagging each line with its node.")
+	cmd.PersistentFlags().DurationVar(&cc.reorderWindow, "reorder-window", 2*time.Second, "How long merged output is buffered to reorder late lines. Only used with --merge.")
+	cmd.PersistentFlags().StringVar(&cc.outputPcap, "output-pcap", "", "Write captured packets to a pcapng file instead of printing them. Captures on several nodes write one file per node, suffixed with the node name.")
+	cmd.PersistentFlags().StringVar(&cc.toNode, "to-node", "", "Log packets to a file on each node instead of streaming them, as etl or pcapng. The capture keeps running if wcnspect exits. Download the files with 'wcnspect fetch'.")
 	cc.baseBuilderCmd = b.newBuilderCmd(cmd)
 
 	return cc
 }
 
-func (cc *captureCmd) printCapture(subcmd string, endpoints []string) {
+func (cc *captureCmd) printCapture(subcmd string, endpoints []string) error {
 	cc.validateArgs()
 	var targetNodes []v1.Node
 	// Store mapping of NodeName => Pod IPs
@@ -97,74 +160,299 @@ func (cc *captureCmd) printCapture(subcmd string, endpoints []string) {
 		}
 		targetNodes = cc.getNodes(nodes)
 	case "pods":
-		// Use namespace command
-		//cc.cmd.PersistentFlags().StringVar(&cc.namespace, "namespace", common.DefaultNamespace, "Optionally specify Kubernetes namespace to filter pods on.")
-		if len(endpoints) == 0 {
-			log.Fatal("must pass pod names when using 'wcnspect capture pods ...'")
-		}
-
-		pods := strings.Split(endpoints[0], ",")
-		// Namespace
-		ns := k8sclient.GetNamespace(cc.namespace)
-		// Loop over Pod, Node
-		var p *v1.Pod
-		var nodeName string
-		for _, podName := range pods {
-			p = k8sclient.GetPod(podName, ns.GetName())
-			nodeName = p.Spec.NodeName
-			podIP := p.Status.PodIP
-			if nodeName != "" {
-				hostMap[nodeName] = append(hostMap[nodeName], podIP)
-				targetNodes = append(targetNodes, cc.getNode(nodeName))
+		if len(endpoints) == 0 && cc.selector == "" && len(cc.deployments) == 0 && len(cc.services) == 0 {
+			log.Fatal("must pass pod names, --selector, --deployment or --service when using 'wcnspect capture pods ...'")
+		}
+
+		if cc.allNamespaces && (len(endpoints) > 0 || len(cc.deployments) > 0 || len(cc.services) > 0) {
+			log.Fatal("--all-namespaces can only be used with --selector")
+		}
+
+		var names []string
+		if len(endpoints) > 0 {
+			names = strings.Split(endpoints[0], ",")
+		}
+
+		pods, err := cc.getPods(names)
+		if err != nil {
+			return err
+		}
+
+		// Each node only filters on the pods running on it
+		hostMap = k8sapi.GroupPodIPs(pods)
+		nodeNames := comprise.Keys(hostMap)
+		sort.Strings(nodeNames)
+		for _, nodeName := range nodeNames {
+			node, ok := cc.winNodeNames[nodeName]
+			if !ok {
+				log.Printf("Skipping pods %v on node %s, which isn't a Windows node.\n", hostMap[nodeName], nodeName)
+				continue
+			}
+			targetNodes = append(targetNodes, node)
+		}
+
+		if len(targetNodes) == 0 {
+			return errors.New("no running pods on Windows nodes matched")
+		}
+	case "service":
+		if err := cc.resolveService(endpoints[0]); err != nil {
+			return err
+		}
+
+		if cc.nodePorts {
+			targetNodes = cc.getWinNodes()
+			break
+		}
+
+		for _, nodeName := range cc.service.Nodes {
+			if node, ok := cc.winNodeNames[nodeName]; ok {
+				targetNodes = append(targetNodes, node)
+			}
+		}
+
+		if len(targetNodes) == 0 {
+			return fmt.Errorf("service %s has no backends on Windows nodes, pass --node-ports to capture NodePort traffic on every node", endpoints[0])
+		}
+	}
+
+	// Services narrow the filters to their traffic on each node, so they're only checked in full once resolved
+	nodeFilters := map[string]*pb.Filters{}
+	if cc.service != nil {
+		serviceNodes := []v1.Node{}
+		for _, node := range targetNodes {
+			nodeIP := ""
+			if cc.nodePorts {
+				nodeIP = k8sapi.RetrieveInternalIP(node)
+			}
+
+			filters := client.ServiceFilters(cc.getFilters(), cc.service, node.GetName(), nodeIP)
+			if len(filters.GetSets()) == 0 {
+				log.Printf("Skipping node %s, where the filters don't match any of the service's traffic.\n", node.GetName())
+				continue
+			}
+
+			if err := client.ValidateFilters(filters); err != nil {
+				return err
 			}
+			nodeFilters[node.GetName()] = filters
+			serviceNodes = append(serviceNodes, node)
+		}
+
+		if targetNodes = serviceNodes; len(targetNodes) == 0 {
+			return fmt.Errorf("the filters don't match any of service %s's addresses and ports", endpoints[0])
 		}
 	}
 
+	// Merge output from every node into a single timeline if requested
+	var timeline *client.Timeline
+	if cc.merge {
+		timeline = client.NewTimeline(os.Stdout, cc.reorderWindow)
+		timeline.Start()
+		defer timeline.Close()
+	}
+
+	// Track the sessions we start, so that only our captures get stopped
+	sessions := client.NewSessions()
+
+	out := cc.getOutput()
+	defer out.Close()
+
 	// Capture any sigint to send a StopCapture request
-	c := make(chan os.Signal)
+	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		<-c
-		client.Cleanup(targetNodes)
-		os.Exit(1)
+		if err := client.Cleanup(targetNodes, sessions, out, cc.getConnOptions()); err != nil {
+			log.Print(err)
+		}
+
+		if timeline != nil {
+			timeline.Close()
+		}
+
+		// Captures logging to pcapng still have to stream their files back, so let them finish
+		if cc.outputPcap == "" {
+			out.Close()
+			os.Exit(1)
+		}
 	}()
 
+	failures := client.NewFailures()
+
 	var wg sync.WaitGroup
 	for _, node := range targetNodes {
 		wg.Add(1)
 
 		name, ip := node.GetName(), k8sapi.RetrieveInternalIP(node)
 
-		c, closeClient := client.CreateConnection(ip)
-		defer closeClient()
-
 		ctx := &client.ReqContext{
 			Server: client.Node{
 				Name: name,
 				Ip:   ip,
 			},
-			Wg: &wg,
+			Wg:       &wg,
+			Timeline: timeline,
+			Sessions: sessions,
+			Output:   out,
+			Failures: failures,
+			Search:   cc.search,
+		}
+
+		c, closeClient, err := client.CreateConnection(ctx.Server, cc.getConnOptions())
+		if err != nil {
+			ctx.Done(err)
+			continue
+		}
+		defer closeClient()
+
+		filters, ok := nodeFilters[name]
+		if !ok {
+			filters = cc.getFilters()
 		}
 
 		req := &pb.CaptureRequest{
 			Duration: cc.time,
 			Modifier: cc.getModifiers(hostMap[name]),
-			Filter:   cc.getFilters(),
+			Filter:   filters,
+			Artifact: pb.ArtifactFormat(pb.ArtifactFormat_value[cc.toNode]),
 		}
 
-		go client.RunCaptureStream(c, req, ctx)
+		if cc.outputPcap != "" {
+			go client.RunPcapStream(c, req, ctx, client.PcapFileName(cc.outputPcap, name, len(targetNodes) > 1))
+		} else {
+			go client.RunCaptureStream(c, req, ctx)
+		}
 	}
 
 	wg.Wait()
+
+	return failures.Err()
+}
+
+// Returns the pods named or matched by --selector, --deployment and --service
+func (cc *captureCmd) getPods(names []string) ([]v1.Pod, error) {
+	namespace := cc.namespace
+	if cc.allNamespaces {
+		namespace = metav1.NamespaceAll
+	} else if _, err := k8sclient.GetNamespace(namespace); err != nil {
+		return nil, err
+	}
+
+	var pods []v1.Pod
+	for _, name := range names {
+		pod, err := k8sclient.GetPod(name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, *pod)
+	}
+
+	selectors := []string{}
+	if cc.selector != "" {
+		selectors = append(selectors, cc.selector)
+	}
+
+	for _, name := range cc.deployments {
+		deployment, err := k8sclient.GetDeployment(name, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		selector, err := k8sapi.DeploymentSelector(deployment)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+	}
+
+	for _, name := range cc.services {
+		service, err := k8sclient.GetService(name, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		selector, err := k8sapi.ServiceSelector(service)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+	}
+
+	for _, selector := range selectors {
+		list, err := k8sclient.ListPods(namespace, selector)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(list.Items) == 0 {
+			log.Printf("No pods matched selector '%s'.\n", selector)
+		}
+		pods = append(pods, list.Items...)
+	}
+
+	return pods, nil
 }
 
+// Looks up the service's addresses and backends in --namespace
+func (cc *captureCmd) resolveService(name string) error {
+	service, err := k8sclient.GetService(name, cc.namespace)
+	if err != nil {
+		return err
+	}
+
+	slices, err := k8sclient.ListEndpointSlices(name, cc.namespace)
+	if err != nil {
+		return err
+	}
+
+	endpoints := k8sapi.ResolveService(service, slices.Items)
+	if cc.nodePorts && len(endpoints.NodePorts) == 0 {
+		log.Printf("Service %s doesn't have any NodePorts.\n", name)
+	}
+	cc.service = &endpoints
+
+	return nil
+}
+
+// Returns the filters passed as flags
 func (cc *captureCmd) getFilters() *pb.Filters {
-	return &pb.Filters{
-		Ips:       cc.ips,
-		Protocols: cc.protocols,
-		Ports:     cc.ports,
-		Macs:      cc.macs,
+	ips, ipPairs := client.SplitFilterPairs(cc.ips)
+	ports, portPairs := client.SplitFilterPairs(cc.ports)
+	macs, macPairs := client.SplitFilterPairs(cc.macs)
+
+	vlans := []uint32{}
+	for _, vlan := range cc.vlans {
+		vlans = append(vlans, uint32(vlan))
+	}
+
+	filters := &pb.Filters{
+		Ips:        ips,
+		Protocols:  cc.protocols,
+		Ports:      ports,
+		Macs:       macs,
+		IpPairs:    ipPairs,
+		PortPairs:  portPairs,
+		MacPairs:   macPairs,
+		Ethertypes: cc.ethertypes,
+		Vlans:      vlans,
+		Heartbeat:  cc.heartbeat,
+		Encap:      cc.encap,
+		VxlanPort:  cc.vxlanPort,
+	}
+	sets := []*pb.Filters{filters}
+
+	// A filter expression replaces the other filters, apart from how encapsulated packets are matched
+	if len(cc.filterSets) > 0 {
+		filters, sets = &pb.Filters{}, []*pb.Filters{}
+		for _, set := range cc.filterSets {
+			set = proto.Clone(set).(*pb.Filters)
+			set.Encap, set.VxlanPort = cc.encap, cc.vxlanPort
+			sets = append(sets, set)
+		}
+		filters.Sets = sets
 	}
+
+	return filters
 }
 
 func (cc *captureCmd) getModifiers(pods []string) *pb.Modifiers {
@@ -180,23 +468,71 @@ func (cc *captureCmd) validateArgs() {
 		log.Fatal(err)
 	}
 
-	if err := client.ValidateIPAddrs(cc.ips); err != nil {
-		log.Fatal(err)
+	if cc.filter != "" {
+		if len(cc.ips)+len(cc.protocols)+len(cc.ports)+len(cc.macs)+len(cc.ethertypes)+len(cc.vlans) > 0 || cc.heartbeat {
+			log.Fatal("--filter can't be used with --ips, --protocols, --ports, --macs, --ethertypes, --vlans or --heartbeat")
+		}
+
+		sets, err := client.ParseFilter(cc.filter)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cc.filterSets = sets
 	}
 
-	if err := client.ValidateProtocols(cc.protocols); err != nil {
+	// Services aren't resolved yet, so only the filters passed as flags are checked
+	if err := client.ValidateFilters(cc.getFilters()); err != nil {
 		log.Fatal(err)
 	}
 
-	if err := client.ValidatePorts(cc.ports); err != nil {
+	if err := client.ValidatePktType(cc.packetType); err != nil {
 		log.Fatal(err)
 	}
 
-	if err := client.ValidateMACAddrs(cc.macs); err != nil {
-		log.Fatal(err)
+	if cc.outputPcap != "" && cc.countersOnly {
+		log.Fatal("--output-pcap can't be used with --counters-only")
 	}
 
-	if err := client.ValidatePktType(cc.packetType); err != nil {
-		log.Fatal(err)
+	if cc.toNode != "" {
+		if format, ok := pb.ArtifactFormat_value[cc.toNode]; !ok || format == int32(pb.ArtifactFormat_none) {
+			log.Fatal("--to-node must be etl or pcapng")
+		}
+
+		if cc.outputPcap != "" || cc.countersOnly || cc.merge {
+			log.Fatal("--to-node can't be used with --output-pcap, --counters-only or --merge")
+		}
+	}
+
+	if cc.merge && cc.outputPcap != "" {
+		log.Fatal("--merge can't be used with --output-pcap")
+	}
+
+	if cc.merge && cc.output != "" {
+		log.Fatal("--merge can't be used with --output")
+	}
+
+	// YAML and tables are only written once every node is done, so streamed packets would pile up in memory until then
+	if (cc.output == "yaml" || cc.output == "table") && cc.outputPcap == "" && !cc.countersOnly {
+		log.Fatalf("-o %s can't be used for streaming captures, use -o json to write each result as it arrives", cc.output)
+	}
+
+	if cc.match != "" || cc.grep != "" {
+		if cc.outputPcap != "" || cc.countersOnly || cc.toNode != "" {
+			log.Fatal("--match and --grep can't be used with --output-pcap, --counters-only or --to-node")
+		}
+
+		// Matches are only highlighted for people reading them
+		stat, err := os.Stdout.Stat()
+		terminal := err == nil && stat.Mode()&os.ModeCharDevice != 0
+
+		search, err := client.NewSearch(cc.match, cc.grep, terminal && cc.output == "")
+		if err != nil {
+			log.Fatal(err)
+		}
+		cc.search = search
+	}
+
+	if cc.reorderWindow < 0 {
+		log.Fatal("--reorder-window can't be negative")
 	}
 }
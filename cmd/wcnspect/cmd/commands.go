@@ -6,9 +6,15 @@ package cmd
 import (
 	"fmt"
 	"log"
+	"os"
+	"strconv"
+	"strings"
 
+	"github.com/microsoft/wcnspect/common"
+	"github.com/microsoft/wcnspect/pkg/client"
 	"github.com/microsoft/wcnspect/pkg/comprise"
 	"github.com/microsoft/wcnspect/pkg/k8sapi"
+	"github.com/microsoft/wcnspect/pkg/tlsutil"
 	"github.com/spf13/cobra"
 
 	v1 "k8s.io/api/core/v1"
@@ -39,6 +45,11 @@ func (b *commandsBuilder) addAll() *commandsBuilder {
 		b.newCounterCmd(),
 		b.newHnsCmd(),
 		b.newVfpCounterCmd(),
+		b.newVfpRulesCmd(),
+		b.newSessionsCmd(),
+		b.newServicesCmd(),
+		b.newDoctorCmd(),
+		b.newFetchCmd(),
 	)
 
 	return b
@@ -88,7 +99,24 @@ func (b *commandsBuilder) newwcnspectCmd() *wcnspectCmd {
 		Long:  `An advanced distributed packet capture and HNS log collection tool made with Go (^_^)`,
 	})
 	cc.cmd.PersistentFlags().StringVar(&cc.kubeconfig, "kubeconfig", "", "Specify absolute path to the kubeconfig file.")
+	cc.cmd.PersistentFlags().StringVar(&cc.caFile, "ca", "", "Connect to servers over TLS, verifying their certificates against the CA certificates in this file.")
+	cc.cmd.PersistentFlags().StringVar(&cc.certFile, "cert", "", "Client certificate to present to servers requiring mutual TLS. Requires --key.")
+	cc.cmd.PersistentFlags().StringVar(&cc.keyFile, "key", "", "Key of the client certificate passed with --cert.")
+	cc.cmd.PersistentFlags().StringVar(&cc.serverName, "tls-server-name", "", "Name expected in the servers' certificates instead of each node's IP.")
+	cc.cmd.PersistentFlags().StringVar(&cc.serverNamespace, "server-namespace", common.ServerNamespace, "Namespace of the wcnspect server pods, used to find each node's server.")
+	cc.cmd.PersistentFlags().StringVar(&cc.serverSelector, "server-selector", common.ServerSelector, "Label selector of the wcnspect server pods, used to find each node's server.")
+	cc.cmd.PersistentFlags().StringVar(&cc.serverPort, "server-port", common.DefaultServerPort, "Port to connect to when it can't be found from the server pods.")
+	cc.cmd.PersistentFlags().BoolVar(&cc.tunnel, "tunnel", false, "Reach servers through port-forwards made via the Kubernetes API server instead of node IPs.")
+	cc.cmd.PersistentFlags().StringVar(&cc.token, "token", "", "Bearer token sent to servers requiring authorization.")
+	cc.cmd.PersistentFlags().StringVar(&cc.tokenFile, "token-file", "", "Read the bearer token sent to servers from this file.")
+	cc.cmd.PersistentFlags().StringVarP(&cc.output, "output", "o", "", fmt.Sprintf("Write a result object for each node in a machine-readable format: %s.", strings.Join(client.OutputFormats, ", ")))
 	cc.cmd.CompletionOptions.DisableDefaultCmd = true
+
+	// Failed requests are summarized by Execute, so only show usage for invalid flags and arguments
+	cc.cmd.SilenceErrors = true
+	cc.cmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		cmd.SilenceUsage = true
+	}
 	cc.initializeAKSClusterValues()
 
 	return cc
@@ -96,15 +124,39 @@ func (b *commandsBuilder) newwcnspectCmd() *wcnspectCmd {
 
 type wcnspectBuilderCommon struct {
 	kubeconfig string
+	output     string
+
+	// TLS
+	caFile     string
+	certFile   string
+	keyFile    string
+	serverName string
+	connOpts   *client.ConnOptions
+
+	// Authorization
+	token     string
+	tokenFile string
+
+	// Server discovery
+	serverNamespace string
+	serverSelector  string
+	serverPort      string
+	tunnel          bool
 
 	winNodeNames map[string]v1.Node // node name -> v1.Node
 }
 
 func (cc *wcnspectBuilderCommon) initializeAKSClusterValues() {
-	k8sclient = k8sapi.New(cc.kubeconfig)
+	var err error
+	if k8sclient, err = k8sapi.New(cc.kubeconfig); err != nil {
+		log.Fatal(err)
+	}
 
 	// Pull windows nodes
-	nodes := k8sclient.GetAllNodesWindows()
+	nodes, err := k8sclient.GetAllNodesWindows()
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// Pull pods while creating mapping of node : pods
 	cc.winNodeNames = make(map[string]v1.Node)
@@ -144,6 +196,81 @@ func (cc *wcnspectBuilderCommon) getWinNodes() []v1.Node {
 	return comprise.Values(cc.winNodeNames)
 }
 
+// Returns the machine-readable output requested with --output, or nil if results should be printed as usual
+func (cc *wcnspectBuilderCommon) getOutput() *client.Output {
+	if cc.output == "" {
+		return nil
+	}
+
+	out, err := client.NewOutput(cc.output, os.Stdout)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return out
+}
+
+// Returns how to connect to servers: where each node's server listens, whether to use TLS and any token to send
+func (cc *wcnspectBuilderCommon) getConnOptions() client.ConnOptions {
+	if cc.connOpts != nil {
+		return *cc.connOpts
+	}
+
+	if _, err := strconv.ParseUint(cc.serverPort, 10, 16); err != nil {
+		log.Fatalf("invalid server port: %s", cc.serverPort)
+	}
+
+	cc.connOpts = &client.ConnOptions{}
+	if cc.caFile != "" || cc.certFile != "" || cc.keyFile != "" || cc.serverName != "" {
+		config, err := tlsutil.ClientConfig(cc.caFile, cc.certFile, cc.keyFile, cc.serverName)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		cc.connOpts.TLS = config
+	}
+
+	// Connect to wherever the server pods say they listen, falling back to node IPs if there aren't any
+	cc.connOpts.Port = cc.serverPort
+	servers, err := client.DiscoverServers(&k8sclient, cc.serverNamespace, cc.serverSelector, cc.serverPort)
+	switch {
+	case err != nil:
+		log.Printf("%v, connecting to node IPs on port %s.\n", err, cc.serverPort)
+	case servers.Len() == 0:
+		log.Printf("No wcnspect server pods matching '%s' found in namespace %s, connecting to node IPs on port %s.\n", cc.serverSelector, cc.serverNamespace, cc.serverPort)
+	default:
+		cc.connOpts.Servers = servers
+	}
+
+	if cc.tunnel {
+		if cc.connOpts.Servers == nil {
+			log.Fatal("--tunnel requires wcnspect server pods to be found")
+		}
+
+		cc.connOpts.Tunnel = client.NewTunnel(&k8sclient, cc.connOpts.Servers)
+	}
+
+	cc.connOpts.Token = cc.token
+	if cc.tokenFile != "" {
+		if cc.token != "" {
+			log.Fatal("--token can't be used with --token-file")
+		}
+
+		b, err := os.ReadFile(cc.tokenFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		cc.connOpts.Token = strings.TrimSpace(string(b))
+	}
+
+	if cc.connOpts.Token != "" && cc.connOpts.TLS == nil && cc.connOpts.Tunnel == nil {
+		log.Fatal("--token and --token-file require TLS (--ca) or --tunnel, so that the token isn't sent in plaintext")
+	}
+
+	return *cc.connOpts
+}
+
 func (cc *wcnspectBuilderCommon) getWinNodeNames() []string {
 	return comprise.Keys(cc.winNodeNames)
 }
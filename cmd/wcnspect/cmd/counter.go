@@ -17,6 +17,8 @@ import (
 type counterCmd struct {
 	nodes         []string
 	includeHidden bool
+	components    []string
+	sortBy        string
 
 	*baseBuilderCmd
 }
@@ -29,21 +31,24 @@ func (b *commandsBuilder) newCounterCmd() *counterCmd {
 		Short: "The 'counter' command will retrieve packet counter tables from all windows nodes.",
 		Long: `The 'counter' command will retrieve packet counter tables from all windows nodes. 
 	This command requires that a capture is being run on the requested nodes. For example:
-	'wcnspect counter --nodes {nodes} --include-hidden`,
-		Run: func(cmd *cobra.Command, args []string) {
-			cc.printCounters()
+	'wcnspect counter --nodes {nodes} --include-hidden'
+	'wcnspect counter --components {ids or names} --sort-by drops'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.printCounters()
 		},
 	}
 
 	cmd.PersistentFlags().StringSliceVarP(&cc.nodes, "nodes", "n", []string{}, "Specify which nodes wcnspect should send requests to using node names. Runs on all windows nodes by default.")
 	cmd.PersistentFlags().BoolVarP(&cc.includeHidden, "include-hidden", "i", false, "Show counters from components that are hidden by default.")
+	cmd.PersistentFlags().StringSliceVar(&cc.components, "components", []string{}, "Only show counters for these packet monitor components, given as IDs or name substrings.")
+	cmd.PersistentFlags().StringVar(&cc.sortBy, "sort-by", "", "Sort the counter table by one of: id, packets, bytes, drops.")
 
 	cc.baseBuilderCmd = b.newBuilderCmd(cmd)
 
 	return cc
 }
 
-func (cc *counterCmd) printCounters() {
+func (cc *counterCmd) printCounters() error {
 	targetNodes := cc.getWinNodes()
 
 	if len(cc.nodes) > 0 {
@@ -54,22 +59,46 @@ func (cc *counterCmd) printCounters() {
 		targetNodes = cc.getNodes(cc.nodes)
 	}
 
+	// The raw pktmon table is printed unless rows need to be selected or reordered
+	var view *client.CounterView
+	if len(cc.components) > 0 || cc.sortBy != "" {
+		if cc.sortBy != "" {
+			if err := client.ValidateCounterSortField(cc.sortBy); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		view = &client.CounterView{Components: cc.components, SortBy: cc.sortBy}
+	}
+
+	out := cc.getOutput()
+	defer out.Close()
+
+	failures := client.NewFailures()
+
 	var wg sync.WaitGroup
 	for _, node := range targetNodes {
 		wg.Add(1)
 
 		name, ip := node.GetName(), k8sapi.RetrieveInternalIP(node)
 
-		c, closeClient := client.CreateConnection(ip)
-		defer closeClient()
-
 		ctx := &client.ReqContext{
 			Server: client.Node{
 				Name: name,
 				Ip:   ip,
 			},
-			Wg: &wg,
+			Wg:       &wg,
+			Output:   out,
+			Counters: view,
+			Failures: failures,
+		}
+
+		c, closeClient, err := client.CreateConnection(ctx.Server, cc.getConnOptions())
+		if err != nil {
+			ctx.Done(err)
+			continue
 		}
+		defer closeClient()
 
 		req := &pb.CountersRequest{
 			IncludeHidden: cc.includeHidden,
@@ -79,4 +108,6 @@ func (cc *counterCmd) printCounters() {
 	}
 
 	wg.Wait()
+
+	return failures.Err()
 }
@@ -0,0 +1,107 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package cmd
+
+import (
+	"log"
+	"sync"
+
+	"github.com/microsoft/wcnspect/pkg/client"
+	"github.com/microsoft/wcnspect/pkg/k8sapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/spf13/cobra"
+)
+
+type doctorCmd struct {
+	nodes []string
+
+	*baseBuilderCmd
+}
+
+func (b *commandsBuilder) newDoctorCmd() *doctorCmd {
+	cc := &doctorCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "The 'doctor' command will check windows nodes for common networking problems.",
+		Long: `The 'doctor' command will check windows nodes for common networking problems. For example:
+	'wcnspect doctor endpoints --nodes {nodes}'.`,
+	}
+
+	checkTypes := []string{"endpoints"}
+	checkHelp := map[string]string{
+		"endpoints": "Compare the HNS endpoints on each node with the pods scheduled on it, reporting orphaned endpoints, pods without endpoints and duplicate IPs.",
+	}
+	for _, name := range checkTypes {
+		subcmd := &cobra.Command{
+			Use:   name,
+			Short: checkHelp[name],
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return cc.printEndpoints()
+			},
+		}
+
+		cmd.AddCommand(subcmd)
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&cc.nodes, "nodes", []string{}, "Specify which nodes wcnspect should send requests to using node names. Runs on all windows nodes by default.")
+
+	cc.baseBuilderCmd = b.newBuilderCmd(cmd)
+
+	return cc
+}
+
+func (cc *doctorCmd) printEndpoints() error {
+	targetNodes := cc.getWinNodes()
+
+	if len(cc.nodes) != 0 {
+		if err := client.ValidateNodes(cc.nodes, cc.getWinNodeNames()); err != nil {
+			log.Fatal(err)
+		}
+
+		targetNodes = cc.getNodes(cc.nodes)
+	}
+
+	// Pods on every node are needed to tell endpoints of pods scheduled elsewhere and duplicate IPs apart
+	pods, err := k8sclient.ListPods(metav1.NamespaceAll, "")
+	if err != nil {
+		return err
+	}
+
+	out := cc.getOutput()
+	defer out.Close()
+
+	failures := client.NewFailures()
+
+	var wg sync.WaitGroup
+	for _, node := range targetNodes {
+		wg.Add(1)
+
+		name, ip := node.GetName(), k8sapi.RetrieveInternalIP(node)
+
+		ctx := &client.ReqContext{
+			Server: client.Node{
+				Name: name,
+				Ip:   ip,
+			},
+			Wg:       &wg,
+			Output:   out,
+			Failures: failures,
+		}
+
+		c, closeClient, err := client.CreateConnection(ctx.Server, cc.getConnOptions())
+		if err != nil {
+			ctx.Done(err)
+			continue
+		}
+		defer closeClient()
+
+		go client.PrintEndpointIssues(c, pods.Items, ctx)
+	}
+
+	wg.Wait()
+
+	return failures.Err()
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package cmd
+
+import (
+	"log"
+	"os"
+	"sync"
+
+	"github.com/microsoft/wcnspect/pkg/client"
+	"github.com/microsoft/wcnspect/pkg/k8sapi"
+
+	"github.com/spf13/cobra"
+)
+
+type fetchCmd struct {
+	nodes []string
+	list  bool
+	all   bool
+	dir   string
+
+	*baseBuilderCmd
+}
+
+func (b *commandsBuilder) newFetchCmd() *fetchCmd {
+	cc := &fetchCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "fetch",
+		Short: "The 'fetch' command will download the files captures were logged to on windows nodes.",
+		Long: `The 'fetch' command will download the files captures were logged to on windows nodes, as started with 'wcnspect capture --to-node'. For example:
+	'wcnspect fetch --list --nodes {nodes}'
+	'wcnspect fetch {session ids or file names} --dir {directory}'
+	'wcnspect fetch --all --nodes {nodes}'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.fetch(args)
+		},
+	}
+
+	cmd.PersistentFlags().StringSliceVarP(&cc.nodes, "nodes", "n", []string{}, "Specify which nodes wcnspect should send requests to using node names. Runs on all windows nodes by default.")
+	cmd.PersistentFlags().BoolVar(&cc.list, "list", false, "List the capture files on each node instead of downloading them.")
+	cmd.PersistentFlags().BoolVar(&cc.all, "all", false, "Download every complete capture file.")
+	cmd.PersistentFlags().StringVar(&cc.dir, "dir", ".", "Directory the files are downloaded to, each prefixed with the name of its node.")
+
+	cc.baseBuilderCmd = b.newBuilderCmd(cmd)
+
+	return cc
+}
+
+func (cc *fetchCmd) fetch(names []string) error {
+	if !cc.list && !cc.all && len(names) == 0 {
+		log.Fatal("must pass session IDs or file names, --all or --list when using 'wcnspect fetch'")
+	}
+
+	if cc.all && len(names) > 0 {
+		log.Fatal("--all can't be used with session IDs or file names")
+	}
+
+	if !cc.list {
+		if err := os.MkdirAll(cc.dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	targetNodes := cc.getWinNodes()
+
+	if len(cc.nodes) != 0 {
+		if err := client.ValidateNodes(cc.nodes, cc.getWinNodeNames()); err != nil {
+			log.Fatal(err)
+		}
+
+		targetNodes = cc.getNodes(cc.nodes)
+	}
+
+	out := cc.getOutput()
+	defer out.Close()
+
+	failures := client.NewFailures()
+
+	var wg sync.WaitGroup
+	for _, node := range targetNodes {
+		wg.Add(1)
+
+		name, ip := node.GetName(), k8sapi.RetrieveInternalIP(node)
+
+		ctx := &client.ReqContext{
+			Server: client.Node{
+				Name: name,
+				Ip:   ip,
+			},
+			Wg:       &wg,
+			Output:   out,
+			Failures: failures,
+		}
+
+		c, closeClient, err := client.CreateConnection(ctx.Server, cc.getConnOptions())
+		if err != nil {
+			ctx.Done(err)
+			continue
+		}
+		defer closeClient()
+
+		if cc.list {
+			go client.PrintArtifacts(c, ctx)
+		} else {
+			go client.FetchArtifacts(c, names, cc.all, cc.dir, ctx)
+		}
+	}
+
+	wg.Wait()
+
+	return failures.Err()
+}
@@ -43,8 +43,8 @@ func (b *commandsBuilder) newHnsCmd() *hnsCmd {
 		subcmd := &cobra.Command{
 			Use:   name,
 			Short: logHelp[name],
-			Run: func(cmd *cobra.Command, args []string) {
-				cc.printLogs(cmd.Name())
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return cc.printLogs(cmd.Name())
 			},
 		}
 
@@ -59,7 +59,7 @@ func (b *commandsBuilder) newHnsCmd() *hnsCmd {
 	return cc
 }
 
-func (cc *hnsCmd) printLogs(subcmd string) {
+func (cc *hnsCmd) printLogs(subcmd string) error {
 	targetNodes := cc.getWinNodes()
 
 	if len(cc.nodes) != 0 {
@@ -70,23 +70,34 @@ func (cc *hnsCmd) printLogs(subcmd string) {
 		targetNodes = cc.getNodes(cc.nodes)
 	}
 
+	out := cc.getOutput()
+	defer out.Close()
+
+	failures := client.NewFailures()
+
 	var wg sync.WaitGroup
 	for _, node := range targetNodes {
 		wg.Add(1)
 
 		name, ip := node.GetName(), k8sapi.RetrieveInternalIP(node)
 
-		c, closeClient := client.CreateConnection(ip)
-		defer closeClient()
-
 		ctx := &client.ReqContext{
 			Server: client.Node{
 				Name: name,
 				Ip:   ip,
 			},
-			Wg: &wg,
+			Wg:       &wg,
+			Output:   out,
+			Failures: failures,
 		}
 
+		c, closeClient, err := client.CreateConnection(ctx.Server, cc.getConnOptions())
+		if err != nil {
+			ctx.Done(err)
+			continue
+		}
+		defer closeClient()
+
 		req := &pb.HCNRequest{
 			Hcntype: pb.HCNType(pb.HCNType_value[subcmd]),
 			Verbose: cc.verbose,
@@ -96,4 +107,6 @@ func (cc *hnsCmd) printLogs(subcmd string) {
 	}
 
 	wg.Wait()
+
+	return failures.Err()
 }
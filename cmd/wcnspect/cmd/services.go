@@ -0,0 +1,153 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/microsoft/wcnspect/pkg/client"
+	"github.com/microsoft/wcnspect/pkg/comprise"
+	"github.com/microsoft/wcnspect/pkg/k8sapi"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/spf13/cobra"
+)
+
+type servicesCmd struct {
+	nodes     []string
+	namespace string
+
+	*baseBuilderCmd
+}
+
+func (b *commandsBuilder) newServicesCmd() *servicesCmd {
+	cc := &servicesCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "services [names]",
+		Short: "The 'services' command will check that Kubernetes services are programmed in HNS on all windows nodes.",
+		Long: `The 'services' command will check that Kubernetes services are programmed in HNS on all windows nodes,
+comparing each node's HNS load balancers with the services' VIPs, ports and EndpointSlices. For example:
+	'wcnspect services web,api -n shop --nodes {nodes}'.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.printServices(args)
+		},
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&cc.nodes, "nodes", []string{}, "Specify which nodes wcnspect should send requests to using node names. Runs on all windows nodes by default.")
+	cmd.PersistentFlags().StringVarP(&cc.namespace, "namespace", "n", metav1.NamespaceAll, "Specify Kubernetes namespace to check services in. Checks every namespace by default.")
+
+	cc.baseBuilderCmd = b.newBuilderCmd(cmd)
+
+	return cc
+}
+
+func (cc *servicesCmd) printServices(args []string) error {
+	targetNodes := cc.getWinNodes()
+
+	if len(cc.nodes) != 0 {
+		if err := client.ValidateNodes(cc.nodes, cc.getWinNodeNames()); err != nil {
+			log.Fatal(err)
+		}
+
+		targetNodes = cc.getNodes(cc.nodes)
+	}
+
+	services, err := cc.getServiceSpecs(args)
+	if err != nil {
+		return err
+	}
+
+	out := cc.getOutput()
+	defer out.Close()
+
+	failures := client.NewFailures()
+
+	var wg sync.WaitGroup
+	for _, node := range targetNodes {
+		wg.Add(1)
+
+		name, ip := node.GetName(), k8sapi.RetrieveInternalIP(node)
+
+		ctx := &client.ReqContext{
+			Server: client.Node{
+				Name: name,
+				Ip:   ip,
+			},
+			Wg:       &wg,
+			Output:   out,
+			Failures: failures,
+		}
+
+		c, closeClient, err := client.CreateConnection(ctx.Server, cc.getConnOptions())
+		if err != nil {
+			ctx.Done(err)
+			continue
+		}
+		defer closeClient()
+
+		go client.PrintServices(c, services, ctx)
+	}
+
+	wg.Wait()
+
+	return failures.Err()
+}
+
+// Returns the specs of the load balanced services in --namespace, or only of those named
+func (cc *servicesCmd) getServiceSpecs(args []string) ([]client.ServiceSpec, error) {
+	var names []string
+	if len(args) > 0 {
+		names = strings.Split(args[0], ",")
+	}
+
+	services, err := k8sclient.ListServices(cc.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	slices, err := k8sclient.ListAllEndpointSlices(cc.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// namespace/name -> EndpointSlices of the service
+	serviceSlices := map[string][]discoveryv1.EndpointSlice{}
+	for _, slice := range slices.Items {
+		key := slice.GetNamespace() + "/" + slice.GetLabels()[discoveryv1.LabelServiceName]
+		serviceSlices[key] = append(serviceSlices[key], slice)
+	}
+
+	ret := []client.ServiceSpec{}
+	found := []string{}
+	for i := range services.Items {
+		service := &services.Items[i]
+		if len(names) > 0 && !comprise.Contains(names, service.GetName()) {
+			continue
+		}
+		found = append(found, service.GetName())
+
+		if spec, ok := client.NewServiceSpec(service, serviceSlices[service.GetNamespace()+"/"+service.GetName()]); ok {
+			ret = append(ret, spec)
+		}
+	}
+
+	for _, name := range names {
+		if !comprise.Contains(found, name) {
+			return nil, fmt.Errorf("service %s not found", name)
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil, errors.New("no load balanced services found")
+	}
+
+	return ret, nil
+}
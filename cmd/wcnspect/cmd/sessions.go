@@ -0,0 +1,137 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package cmd
+
+import (
+	"log"
+	"sync"
+
+	"github.com/microsoft/wcnspect/pkg/client"
+	"github.com/microsoft/wcnspect/pkg/k8sapi"
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"github.com/spf13/cobra"
+)
+
+type sessionsCmd struct {
+	nodes []string
+
+	*baseBuilderCmd
+}
+
+func (b *commandsBuilder) newSessionsCmd() *sessionsCmd {
+	cc := &sessionsCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "sessions",
+		Short: "The 'sessions' command will list or stop capture sessions running on windows nodes.",
+		Long: `The 'sessions' command will list or stop capture sessions running on windows nodes. For example:
+	'wcnspect sessions list --nodes {nodes}'
+	'wcnspect sessions stop {session id} --nodes {node}'
+	'wcnspect sessions status --nodes {nodes}'`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the capture sessions running on each node.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.printSessions(client.PrintCaptures)
+		},
+	}
+
+	stopCmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stop the capture session with the given ID on each node.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.stopSession(args[0])
+		},
+	}
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show how long each node's server has been running, and what it cleaned up after its previous run.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.printSessions(client.PrintServerStatus)
+		},
+	}
+
+	cmd.AddCommand(listCmd, stopCmd, statusCmd)
+	cmd.PersistentFlags().StringSliceVarP(&cc.nodes, "nodes", "n", []string{}, "Specify which nodes wcnspect should send requests to using node names. Runs on all windows nodes by default.")
+
+	cc.baseBuilderCmd = b.newBuilderCmd(cmd)
+
+	return cc
+}
+
+// Sends request to each node
+func (cc *sessionsCmd) printSessions(request func(pb.CaptureServiceClient, *client.ReqContext) error) error {
+	targetNodes := cc.getWinNodes()
+
+	if len(cc.nodes) != 0 {
+		if err := client.ValidateNodes(cc.nodes, cc.getWinNodeNames()); err != nil {
+			log.Fatal(err)
+		}
+
+		targetNodes = cc.getNodes(cc.nodes)
+	}
+
+	out := cc.getOutput()
+	defer out.Close()
+
+	failures := client.NewFailures()
+
+	var wg sync.WaitGroup
+	for _, node := range targetNodes {
+		wg.Add(1)
+
+		name, ip := node.GetName(), k8sapi.RetrieveInternalIP(node)
+
+		ctx := &client.ReqContext{
+			Server: client.Node{
+				Name: name,
+				Ip:   ip,
+			},
+			Wg:       &wg,
+			Output:   out,
+			Failures: failures,
+		}
+
+		c, closeClient, err := client.CreateConnection(ctx.Server, cc.getConnOptions())
+		if err != nil {
+			ctx.Done(err)
+			continue
+		}
+		defer closeClient()
+
+		go request(c, ctx)
+	}
+
+	wg.Wait()
+
+	return failures.Err()
+}
+
+func (cc *sessionsCmd) stopSession(id string) error {
+	targetNodes := cc.getWinNodes()
+
+	if len(cc.nodes) != 0 {
+		if err := client.ValidateNodes(cc.nodes, cc.getWinNodeNames()); err != nil {
+			log.Fatal(err)
+		}
+
+		targetNodes = cc.getNodes(cc.nodes)
+	}
+
+	// Session IDs are unique to a node, so the session is only stopped where it exists
+	sessions := client.NewSessions()
+	for _, node := range targetNodes {
+		sessions.Set(node.GetName(), id)
+	}
+
+	out := cc.getOutput()
+	defer out.Close()
+
+	return client.Cleanup(targetNodes, sessions, out, cc.getConnOptions())
+}
@@ -18,6 +18,7 @@ import (
 type vfpCounterCmd struct {
 	pod       string
 	verbose   bool
+	raw       bool
 	namespace string
 
 	*baseBuilderCmd
@@ -31,14 +32,16 @@ func (b *commandsBuilder) newVfpCounterCmd() *vfpCounterCmd {
 		Short: "The 'vfp-counter' command will retrieve packet counter tables from a specified windows pod's port VFP.",
 		Long: `The 'vfp-counter' command will retrieve packet counter tables from a specified windows pod's port VFP. 
 	For example:
-	'wcnspect vfp-counter --pod {pod}'`,
-		Run: func(cmd *cobra.Command, args []string) {
-			cc.printVFPCounters()
+	'wcnspect vfp-counter --pod {pod}'
+	'wcnspect vfp-counter --pod {pod} --detailed --raw'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.printVFPCounters()
 		},
 	}
 
 	cmd.PersistentFlags().StringVarP(&cc.pod, "pod", "p", "", "Specify which pod wcnspect should send requests to using pod name. This flag is required.")
 	cmd.PersistentFlags().BoolVarP(&cc.verbose, "detailed", "d", false, "Option to output Host vNic and External Adapter Port counters.")
+	cmd.PersistentFlags().BoolVar(&cc.raw, "raw", false, "Option to output the raw vfpctrl counter tables instead of a summary table.")
 	cmd.PersistentFlags().StringVar(&cc.namespace, "namespace", common.DefaultNamespace, "Optionally specify Kubernetes namespace to filter pods on.")
 	cmd.MarkPersistentFlagRequired("pod")
 
@@ -47,41 +50,60 @@ func (b *commandsBuilder) newVfpCounterCmd() *vfpCounterCmd {
 	return cc
 }
 
-func (cc *vfpCounterCmd) printVFPCounters() {
+func (cc *vfpCounterCmd) printVFPCounters() error {
 	// Read in pods
 	pods := []string{cc.pod}
 	// Namespace
-	ns := k8sclient.GetNamespace(cc.namespace)
+	ns, err := k8sclient.GetNamespace(cc.namespace)
+	if err != nil {
+		return err
+	}
 	// Loop over Pod, Node
 	var p *v1.Pod
 	var nodeName string
 	var wg sync.WaitGroup
 
+	out := cc.getOutput()
+	defer out.Close()
+
+	failures := client.NewFailures()
 	for _, podName := range pods {
-		p = k8sclient.GetPod(podName, ns.GetName())
+		if p, err = k8sclient.GetPod(podName, ns.GetName()); err != nil {
+			return err
+		}
 		nodeName = p.Spec.NodeName
 		podIP := p.Status.PodIP
 		if nodeName != "" {
 			wg.Add(1)
 			nodeIP := k8sapi.RetrieveInternalIP(cc.getNode(nodeName))
-			c, closeClient := client.CreateConnection(nodeIP)
-			defer closeClient()
 
 			ctx := &client.ReqContext{
 				Server: client.Node{
 					Name: nodeName,
 					Ip:   nodeIP,
 				},
-				Wg: &wg,
+				Wg:       &wg,
+				Output:   out,
+				Failures: failures,
 			}
 
+			c, closeClient, err := client.CreateConnection(ctx.Server, cc.getConnOptions())
+			if err != nil {
+				ctx.Done(err)
+				continue
+			}
+			defer closeClient()
+
 			req := &pb.VFPCountersRequest{
-				Pod:     podIP,
-				Verbose: cc.verbose,
+				Pod:        podIP,
+				Verbose:    cc.verbose,
+				IncludeRaw: cc.raw,
 			}
 
 			go client.PrintVFPCounters(c, req, ctx)
 		}
 	}
 	wg.Wait()
+
+	return failures.Err()
 }
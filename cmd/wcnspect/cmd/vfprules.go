@@ -0,0 +1,91 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/microsoft/wcnspect/common"
+	"github.com/microsoft/wcnspect/pkg/client"
+	"github.com/microsoft/wcnspect/pkg/k8sapi"
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"github.com/spf13/cobra"
+)
+
+type vfpRulesCmd struct {
+	pod       string
+	layers    []string
+	namespace string
+
+	*baseBuilderCmd
+}
+
+func (b *commandsBuilder) newVfpRulesCmd() *vfpRulesCmd {
+	cc := &vfpRulesCmd{}
+
+	cmd := &cobra.Command{
+		Use:   "vfp-rules",
+		Short: "The 'vfp-rules' command will retrieve the VFP layers, groups and rules of a specified windows pod's port.",
+		Long: `The 'vfp-rules' command will retrieve the VFP layers, groups and rules of a specified windows pod's port. 
+	For example:
+	'wcnspect vfp-rules --pod {pod}'
+	'wcnspect vfp-rules --pod {pod} --layers ACL_ENDPOINT_LAYER'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cc.printVFPRules()
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&cc.pod, "pod", "p", "", "Specify which pod wcnspect should send requests to using pod name. This flag is required.")
+	cmd.PersistentFlags().StringSliceVarP(&cc.layers, "layers", "l", []string{}, "Only retrieve the VFP layers with these names. Retrieves all layers by default.")
+	cmd.PersistentFlags().StringVar(&cc.namespace, "namespace", common.DefaultNamespace, "Optionally specify Kubernetes namespace to filter pods on.")
+	cmd.MarkPersistentFlagRequired("pod")
+
+	cc.baseBuilderCmd = b.newBuilderCmd(cmd)
+
+	return cc
+}
+
+func (cc *vfpRulesCmd) printVFPRules() error {
+	ns, err := k8sclient.GetNamespace(cc.namespace)
+	if err != nil {
+		return err
+	}
+
+	p, err := k8sclient.GetPod(cc.pod, ns.GetName())
+	if err != nil {
+		return err
+	}
+
+	nodeName := p.Spec.NodeName
+	if nodeName == "" {
+		return fmt.Errorf("pod %s is not scheduled on a node", cc.pod)
+	}
+
+	nodeIP := k8sapi.RetrieveInternalIP(cc.getNode(nodeName))
+
+	out := cc.getOutput()
+	defer out.Close()
+
+	ctx := &client.ReqContext{
+		Server: client.Node{
+			Name: nodeName,
+			Ip:   nodeIP,
+		},
+		Output: out,
+	}
+
+	c, closeClient, err := client.CreateConnection(ctx.Server, cc.getConnOptions())
+	if err != nil {
+		return ctx.Done(err)
+	}
+	defer closeClient()
+
+	req := &pb.VFPRulesRequest{
+		Pod:    p.Status.PodIP,
+		Layers: cc.layers,
+	}
+
+	return client.PrintVFPRules(c, req, ctx)
+}
@@ -17,7 +17,7 @@ func Execute() {
 	cmd := wcnspectCmd.getCommand()
 
 	if err := cmd.Execute(); err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
@@ -4,26 +4,59 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
 	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/microsoft/wcnspect/common"
+	"github.com/microsoft/wcnspect/pkg/runner"
 	"github.com/microsoft/wcnspect/pkg/server"
+	"github.com/microsoft/wcnspect/pkg/tlsutil"
 	pb "github.com/microsoft/wcnspect/rpc"
 
 	flag "github.com/spf13/pflag"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 func main() {
 	// User input variables
-	var port string
+	var port, healthPort, hnsBackend string
+	var certFile, keyFile, clientCAFile string
+	var authConfig, kubeconfig string
+	var artifactDir, journalPath string
+	var artifactMaxBytes int64
+	var artifactMaxAge, artifactPruneInterval time.Duration
+	var healthInterval, shutdownTimeout time.Duration
 
 	// Flags
 	flag.StringVarP(&port, "port", "p", common.DefaultServerPort, "Specify port for server to listen on.")
+	flag.StringVar(&hnsBackend, "hns-backend", "hcn", "Specify how HNS objects are retrieved: through the HCN API (hcn) or by running hnsdiag (hnsdiag).")
+	flag.StringVar(&certFile, "tls-cert", "", "Serve TLS using this certificate. Requires --tls-key. The certificate is reloaded when the file changes.")
+	flag.StringVar(&keyFile, "tls-key", "", "Key of the certificate passed with --tls-cert.")
+	flag.StringVar(&clientCAFile, "client-ca", "", "Require clients to present a certificate signed by one of the CAs in this file (mutual TLS). Requires --tls-cert.")
+	flag.StringVar(&authConfig, "auth-config", "", "Require callers to send a bearer token granted permissions in this file.")
+	flag.StringVar(&kubeconfig, "kubeconfig", "", "Kubeconfig used to review ServiceAccount tokens. Uses the in-cluster config by default.")
+	flag.StringVar(&artifactDir, "artifact-dir", filepath.Join(os.TempDir(), "wcnspect"), "Directory captures logged to files on the node are kept in.")
+	flag.Int64Var(&artifactMaxBytes, "artifact-max-bytes", 1<<30, "Delete the oldest capture files once they take up more bytes than this. 0 for no limit.")
+	flag.DurationVar(&artifactMaxAge, "artifact-max-age", 24*time.Hour, "Delete capture files older than this. 0 for no limit.")
+	flag.DurationVar(&artifactPruneInterval, "artifact-prune-interval", 5*time.Minute, "How often capture files are checked against --artifact-max-bytes and --artifact-max-age.")
+	flag.StringVar(&journalPath, "journal", filepath.Join(os.TempDir(), "wcnspect", "sessions.json"), "File running capture sessions are journaled to, so that pktmon can be cleaned up after them if the server dies.")
+	flag.StringVar(&healthPort, "health-port", "", "Also serve the gRPC health service alone on this port, in plaintext and without auth, for probes that can't use the server's TLS certificate.")
+	flag.DurationVar(&healthInterval, "health-interval", 30*time.Second, "How often the tools the server relies on are checked for the gRPC health service.")
+	flag.DurationVar(&shutdownTimeout, "shutdown-timeout", 20*time.Second, "How long running captures and requests are given to finish once the server is asked to terminate.")
 	flag.Parse()
 
 	// Input validation
@@ -31,19 +64,168 @@ func main() {
 		log.Fatalf("Supplied value was not a valid port.")
 	}
 
+	if healthPort != "" {
+		if _, err := strconv.Atoi(healthPort); err != nil || healthPort == port {
+			log.Fatalf("--health-port must be a valid port other than --port.")
+		}
+	}
+
+	if (certFile == "") != (keyFile == "") {
+		log.Fatalf("--tls-cert and --tls-key must be passed together.")
+	}
+
+	if clientCAFile != "" && certFile == "" {
+		log.Fatalf("--client-ca requires --tls-cert and --tls-key.")
+	}
+
+	if healthInterval <= 0 {
+		log.Fatalf("--health-interval must be positive.")
+	}
+
+	if artifactPruneInterval <= 0 {
+		log.Fatalf("--artifact-prune-interval must be positive.")
+	}
+
+	backend, err := server.NewHNSBackend(hnsBackend, runner.Shell{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	artifacts, err := server.NewArtifactStore(artifactDir, artifactMaxBytes, artifactMaxAge)
+	if err != nil {
+		log.Fatal(err)
+	}
+	go artifacts.Run(context.Background(), artifactPruneInterval)
+
+	journal, err := server.OpenJournal(journalPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts := []grpc.ServerOption{}
+	unary, stream := []grpc.UnaryServerInterceptor{}, []grpc.StreamServerInterceptor{}
+
+	if certFile != "" {
+		config, err := tlsutil.ServerConfig(certFile, keyFile, clientCAFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		opts = append(opts, grpc.Creds(credentials.NewTLS(config)))
+	} else {
+		log.Printf("No TLS certificate was passed, serving plaintext. Anyone who can reach the port can run captures.\n")
+	}
+
+	if authConfig != "" {
+		authorizer, err := newAuthorizer(authConfig, kubeconfig)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		unary, stream = append(unary, authorizer.UnaryInterceptor()), append(stream, authorizer.StreamInterceptor())
+	} else {
+		log.Printf("No auth config was passed, requests won't be authorized.\n")
+	}
+
 	listener, err := net.Listen("tcp", "0.0.0.0:"+port)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 	fmt.Printf("Server started on port %s\n", port)
-	s := grpc.NewServer()
-	pb.RegisterCaptureServiceServer(s, &server.CaptureServer{})
-	pb.RegisterHCNServiceServer(s, &server.HcnServer{})
+	opts = append(opts, grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...))
+	s := grpc.NewServer(opts...)
+	captures := server.NewCaptureServer(runner.Shell{}, artifacts)
+	captures.Recover(journal)
+	pb.RegisterCaptureServiceServer(s, captures)
+	pb.RegisterHCNServiceServer(s, server.NewHcnServer(backend))
+
+	// Register health service, which reports the server as not serving while pktmon, vfpctrl or hnsdiag can't be run
+	health := server.NewHealthChecker(runner.Shell{})
+	healthpb.RegisterHealthServer(s, health.Server())
+	go health.Run(context.Background(), healthInterval)
+
+	if healthPort != "" {
+		healthListener, err := net.Listen("tcp", "0.0.0.0:"+healthPort)
+		if err != nil {
+			log.Fatalf("Failed to listen for health checks: %v", err)
+		}
+
+		healthServer := grpc.NewServer()
+		healthpb.RegisterHealthServer(healthServer, health.Server())
+		go healthServer.Serve(healthListener)
+		fmt.Printf("Serving health checks on port %s\n", healthPort)
+	}
 
 	// Register reflection service on gRPC server
 	reflection.Register(s)
 
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		shutdownOnSignal(s, captures, health, shutdownTimeout)
+	}()
+
 	if err := s.Serve(listener); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
+
+	// Serve returns as soon as the server starts stopping, so wait for pktmon to be reset
+	<-stopped
+}
+
+// Waits to be asked to terminate, then stops the running captures, resets pktmon and stops the server once the
+// requests in flight are done. Whatever hasn't finished by the timeout is cut short.
+func shutdownOnSignal(s *grpc.Server, captures *server.CaptureServer, health *server.HealthChecker, timeout time.Duration) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	log.Printf("Received %v, shutting down.\n", <-c)
+
+	health.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := captures.Shutdown(ctx); err != nil {
+		log.Print(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Printf("Requests didn't finish in time, stopping the server.\n")
+		s.Stop()
+	}
+}
+
+// Loads the auth config, connecting to the Kubernetes API only if ServiceAccount tokens need to be reviewed
+func newAuthorizer(path string, kubeconfig string) (*server.Authorizer, error) {
+	config, err := server.LoadAuthConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.ServiceAccounts) == 0 {
+		return server.NewAuthorizer(config, nil)
+	}
+
+	restConfig, err := rest.InClusterConfig()
+	if kubeconfig != "" {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config for reviewing tokens: %v", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for reviewing tokens: %v", err)
+	}
+
+	return server.NewAuthorizer(config, server.NewCachingTokenReviewer(server.KubeTokenReviewer{Client: clientset}, server.TokenReviewTTL))
 }
@@ -9,5 +9,10 @@ const (
 	KubeConfigEnvVar  = "KUBECONFIG"
 	ValidProtocols    = "TCP UDP ICMP ICMPv6"
 	ValidTCPFlags     = "FIN SYN RST PSH ACK URG ECE CWR"
+	ValidEthertypes   = "IPv4 IPv6 ARP"
 	ValidPacketTypes  = "ALL FLOW DROP"
+	SessionIDHeader   = "session-id"
+	ServerNamespace   = "kube-system"
+	ServerSelector    = "app=wcnspect-server"
+	ServerName        = "wcnspectserv"
 )
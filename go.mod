@@ -7,6 +7,7 @@ require (
 	github.com/spf13/pflag v1.0.5
 	google.golang.org/grpc v1.47.0
 	google.golang.org/protobuf v1.28.0
+	sigs.k8s.io/yaml v1.2.0
 )
 
 require (
@@ -26,6 +27,7 @@ require (
 	github.com/magiconair/properties v1.8.6 // indirect
 	github.com/mailru/easyjson v0.7.6 // indirect
 	github.com/mitchellh/mapstructure v1.5.0 // indirect
+	github.com/moby/spdystream v0.2.0 // indirect
 	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
 	github.com/pelletier/go-toml v1.9.5 // indirect
 	github.com/pelletier/go-toml/v2 v2.0.1 // indirect
@@ -40,7 +42,6 @@ require (
 	google.golang.org/appengine v1.6.7 // indirect
 	gopkg.in/ini.v1 v1.66.4 // indirect
 	k8s.io/kube-openapi v0.0.0-20220328201542-3ee0da9b0b42 // indirect
-	sigs.k8s.io/yaml v1.2.0 // indirect
 )
 
 require (
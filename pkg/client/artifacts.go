@@ -0,0 +1,175 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/microsoft/wcnspect/pkg/comprise"
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+// Payload written to --output for each artifact fetched
+type artifactPayload struct {
+	Artifact string `json:"artifact"`
+	File     string `json:"file"`
+	Bytes    int    `json:"bytes"`
+}
+
+// SelectArtifacts returns the complete artifacts named or logged by one of the sessions in names, or all of them.
+func SelectArtifacts(artifacts []*pb.Artifact, names []string, all bool) []*pb.Artifact {
+	ret := []*pb.Artifact{}
+	for _, artifact := range artifacts {
+		if !artifact.GetComplete() {
+			continue
+		}
+
+		if all || comprise.Contains(names, artifact.GetName()) || comprise.Contains(names, artifact.GetSessionId()) {
+			ret = append(ret, artifact)
+		}
+	}
+
+	return ret
+}
+
+// FormatArtifacts renders a table with an artifact per line.
+func FormatArtifacts(artifacts []*pb.Artifact) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Name\tSession\tSize\tModified\tStatus")
+	for _, a := range artifacts {
+		state := "complete"
+		if !a.GetComplete() {
+			state = "capturing"
+		} else if a.GetError() != "" {
+			state = "complete, " + a.GetError()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", a.GetName(), a.GetSessionId(), a.GetSize(), a.GetModified().AsTime().Format(time.RFC3339), state)
+	}
+	w.Flush()
+
+	return buf.String()
+}
+
+func PrintArtifacts(c pb.CaptureServiceClient, reqCtx *ReqContext) error {
+	name, ip := reqCtx.Server.Name, reqCtx.Server.Ip
+
+	// Send request
+	res, err := c.ListArtifacts(context.Background(), &pb.Empty{})
+	if err != nil {
+		return reqCtx.Done(fmt.Errorf("error while calling ListArtifacts RPC: %v", err))
+	}
+
+	if reqCtx.Output != nil {
+		reqCtx.Output.Write(reqCtx.Server, res.GetTimestamp(), res, nil)
+		return reqCtx.Done(nil)
+	}
+
+	if len(res.GetArtifacts()) == 0 {
+		fmt.Printf("No capture files on %s (IP: %s).\n", name, ip)
+		return reqCtx.Done(nil)
+	}
+
+	fmt.Printf("Capture files on %s (IP: %s):\n\n%s\n", name, ip, FormatArtifacts(res.GetArtifacts()))
+
+	return reqCtx.Done(nil)
+}
+
+// FetchArtifacts downloads the node's artifacts selected by names or all into dir, prefixing each file with the
+// node's name. Session IDs are unique to a node, so nodes without matching artifacts aren't an error.
+func FetchArtifacts(c pb.CaptureServiceClient, names []string, all bool, dir string, reqCtx *ReqContext) error {
+	name, ip := reqCtx.Server.Name, reqCtx.Server.Ip
+
+	res, err := c.ListArtifacts(context.Background(), &pb.Empty{})
+	if err != nil {
+		return reqCtx.Done(fmt.Errorf("error while calling ListArtifacts RPC: %v", err))
+	}
+
+	artifacts := SelectArtifacts(res.GetArtifacts(), names, all)
+	if len(artifacts) == 0 {
+		reqCtx.printf("No complete capture files matched on %s (IP: %s).\n", name, ip)
+		return reqCtx.Done(nil)
+	}
+
+	for _, artifact := range artifacts {
+		// Names come from the server, so make sure they can't point outside of dir
+		if !validArtifactName(artifact.GetName()) {
+			return reqCtx.Done(fmt.Errorf("invalid capture file name '%s'", artifact.GetName()))
+		}
+
+		path := filepath.Join(dir, name+"-"+artifact.GetName())
+		written, err := downloadArtifact(c, artifact.GetName(), path)
+		if err != nil {
+			return reqCtx.Done(err)
+		}
+
+		if reqCtx.Output != nil {
+			reqCtx.Output.Write(reqCtx.Server, nil, artifactPayload{Artifact: artifact.GetName(), File: path, Bytes: written}, nil)
+			continue
+		}
+
+		fmt.Printf("Fetched %s from %s (IP: %s) to %s (%d bytes).\n", artifact.GetName(), name, ip, path, written)
+	}
+
+	return reqCtx.Done(nil)
+}
+
+// Returns whether name is a plain file name, without any directory
+func validArtifactName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\:`) && name == filepath.Base(name)
+}
+
+// Streams an artifact into path, returning the number of bytes written. The file is removed if the download fails.
+func downloadArtifact(c pb.CaptureServiceClient, artifact string, path string) (int, error) {
+	stream, err := c.DownloadArtifact(context.Background(), &pb.DownloadArtifactRequest{Name: artifact})
+	if err != nil {
+		return 0, fmt.Errorf("error while calling DownloadArtifact RPC: %v", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("error creating file for %s: %v", artifact, err)
+	}
+
+	written, err := receiveArtifact(stream, file, artifact, path)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("error writing %s: %v", path, closeErr)
+	}
+
+	if err != nil {
+		os.Remove(path)
+		return written, err
+	}
+
+	return written, nil
+}
+
+// Writes the artifact's chunks to file until the stream ends
+func receiveArtifact(stream pb.CaptureService_DownloadArtifactClient, file *os.File, artifact string, path string) (int, error) {
+	var written int
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return written, nil
+		}
+
+		if err != nil {
+			return written, fmt.Errorf("error while downloading %s: %v", artifact, err)
+		}
+
+		n, err := file.Write(msg.GetData())
+		written += n
+		if err != nil {
+			return written, fmt.Errorf("error writing %s: %v", path, err)
+		}
+	}
+}
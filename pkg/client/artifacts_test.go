@@ -0,0 +1,151 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/grpc"
+)
+
+// Serves artifacts from memory, failing downloads of those without data after their first chunk
+type fakeArtifactClient struct {
+	pb.CaptureServiceClient
+	artifacts map[string][]byte
+}
+
+func (c *fakeArtifactClient) ListArtifacts(ctx context.Context, in *pb.Empty, opts ...grpc.CallOption) (*pb.ListArtifactsResponse, error) {
+	res := &pb.ListArtifactsResponse{}
+	for name := range c.artifacts {
+		res.Artifacts = append(res.Artifacts, &pb.Artifact{Name: name, Complete: true})
+	}
+
+	return res, nil
+}
+
+func (c *fakeArtifactClient) DownloadArtifact(ctx context.Context, in *pb.DownloadArtifactRequest, opts ...grpc.CallOption) (pb.CaptureService_DownloadArtifactClient, error) {
+	return &fakeArtifactStream{data: c.artifacts[in.GetName()]}, nil
+}
+
+type fakeArtifactStream struct {
+	grpc.ClientStream
+	data []byte
+	sent int
+}
+
+func (s *fakeArtifactStream) Recv() (*pb.ArtifactChunk, error) {
+	s.sent++
+	switch {
+	case s.sent == 1:
+		return &pb.ArtifactChunk{Data: []byte("pcapng")}, nil
+	case len(s.data) == 0:
+		return nil, errors.New("artifact was deleted")
+	case s.sent == 2:
+		return &pb.ArtifactChunk{Data: s.data}, nil
+	}
+
+	return nil, io.EOF
+}
+
+func TestSelectArtifacts(t *testing.T) {
+	artifacts := []*pb.Artifact{
+		{Name: "wcnspect-1a2b-20220601T100000Z.pcapng", SessionId: "1a2b", Complete: true},
+		{Name: "wcnspect-3c4d-20220601T110000Z.etl", SessionId: "3c4d", Complete: true},
+		{Name: "wcnspect-5e6f-20220601T120000Z.etl", SessionId: "5e6f"},
+	}
+
+	cases := []struct {
+		desc     string
+		names    []string
+		all      bool
+		expected []string
+	}{
+		{"TestBySession", []string{"1a2b"}, false, []string{"wcnspect-1a2b-20220601T100000Z.pcapng"}},
+		{"TestByName", []string{"wcnspect-3c4d-20220601T110000Z.etl"}, false, []string{"wcnspect-3c4d-20220601T110000Z.etl"}},
+		{"TestIncomplete", []string{"5e6f"}, false, []string{}},
+		{"TestAll", nil, true, []string{"wcnspect-1a2b-20220601T100000Z.pcapng", "wcnspect-3c4d-20220601T110000Z.etl"}},
+		{"TestNoMatch", []string{"7a8b"}, false, []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actual := []string{}
+			for _, artifact := range SelectArtifacts(artifacts, tc.names, tc.all) {
+				actual = append(actual, artifact.GetName())
+			}
+
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected: %v, got: %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFetchArtifacts(t *testing.T) {
+	cases := []struct {
+		desc     string
+		artifact string
+		data     []byte
+		fails    bool
+		expected string // file expected in the download directory, if any
+	}{
+		{"TestFetch", "wcnspect-1a2b-20220601T100000Z.pcapng", []byte(" data"), false, "win1-wcnspect-1a2b-20220601T100000Z.pcapng"},
+		{"TestFailedDownloadRemoved", "wcnspect-3c4d-20220601T110000Z.etl", nil, true, ""},
+		{"TestParentDirectory", "/../../wcnspect-5e6f-20220601T120000Z.etl", []byte(" data"), true, ""},
+		{"TestSubdirectory", "captures/wcnspect-5e6f-20220601T120000Z.etl", []byte(" data"), true, ""},
+		{"TestWindowsPath", `..\wcnspect-5e6f-20220601T120000Z.etl`, []byte(" data"), true, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			root := t.TempDir()
+			dir := filepath.Join(root, "captures")
+			if err := os.Mkdir(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			c := &fakeArtifactClient{artifacts: map[string][]byte{tc.artifact: tc.data}}
+			err := FetchArtifacts(c, nil, true, dir, &ReqContext{Server: Node{Name: "win1"}})
+			if (err != nil) != tc.fails {
+				t.Fatalf("expected failure: %t got error: %v", tc.fails, err)
+			}
+
+			// Nothing is written outside of the directory, and failed downloads leave nothing behind
+			actual := []string{}
+			err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+				if err == nil && !info.IsDir() {
+					rel, _ := filepath.Rel(dir, path)
+					actual = append(actual, rel)
+				}
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected := []string{}
+			if tc.expected != "" {
+				expected = append(expected, tc.expected)
+			}
+
+			if !reflect.DeepEqual(actual, expected) {
+				t.Fatalf("expected: %v, got: %v", expected, actual)
+			}
+
+			if tc.expected != "" {
+				if b, _ := os.ReadFile(filepath.Join(dir, tc.expected)); string(b) != "pcapng data" {
+					t.Fatalf("expected the artifact's data, got: %s", b)
+				}
+			}
+		})
+	}
+}
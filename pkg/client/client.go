@@ -5,9 +5,15 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/microsoft/wcnspect/common"
@@ -15,7 +21,10 @@ import (
 	pb "github.com/microsoft/wcnspect/rpc"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	v1 "k8s.io/api/core/v1"
 )
@@ -31,33 +40,155 @@ type Node struct {
 }
 
 type ReqContext struct {
-	Server Node
-	Wg     *sync.WaitGroup
+	Server   Node
+	Wg       *sync.WaitGroup
+	Timeline *Timeline    // If set, streamed output is merged into the timeline instead of printed
+	Sessions *Sessions    // If set, records the capture session started on the node
+	Counters *CounterView // If set, packet counters are printed as a filtered and sorted table
+	Output   *Output      // If set, results are written as machine-readable output instead of printed
+	Failures *Failures    // If set, records the error returned by the node
+	Search   *Search      // If set, only the streamed packets matching the search are shown
 }
 
-func (rq *ReqContext) Done() {
+/* Marks the request as finished and returns err.
+A failed request is logged, or written as the node's result when writing machine-readable output, and recorded in Failures.
+*/
+func (rq *ReqContext) Done(err error) error {
+	if err != nil {
+		if rq.Output != nil {
+			rq.Output.Write(rq.Server, nil, nil, err)
+		} else {
+			log.Printf("Request to %s (IP: %s) failed: %v\n", rq.Server.Name, rq.Server.Ip, err)
+		}
+
+		if rq.Failures != nil {
+			rq.Failures.Record(rq.Server, err)
+		}
+	}
+
 	if rq.Wg != nil {
 		rq.Wg.Done()
 	}
+
+	return err
+}
+
+// Prints a banner, unless results are being written as machine-readable output
+func (rq *ReqContext) printf(format string, a ...interface{}) {
+	if rq.Output == nil {
+		fmt.Printf(format, a...)
+	}
+}
+
+// Sessions tracks the capture session started on each node, so that only this client's captures get stopped
+type Sessions struct {
+	mu  sync.Mutex
+	ids map[string]string // node name -> session ID
+}
+
+func NewSessions() *Sessions {
+	return &Sessions{ids: map[string]string{}}
+}
+
+func (s *Sessions) Set(node string, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ids[node] = id
+}
+
+func (s *Sessions) Get(node string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, ok := s.ids[node]
+	return id, ok
+}
+
+// ConnOptions configures the connections made to wcnspect servers
+type ConnOptions struct {
+	TLS    *tls.Config // If set, connections are secured with TLS instead of being plaintext
+	Token  string      // If set, sent as a bearer token with every request
+	Tunnel *Tunnel     // If set, servers are reached through the Kubernetes API server instead of node IPs
+
+	Servers *Servers // If set, each node's server address and port are taken from its server pod
+	Port    string   // Port used when it isn't discovered. Defaults to common.DefaultServerPort
+}
+
+// Sends a bearer token with every request
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// Tunnelled connections are plaintext as far as gRPC knows, so CreateConnection checks the transport instead
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
 }
 
-func CreateConnection(ip string) (*client, func() error) {
-	//FIXME: hardcoded port addition
-	cc, err := grpc.Dial(ip+":"+common.DefaultServerPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func CreateConnection(node Node, opts ConnOptions) (*client, func() error, error) {
+	creds := insecure.NewCredentials()
+	if opts.TLS != nil {
+		creds = credentials.NewTLS(opts.TLS)
+	}
+
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if opts.Token != "" {
+		// Tokens are only sent over TLS, or through the tunnel, which the API server secures
+		if opts.TLS == nil && opts.Tunnel == nil {
+			return nil, nil, errors.New("a token can only be sent over TLS or through a tunnel")
+		}
+
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(tokenCredentials(opts.Token)))
+	}
+
+	host, port := node.Ip, opts.Port
+	if port == "" {
+		port = common.DefaultServerPort
+	}
+
+	if opts.Servers != nil {
+		var err error
+		if host, port, err = opts.Servers.Address(node); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	target, release := net.JoinHostPort(host, port), func() {}
+	if opts.Tunnel != nil {
+		addr, releaseTunnel, err := opts.Tunnel.Address(node, port)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Keep the node's address as the authority, so TLS certificates are verified the same way
+		dialOpts = append(dialOpts, grpc.WithAuthority(target))
+		target, release = addr, releaseTunnel
+	}
+
+	cc, err := grpc.Dial(target, dialOpts...)
 	if err != nil {
-		log.Fatalf("could not connect: %v", err)
+		release()
+		return nil, nil, fmt.Errorf("could not connect: %v", err)
 	}
 
 	c1 := pb.NewCaptureServiceClient(cc)
 	c2 := pb.NewHCNServiceClient(cc)
 	c := &client{c1, c2}
 
-	return c, cc.Close
+	// Closing the connection also stops the port-forward it went through, once nothing else uses it
+	closeClient := func() error {
+		defer release()
+		return cc.Close()
+	}
+
+	return c, closeClient, nil
 }
 
-func RunCaptureStream(c pb.CaptureServiceClient, req *pb.CaptureRequest, reqCtx *ReqContext) {
+func RunCaptureStream(c pb.CaptureServiceClient, req *pb.CaptureRequest, reqCtx *ReqContext) error {
 	name, ip := reqCtx.Server.Name, reqCtx.Server.Ip
-	fmt.Printf("Starting to do a Server Streaming RPC from %s (IP: %s)...\n", name, ip)
+	reqCtx.printf("Starting to do a Server Streaming RPC from %s (IP: %s)...\n", name, ip)
 
 	// Create request object
 	req.Timestamp = timestamppb.Now()
@@ -65,9 +196,62 @@ func RunCaptureStream(c pb.CaptureServiceClient, req *pb.CaptureRequest, reqCtx
 	// Send request
 	resStream, err := c.StartCapture(context.Background(), req)
 	if err != nil {
-		log.Fatalf("error while calling StartCapture RPC from %s (from IP: %s): %v", name, ip, err)
+		return reqCtx.Done(fmt.Errorf("error while calling StartCapture RPC: %v", err))
+	}
+
+	if err := registerSession(resStream, "StartCapture", reqCtx); err != nil {
+		return reqCtx.Done(err)
 	}
 
+	show := func(record captureRecord) {
+		if reqCtx.Search != nil {
+			var ok bool
+			if record, ok = reqCtx.Search.apply(record); !ok {
+				return
+			}
+		}
+
+		if reqCtx.Output != nil {
+			for _, msg := range record.lines {
+				reqCtx.Output.Write(reqCtx.Server, msg.GetTimestamp(), msg, nil)
+			}
+			return
+		}
+
+		// A packet flushed once pktmon's output ended has no line of its own to print
+		lines := []*pb.CaptureResponse{}
+		for _, msg := range record.lines {
+			if msg.GetResult() != "" {
+				lines = append(lines, msg)
+			}
+		}
+
+		if len(lines) == 0 {
+			return
+		}
+
+		if reqCtx.Timeline != nil {
+			// A packet's lines are kept together, at the time pktmon saw it rather than the time they were sent
+			timestamp := lines[0].GetTimestamp().AsTime()
+			if record.packet.GetTimestamp() != nil {
+				timestamp = record.packet.GetTimestamp().AsTime()
+			}
+
+			results := []string{}
+			for _, msg := range lines {
+				results = append(results, msg.GetResult())
+			}
+
+			reqCtx.Timeline.Add(name, timestamp, results...)
+			return
+		}
+
+		for _, msg := range lines {
+			fmt.Printf("Response from %s StartCapture (%s) sent at %s: \n%v\n", name, ip, msg.GetTimestamp().AsTime(), msg.GetResult())
+		}
+	}
+
+	records := &captureRecords{}
 	for {
 		msg, err := resStream.Recv()
 		if err == io.EOF {
@@ -76,22 +260,133 @@ func RunCaptureStream(c pb.CaptureServiceClient, req *pb.CaptureRequest, reqCtx
 		}
 
 		if err != nil {
-			log.Fatalf("error while reading stream from %s (from IP: %s): %v", name, ip, err)
+			return reqCtx.Done(fmt.Errorf("error while reading stream: %v", err))
 		}
 
-		fmt.Printf("Response from %s StartCapture (%s) sent at %s: \n%v\n", name, ip, msg.GetTimestamp().AsTime(), msg.GetResult())
+		for _, record := range records.add(msg) {
+			show(record)
+		}
+	}
+
+	for _, record := range records.flush(nil) {
+		show(record)
+	}
+
+	reqCtx.printf("Finished receiving stream from %s (IP: %s).\n", name, ip)
+
+	return reqCtx.Done(nil)
+}
+
+func RunPcapStream(c pb.CaptureServiceClient, req *pb.CaptureRequest, reqCtx *ReqContext, path string) error {
+	name, ip := reqCtx.Server.Name, reqCtx.Server.Ip
+	reqCtx.printf("Starting to log packets to pcapng on %s (IP: %s)...\n", name, ip)
+
+	// Create request object
+	req.Timestamp = timestamppb.Now()
+
+	// Send request
+	resStream, err := c.CapturePcap(context.Background(), req)
+	if err != nil {
+		return reqCtx.Done(fmt.Errorf("error while calling CapturePcap RPC: %v", err))
+	}
+
+	if err := registerSession(resStream, "CapturePcap", reqCtx); err != nil {
+		return reqCtx.Done(err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return reqCtx.Done(fmt.Errorf("error creating pcapng file: %v", err))
+	}
+	defer file.Close()
+
+	var written int
+	for {
+		msg, err := resStream.Recv()
+		if err == io.EOF {
+			// we've reached the end of the stream
+			break
+		}
+
+		if err != nil {
+			return reqCtx.Done(fmt.Errorf("error while reading stream: %v", err))
+		}
+
+		n, err := file.Write(msg.GetResult())
+		if err != nil {
+			return reqCtx.Done(fmt.Errorf("error writing pcapng file: %v", err))
+		}
+		written += n
+	}
+
+	if reqCtx.Output != nil {
+		reqCtx.Output.Write(reqCtx.Server, nil, pcapPayload{File: path, Bytes: written}, nil)
 	}
 
-	fmt.Printf("Finished receiving stream from %s (IP: %s).\n", name, ip)
+	reqCtx.printf("Wrote %d bytes of packets from %s (IP: %s) to %s.\n", written, name, ip, path)
 
-	reqCtx.Done()
+	return reqCtx.Done(nil)
 }
 
-func RunStopCapture(c pb.CaptureServiceClient, reqCtx *ReqContext) {
+/* Returns the file a node's pcapng should be written to.
+When capturing on several nodes, the node name is added to the file name so each node gets its own file.
+*/
+func PcapFileName(path string, node string, multiNode bool) string {
+	if !multiNode {
+		return path
+	}
+
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + node + ext
+}
+
+/* Waits for the node to accept a capture and records the session it started.
+Returns an error if the node's pktmon is busy with someone else's capture.
+*/
+func registerSession(stream grpc.ClientStream, rpc string, reqCtx *ReqContext) error {
 	name, ip := reqCtx.Server.Name, reqCtx.Server.Ip
-	res, err := c.StopCapture(context.Background(), &pb.Empty{})
+
+	header, err := stream.Header()
+	if status.Code(err) == codes.FailedPrecondition {
+		return fmt.Errorf("unable to start capture: %s", status.Convert(err).Message())
+	}
+
 	if err != nil {
-		log.Fatalf("error while calling StopCapture RPC from %s (IP: %s): %v", name, ip, err)
+		return fmt.Errorf("error while calling %s RPC: %v", rpc, err)
+	}
+
+	if ids := header.Get(common.SessionIDHeader); len(ids) > 0 {
+		reqCtx.printf("Capture session %s started on %s (IP: %s).\n", ids[0], name, ip)
+
+		if reqCtx.Sessions != nil {
+			reqCtx.Sessions.Set(name, ids[0])
+		}
+	}
+
+	return nil
+}
+
+func RunStopCapture(c pb.CaptureServiceClient, reqCtx *ReqContext, sessionID string) error {
+	name, ip := reqCtx.Server.Name, reqCtx.Server.Ip
+	res, err := c.StopCapture(context.Background(), &pb.StopCaptureRequest{SessionId: sessionID})
+
+	// Session IDs are unique to a node, so other nodes not knowing the session isn't a failure
+	if status.Code(err) == codes.NotFound {
+		if reqCtx.Output != nil {
+			reqCtx.Output.Write(reqCtx.Server, nil, nil, fmt.Errorf("capture session %s not found", sessionID))
+		}
+
+		reqCtx.printf("Capture session %s not found on node: %s (IP: %s).\n", sessionID, name, ip)
+		return reqCtx.Done(nil)
+	}
+
+	if err != nil {
+		return reqCtx.Done(fmt.Errorf("error while calling StopCapture RPC: %v", err))
+	}
+
+	if reqCtx.Output != nil {
+		reqCtx.Output.Write(reqCtx.Server, res.GetTimestamp(), res, nil)
+		return reqCtx.Done(nil)
 	}
 
 	msg, timestamp := res.GetResult(), res.GetTimestamp().AsTime()
@@ -103,68 +398,205 @@ func RunStopCapture(c pb.CaptureServiceClient, reqCtx *ReqContext) {
 
 	fmt.Printf("Packet capture ended on node: %s (IP: %s).\n", name, ip)
 
-	reqCtx.Done()
+	return reqCtx.Done(nil)
 }
 
-func PrintCounters(c pb.CaptureServiceClient, req *pb.CountersRequest, reqCtx *ReqContext) {
+func PrintCounters(c pb.CaptureServiceClient, req *pb.CountersRequest, reqCtx *ReqContext) error {
 	name, ip := reqCtx.Server.Name, reqCtx.Server.Ip
-	fmt.Printf("Requesting packet counters table from %s (IP: %s)...\n", name, ip)
+	reqCtx.printf("Requesting packet counters table from %s (IP: %s)...\n", name, ip)
 
 	// Send request
 	res, err := c.GetCounters(context.Background(), req)
 	if err != nil {
-		log.Fatalf("error while calling GetCounters RPC from %s (IP: %s): %v", name, ip, err)
+		return reqCtx.Done(fmt.Errorf("error while calling GetCounters RPC: %v", err))
+	}
+
+	if reqCtx.Output != nil {
+		reqCtx.Output.Write(reqCtx.Server, res.GetTimestamp(), res, nil)
+		return reqCtx.Done(nil)
 	}
 
 	msg, timestamp := res.GetResult(), res.GetTimestamp().AsTime()
+	if reqCtx.Counters != nil {
+		msg = reqCtx.Counters.Format(res.GetCounters())
+	}
+
 	fmt.Printf("Received GetCounters RPC response from %s (IP: %s) at time: %s -\n%s\n", name, ip, timestamp, msg)
 
-	reqCtx.Done()
+	return reqCtx.Done(nil)
 }
 
-func PrintVFPCounters(c pb.CaptureServiceClient, req *pb.VFPCountersRequest, reqCtx *ReqContext) {
+func PrintVFPCounters(c pb.CaptureServiceClient, req *pb.VFPCountersRequest, reqCtx *ReqContext) error {
 	name, ip := reqCtx.Server.Name, reqCtx.Server.Ip
-	fmt.Printf("Requesting VFP packet counters table from %s (IP: %s)...\n", name, ip)
+	reqCtx.printf("Requesting VFP packet counters table from %s (IP: %s)...\n", name, ip)
 
 	// Send request
 	res, err := c.GetVFPCounters(context.Background(), req)
 	if err != nil {
-		log.Fatalf("error while calling GetVFPCounters RPC from %s (IP: %s): %v", name, ip, err)
+		return reqCtx.Done(fmt.Errorf("error while calling GetVFPCounters RPC: %v", err))
 	}
 
+	if reqCtx.Output != nil {
+		reqCtx.Output.Write(reqCtx.Server, res.GetTimestamp(), res, nil)
+		return reqCtx.Done(nil)
+	}
+
+	// Prefer the raw vfpctrl tables if they were asked for
 	msg, timestamp := res.GetResult(), res.GetTimestamp().AsTime()
+	if res.Result == nil {
+		msg = FormatVFPCounters(res.GetPorts())
+	}
+
 	fmt.Printf("Received GetVFPCounters RPC response from %s (IP: %s) at time: %s -\n%s\n", name, ip, timestamp, msg)
 
-	reqCtx.Done()
+	return reqCtx.Done(nil)
 }
 
-func PrintHCNLogs(c pb.HCNServiceClient, req *pb.HCNRequest, reqCtx *ReqContext) {
+func PrintVFPRules(c pb.CaptureServiceClient, req *pb.VFPRulesRequest, reqCtx *ReqContext) error {
+	name, ip := reqCtx.Server.Name, reqCtx.Server.Ip
+	reqCtx.printf("Requesting VFP rules from %s (IP: %s)...\n", name, ip)
+
+	// Send request
+	res, err := c.GetVFPRules(context.Background(), req)
+	if err != nil {
+		return reqCtx.Done(fmt.Errorf("error while calling GetVFPRules RPC: %v", err))
+	}
+
+	if reqCtx.Output != nil {
+		reqCtx.Output.Write(reqCtx.Server, res.GetTimestamp(), res, nil)
+		return reqCtx.Done(nil)
+	}
+
+	msg, timestamp := FormatVFPRules(res.GetLayers()), res.GetTimestamp().AsTime()
+	fmt.Printf("Received GetVFPRules RPC response from %s (IP: %s) for port %s at time: %s -\n%s\n", name, ip, res.GetPortGuid(), timestamp, msg)
+
+	return reqCtx.Done(nil)
+}
+
+func PrintHCNLogs(c pb.HCNServiceClient, req *pb.HCNRequest, reqCtx *ReqContext) error {
 	hcntype, name, ip := pb.HCNType_name[int32(req.GetHcntype())], reqCtx.Server.Name, reqCtx.Server.Ip
-	fmt.Printf("Requesting HCN logs from %s (IP: %s)...\n", name, ip)
+	reqCtx.printf("Requesting HCN logs from %s (IP: %s)...\n", name, ip)
 
 	// Send request
 	res, err := c.GetHCNLogs(context.Background(), req)
 	if err != nil {
-		log.Fatalf("error while calling GetHCNLogs RPC from %s (IP: %s): %v", name, ip, err)
+		return reqCtx.Done(fmt.Errorf("error while calling GetHCNLogs RPC: %v", err))
+	}
+
+	if reqCtx.Output != nil {
+		reqCtx.Output.Write(reqCtx.Server, nil, newHCNPayload(hcntype, res), nil)
+		return reqCtx.Done(nil)
+	}
+
+	// Servers using the HCN API only send raw JSON if detailed logs were asked for
+	logs := string(res.GetHcnResult())
+	if len(logs) == 0 && res.GetObjects() != nil {
+		logs = FormatHCNObjects(res.GetObjects())
 	}
 
-	fmt.Printf("Received logs for %s from %s (IP: %s):\n\n%s\n", hcntype, name, ip, string(res.GetHcnResult()))
+	fmt.Printf("Received logs for %s from %s (IP: %s):\n\n%s\n", hcntype, name, ip, logs)
 
-	reqCtx.Done()
+	return reqCtx.Done(nil)
 }
 
-func Cleanup(nodes []v1.Node) {
+func PrintCaptures(c pb.CaptureServiceClient, reqCtx *ReqContext) error {
+	name, ip := reqCtx.Server.Name, reqCtx.Server.Ip
+
+	// Send request
+	res, err := c.ListCaptures(context.Background(), &pb.Empty{})
+	if err != nil {
+		return reqCtx.Done(fmt.Errorf("error while calling ListCaptures RPC: %v", err))
+	}
+
+	if reqCtx.Output != nil {
+		reqCtx.Output.Write(reqCtx.Server, res.GetTimestamp(), res, nil)
+		return reqCtx.Done(nil)
+	}
+
+	sessions := res.GetSessions()
+	if len(sessions) == 0 {
+		fmt.Printf("No capture sessions running on %s (IP: %s).\n", name, ip)
+	}
+
+	for _, session := range sessions {
+		mode := "stream"
+		if session.GetPcap() {
+			mode = "pcap"
+		}
+		if session.GetArtifact() != "" {
+			mode = "file " + session.GetArtifact()
+		}
+
+		fmt.Printf("Capture session %s running on %s (IP: %s) since %s (%s): %v\n",
+			session.GetSessionId(), name, ip, session.GetStarted().AsTime(), mode, session.GetRequest())
+	}
+
+	return reqCtx.Done(nil)
+}
+
+func PrintServerStatus(c pb.CaptureServiceClient, reqCtx *ReqContext) error {
+	name, ip := reqCtx.Server.Name, reqCtx.Server.Ip
+
+	// Send request
+	res, err := c.GetServerStatus(context.Background(), &pb.Empty{})
+	if err != nil {
+		return reqCtx.Done(fmt.Errorf("error while calling GetServerStatus RPC: %v", err))
+	}
+
+	if reqCtx.Output != nil {
+		reqCtx.Output.Write(reqCtx.Server, res.GetTimestamp(), res, nil)
+		return reqCtx.Done(nil)
+	}
+
+	fmt.Printf("Server on %s (IP: %s) running since %s with %d capture sessions.\n", name, ip, res.GetStarted().AsTime(), len(res.GetSessions()))
+
+	cleanup := res.GetCleanup()
+	switch {
+	case cleanup == nil:
+		fmt.Printf("No session journal, so nothing was cleaned up at startup.\n")
+	case len(cleanup.GetSessions()) == 0 && len(cleanup.GetFilters()) == 0 && len(cleanup.GetErrors()) == 0:
+		fmt.Printf("Nothing was left behind by the previous run.\n")
+	default:
+		for _, session := range cleanup.GetSessions() {
+			fmt.Printf("Cleaned up capture session %s, started at %s: %v\n", session.GetSessionId(), session.GetStarted().AsTime(), session.GetRequest())
+		}
+
+		if cleanup.GetPktmonStopped() {
+			fmt.Printf("Stopped pktmon.\n")
+		}
+
+		if len(cleanup.GetFilters()) > 0 {
+			fmt.Printf("Removed filters %v.\n", cleanup.GetFilters())
+		}
+
+		for _, e := range cleanup.GetErrors() {
+			fmt.Printf("Cleanup failed: %s\n", e)
+		}
+	}
+
+	return reqCtx.Done(nil)
+}
+
+/* Stops the captures this client started on each node, returning a summary of the nodes where that failed.
+If sessions is nil, whichever capture is running on each node is stopped. If out is set, results are written to it.
+*/
+func Cleanup(nodes []v1.Node, sessions *Sessions, out *Output, opts ConnOptions) error {
+	failures := NewFailures()
+
 	var wg sync.WaitGroup
 	for _, node := range nodes {
-		// Increment the WaitGroup counter
-		wg.Add(1)
-
 		// Get target name and ip
 		name, ip := node.GetName(), k8sapi.RetrieveInternalIP(node)
 
-		// Create connections
-		c, closeClient := CreateConnection(ip)
-		defer closeClient()
+		// Only stop captures we started, so we don't stomp on someone else's
+		var sessionID string
+		if sessions != nil {
+			id, ok := sessions.Get(name)
+			if !ok {
+				continue
+			}
+			sessionID = id
+		}
 
 		// Create request context
 		ctx := &ReqContext{
@@ -172,13 +604,28 @@ func Cleanup(nodes []v1.Node) {
 				Name: name,
 				Ip:   ip,
 			},
-			Wg: &wg,
+			Wg:       &wg,
+			Output:   out,
+			Failures: failures,
+		}
+
+		// Increment the WaitGroup counter
+		wg.Add(1)
+
+		// Create connections
+		c, closeClient, err := CreateConnection(ctx.Server, opts)
+		if err != nil {
+			ctx.Done(err)
+			continue
 		}
+		defer closeClient()
 
 		// Launch a goroutine to run the request
-		go RunStopCapture(c, ctx)
+		go RunStopCapture(c, ctx, sessionID)
 	}
 
 	// Wait for all captures to complete
 	wg.Wait()
+
+	return failures.Err()
 }
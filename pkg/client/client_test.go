@@ -0,0 +1,99 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"crypto/tls"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestCreateConnectionToken(t *testing.T) {
+	cases := []struct {
+		desc  string
+		opts  ConnOptions
+		fails bool
+	}{
+		{"TestNoToken", ConnOptions{}, false},
+		{"TestTokenOverTLS", ConnOptions{Token: "oncall-token", TLS: &tls.Config{}}, false},
+		{"TestTokenInPlaintext", ConnOptions{Token: "oncall-token"}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Connections are made lazily, so nothing needs to listen
+			_, closeClient, err := CreateConnection(Node{Name: "win1", Ip: "127.0.0.1"}, tc.opts)
+			if (err != nil) != tc.fails {
+				t.Fatalf("expected failure: %t got error: %v", tc.fails, err)
+			}
+
+			if err == nil {
+				closeClient()
+			}
+		})
+	}
+}
+
+func TestCreateConnectionTunnel(t *testing.T) {
+	server := v1.Container{Command: []string{"./wcnspectserv.exe"}}
+	servers := newServers([]v1.Pod{serverPod("win1", "10.240.0.4", v1.PodRunning, server)}, "kube-system", "app=wcnspect-server", "50051")
+
+	forwards, stops := 0, []<-chan struct{}{}
+	tunnel := &Tunnel{servers: servers, forwards: map[string]*portForward{}}
+	tunnel.forward = func(namespace string, podName string, port string, stop <-chan struct{}) (uint16, error) {
+		forwards++
+		stops = append(stops, stop)
+		return uint16(40000 + forwards), nil
+	}
+
+	opts := ConnOptions{Servers: servers, Tunnel: tunnel}
+	node := Node{Name: "win1", Ip: "10.240.0.4"}
+
+	// Connections to the same node share its port-forward, which stops once the last one is closed
+	_, closeFirst, err := CreateConnection(node, opts)
+	if err != nil {
+		t.Fatalf("CreateConnection failed: %v", err)
+	}
+
+	_, closeSecond, err := CreateConnection(node, opts)
+	if err != nil {
+		t.Fatalf("CreateConnection failed: %v", err)
+	}
+
+	if forwards != 1 {
+		t.Fatalf("expected a single port-forward, got %d", forwards)
+	}
+
+	stopped := func() bool {
+		select {
+		case <-stops[0]:
+			return true
+		default:
+			return false
+		}
+	}
+
+	closeFirst()
+	closeFirst()
+	if stopped() {
+		t.Fatalf("expected the port-forward to keep running while a connection uses it")
+	}
+
+	closeSecond()
+	if !stopped() {
+		t.Fatalf("expected the port-forward to stop once every connection is closed")
+	}
+
+	// A new connection starts a new port-forward
+	_, closeThird, err := CreateConnection(node, opts)
+	if err != nil {
+		t.Fatalf("CreateConnection failed: %v", err)
+	}
+	closeThird()
+
+	if forwards != 2 {
+		t.Fatalf("expected a new port-forward, got %d in total", forwards)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+// Fields packet counter tables can be sorted by
+var CounterSortFields = []string{"id", "packets", "bytes", "drops"}
+
+// CounterView selects and orders the per-component rows of a packet counter table.
+type CounterView struct {
+	Components []string // Component IDs or case-insensitive name substrings to keep, all components if empty
+	SortBy     string   // One of CounterSortFields; counts are sorted in descending order
+}
+
+func ValidateCounterSortField(field string) error {
+	for _, f := range CounterSortFields {
+		if f == field {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid sort field '%s', must be one of: %s", field, strings.Join(CounterSortFields, ", "))
+}
+
+// Filter returns the rows for the components selected by the view, in the view's order.
+func (v *CounterView) Filter(rows []*pb.ComponentCounters) []*pb.ComponentCounters {
+	ret := []*pb.ComponentCounters{}
+	for _, row := range rows {
+		if v.selects(row) {
+			ret = append(ret, row)
+		}
+	}
+
+	var key func(*pb.ComponentCounters) uint64
+	switch v.SortBy {
+	case "packets":
+		key = func(c *pb.ComponentCounters) uint64 { return c.GetPacketsIn() + c.GetPacketsOut() }
+	case "bytes":
+		key = func(c *pb.ComponentCounters) uint64 { return c.GetBytesIn() + c.GetBytesOut() }
+	case "drops":
+		key = droppedPackets
+	default:
+		sort.SliceStable(ret, func(i, j int) bool { return ret[i].GetComponentId() < ret[j].GetComponentId() })
+		return ret
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool { return key(ret[i]) > key(ret[j]) })
+
+	return ret
+}
+
+// Format renders the rows selected by the view as a table.
+func (v *CounterView) Format(rows []*pb.ComponentCounters) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "Id\tName\tMAC Address\tRx Packets\tRx Bytes\tTx Packets\tTx Bytes\tDropped")
+	for _, c := range v.Filter(rows) {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%d\t%d\t%d\n",
+			c.GetComponentId(), c.GetName(), c.GetMac(),
+			c.GetPacketsIn(), c.GetBytesIn(), c.GetPacketsOut(), c.GetBytesOut(), droppedPackets(c))
+
+		for _, d := range c.GetDrops() {
+			fmt.Fprintf(w, "\t\t\t\t\t\t%s (%s)\t%d\n", d.GetReason(), d.GetLocation(), d.GetPackets())
+		}
+	}
+
+	w.Flush()
+
+	return buf.String()
+}
+
+func (v *CounterView) selects(c *pb.ComponentCounters) bool {
+	if len(v.Components) == 0 {
+		return true
+	}
+
+	for _, comp := range v.Components {
+		if id, err := strconv.ParseUint(comp, 10, 32); err == nil {
+			if uint32(id) == c.GetComponentId() {
+				return true
+			}
+			continue
+		}
+
+		if strings.Contains(strings.ToLower(c.GetName()), strings.ToLower(comp)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func droppedPackets(c *pb.ComponentCounters) uint64 {
+	var n uint64
+	for _, d := range c.GetDrops() {
+		n += d.GetPackets()
+	}
+
+	return n
+}
+
+var vfpPortTitles = map[pb.VFPPortType]string{
+	pb.VFPPortType_pod_port:         "Pod Port",
+	pb.VFPPortType_host_vnic:        "Host vNic",
+	pb.VFPPortType_external_adapter: "External Adapter",
+}
+
+// FormatVFPCounters renders the counters of each VFP port as a table, listing the reasons for any drops.
+func FormatVFPCounters(ports []*pb.VFPPortCounters) string {
+	var buf bytes.Buffer
+
+	for i, port := range ports {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		fmt.Fprintf(&buf, "%s VFP Counters (ID: %s)\n", vfpPortTitles[port.GetPortType()], port.GetPortGuid())
+
+		w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "Direction\tPackets\tBytes\tForwarded\tDropped\tFlows Created\tFlows Deleted\tActive Flows")
+		for _, d := range port.GetDirections() {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\n",
+				d.GetDirection(), d.GetPackets(), d.GetBytes(), d.GetForwardedPackets(), d.GetDroppedPackets(),
+				d.GetFlowsCreated(), d.GetFlowsDeleted(), d.GetActiveFlows())
+
+			for _, drop := range d.GetDrops() {
+				if drop.GetPackets() > 0 {
+					fmt.Fprintf(w, "\t\t\t%s\t%d\n", drop.GetReason(), drop.GetPackets())
+				}
+			}
+		}
+
+		w.Flush()
+	}
+
+	return buf.String()
+}
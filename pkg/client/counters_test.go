@@ -0,0 +1,56 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"testing"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+func TestCounterView(t *testing.T) {
+	rows := []*pb.ComponentCounters{
+		{ComponentId: 5, Name: "Microsoft Hyper-V Network Adapter", PacketsIn: 2417, PacketsOut: 1903, BytesIn: 1981422, BytesOut: 221734},
+		{ComponentId: 10, Name: "Container NIC host", PacketsIn: 312, PacketsOut: 287, BytesIn: 41928, BytesOut: 38112},
+		{
+			ComponentId: 14, Name: "Container NIC 3f2a1b0c", PacketsIn: 2105, PacketsOut: 1616, BytesIn: 1939494, BytesOut: 183622,
+			Drops: []*pb.DropCounter{{Reason: "Filtered VLAN", Packets: 3}},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		view     CounterView
+		expected []uint32
+	}{
+		{"TestDefault", CounterView{}, []uint32{5, 10, 14}},
+		{"TestSortByPackets", CounterView{SortBy: "packets"}, []uint32{5, 14, 10}},
+		{"TestSortByDrops", CounterView{SortBy: "drops"}, []uint32{14, 5, 10}},
+		{"TestFilterByName", CounterView{Components: []string{"container nic"}}, []uint32{10, 14}},
+		{"TestFilterByID", CounterView{Components: []string{"5", "14"}, SortBy: "bytes"}, []uint32{5, 14}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actual := []uint32{}
+			for _, row := range tc.view.Filter(rows) {
+				actual = append(actual, row.GetComponentId())
+			}
+
+			if len(actual) != len(tc.expected) {
+				t.Fatalf("expected: %v, got: %v", tc.expected, actual)
+			}
+
+			for i := range actual {
+				if actual[i] != tc.expected[i] {
+					t.Fatalf("expected: %v, got: %v", tc.expected, actual)
+				}
+			}
+		})
+	}
+
+	if err := ValidateCounterSortField("latency"); err == nil {
+		t.Fatalf("expected an error for an invalid sort field")
+	}
+}
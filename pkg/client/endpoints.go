@@ -0,0 +1,217 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/microsoft/wcnspect/pkg/netutil"
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Problems reported by CheckEndpoints
+const (
+	IssueOrphanedEndpoint = "orphaned endpoint"
+	IssueMissingEndpoint  = "pod without endpoint"
+	IssueDuplicateIP      = "duplicate IP"
+	IssueDuplicateMAC     = "duplicate MAC"
+)
+
+// HNSEndpoint is an HNS endpoint as reported by either HNS backend of the server.
+type HNSEndpoint struct {
+	ID      string
+	Name    string
+	IP      string
+	MAC     string
+	Network string
+	Remote  bool // Endpoints for pods on other nodes, as found on overlay networks
+}
+
+// EndpointIssue is a mismatch between the HNS endpoints on a node and the pods scheduled on it.
+type EndpointIssue struct {
+	Issue    string `json:"issue"`
+	IP       string `json:"ip"`
+	MAC      string `json:"mac,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+	Network  string `json:"network,omitempty"`
+	Pod      string `json:"pod,omitempty"` // namespace/name
+	Detail   string `json:"detail,omitempty"`
+}
+
+// Returns the endpoints in an HCN response, whichever HNS backend the server uses
+func hnsEndpoints(res *pb.HCNResponse) ([]HNSEndpoint, error) {
+	ret := []HNSEndpoint{}
+
+	if objs := res.GetObjects(); objs != nil {
+		for _, e := range objs.GetEndpoints() {
+			for _, ip := range e.GetIpAddresses() {
+				ret = append(ret, HNSEndpoint{
+					ID:      e.GetId(),
+					Name:    e.GetName(),
+					IP:      strings.Split(ip, "/")[0],
+					MAC:     e.GetMacAddress(),
+					Network: e.GetNetworkId(),
+					Remote:  e.GetFlags()&1 != 0, // hcn.EndpointFlagsRemoteEndpoint
+				})
+			}
+		}
+
+		return ret, nil
+	}
+
+	objs, err := netutil.ParseHNSDiagLogs(res.GetHcnResult())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse hnsdiag output: %v", err)
+	}
+
+	for _, obj := range objs {
+		e := obj.HNSDiagEndpoint
+		ret = append(ret, HNSEndpoint{ID: e.ID, Name: e.Name, IP: e.IPAddress, MAC: e.MacAddress, Network: e.VirtualNetwork, Remote: e.IsRemoteEndpoint})
+	}
+
+	return ret, nil
+}
+
+// CheckEndpoints compares the local HNS endpoints on node with the pods the API server has scheduled on it, given
+// every pod in the cluster.
+func CheckEndpoints(node Node, endpoints []HNSEndpoint, pods []v1.Pod) []EndpointIssue {
+	issues := []EndpointIssue{}
+	podName := func(pod *v1.Pod) string { return pod.GetNamespace() + "/" + pod.GetName() }
+
+	// Pod IP -> pods, of pods that have their own endpoint
+	podsByIP := map[string][]*v1.Pod{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.HostNetwork || pod.Status.PodIP == "" || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		podsByIP[pod.Status.PodIP] = append(podsByIP[pod.Status.PodIP], pod)
+	}
+
+	byIP, byMAC := map[string][]HNSEndpoint{}, map[string][]HNSEndpoint{}
+	for _, e := range endpoints {
+		if e.Remote || e.IP == "" {
+			continue
+		}
+		byIP[e.IP] = append(byIP[e.IP], e)
+		if e.MAC != "" {
+			byMAC[strings.ToUpper(e.MAC)] = append(byMAC[strings.ToUpper(e.MAC)], e)
+		}
+
+		ipPods := podsByIP[e.IP]
+		switch {
+		case e.IP == node.Ip:
+		case len(ipPods) == 0:
+			issues = append(issues, EndpointIssue{Issue: IssueOrphanedEndpoint, IP: e.IP, MAC: e.MAC, Endpoint: e.ID, Network: e.Network,
+				Detail: "no pod has this IP"})
+		case len(ipPods) == 1 && ipPods[0].Spec.NodeName != node.Name:
+			issues = append(issues, EndpointIssue{Issue: IssueOrphanedEndpoint, IP: e.IP, MAC: e.MAC, Endpoint: e.ID, Network: e.Network,
+				Pod: podName(ipPods[0]), Detail: fmt.Sprintf("pod is scheduled on %s", ipPods[0].Spec.NodeName)})
+		}
+	}
+
+	for ip, ipPods := range podsByIP {
+		for _, pod := range ipPods {
+			if pod.Spec.NodeName != node.Name {
+				continue
+			}
+
+			if len(ipPods) > 1 {
+				others := []string{}
+				for _, other := range ipPods {
+					if other != pod {
+						others = append(others, fmt.Sprintf("%s on %s", podName(other), other.Spec.NodeName))
+					}
+				}
+				issues = append(issues, EndpointIssue{Issue: IssueDuplicateIP, IP: ip, Pod: podName(pod),
+					Detail: "also assigned to " + strings.Join(others, ", ")})
+			}
+
+			if _, ok := byIP[ip]; !ok {
+				issues = append(issues, EndpointIssue{Issue: IssueMissingEndpoint, IP: ip, Pod: podName(pod)})
+			}
+		}
+	}
+
+	for ip, ipEndpoints := range byIP {
+		if len(ipEndpoints) > 1 {
+			for _, e := range ipEndpoints {
+				issues = append(issues, EndpointIssue{Issue: IssueDuplicateIP, IP: ip, MAC: e.MAC, Endpoint: e.ID, Network: e.Network,
+					Detail: fmt.Sprintf("%d endpoints have this IP", len(ipEndpoints))})
+			}
+		}
+	}
+
+	for mac, macEndpoints := range byMAC {
+		if len(macEndpoints) > 1 {
+			for _, e := range macEndpoints {
+				issues = append(issues, EndpointIssue{Issue: IssueDuplicateMAC, IP: e.IP, MAC: mac, Endpoint: e.ID, Network: e.Network,
+					Detail: fmt.Sprintf("%d endpoints have this MAC", len(macEndpoints))})
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Issue != issues[j].Issue {
+			return issues[i].Issue < issues[j].Issue
+		}
+		if issues[i].IP != issues[j].IP {
+			return issues[i].IP < issues[j].IP
+		}
+		return issues[i].Endpoint < issues[j].Endpoint
+	})
+
+	return issues
+}
+
+// FormatEndpointIssues renders a table with an issue per line.
+func FormatEndpointIssues(issues []EndpointIssue) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Issue\tIP\tMAC\tEndpoint\tNetwork\tPod\tDetail")
+	for _, i := range issues {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i.Issue, i.IP, i.MAC, i.Endpoint, i.Network, i.Pod, i.Detail)
+	}
+	w.Flush()
+
+	return buf.String()
+}
+
+func PrintEndpointIssues(c pb.HCNServiceClient, pods []v1.Pod, reqCtx *ReqContext) error {
+	name, ip := reqCtx.Server.Name, reqCtx.Server.Ip
+
+	// Send request. Servers running hnsdiag only return the endpoints' details if asked for
+	res, err := c.GetHCNLogs(context.Background(), &pb.HCNRequest{Hcntype: pb.HCNType_endpoints, Verbose: true})
+	if err != nil {
+		return reqCtx.Done(fmt.Errorf("error while calling GetHCNLogs RPC: %v", err))
+	}
+
+	endpoints, err := hnsEndpoints(res)
+	if err != nil {
+		return reqCtx.Done(err)
+	}
+
+	issues := CheckEndpoints(reqCtx.Server, endpoints, pods)
+
+	if reqCtx.Output != nil {
+		reqCtx.Output.Write(reqCtx.Server, nil, issues, nil)
+		return reqCtx.Done(nil)
+	}
+
+	if len(issues) == 0 {
+		fmt.Printf("No endpoint issues found on %s (IP: %s).\n", name, ip)
+		return reqCtx.Done(nil)
+	}
+
+	fmt.Printf("Endpoint issues on %s (IP: %s):\n\n%s\n", name, ip, FormatEndpointIssues(issues))
+
+	return reqCtx.Done(nil)
+}
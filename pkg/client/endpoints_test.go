@@ -0,0 +1,97 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHNSEndpoints(t *testing.T) {
+	hnsdiag := `{"ID":"3F2A1B0C","IPAddress":"10.240.0.35","MacAddress":"00-15-5D-70-41-2C","Name":"3F2A1B0C_eth0","VirtualNetwork":"2E6C4B8A"}
+{"ID":"7E6D5C4B","IPAddress":"10.240.0.80","IsRemoteEndpoint":true,"MacAddress":"00-15-5D-70-41-2D","VirtualNetwork":"2E6C4B8A"}
+`
+	hcn := &pb.HCNObjects{Endpoints: []*pb.HCNEndpoint{
+		{Id: "3F2A1B0C", Name: "3F2A1B0C_eth0", IpAddresses: []string{"10.240.0.35/16"}, MacAddress: "00-15-5D-70-41-2C", NetworkId: "2E6C4B8A"},
+		{Id: "7E6D5C4B", IpAddresses: []string{"10.240.0.80/16"}, MacAddress: "00-15-5D-70-41-2D", NetworkId: "2E6C4B8A", Flags: 1},
+	}}
+
+	expected := []HNSEndpoint{
+		{ID: "3F2A1B0C", Name: "3F2A1B0C_eth0", IP: "10.240.0.35", MAC: "00-15-5D-70-41-2C", Network: "2E6C4B8A"},
+		{ID: "7E6D5C4B", IP: "10.240.0.80", MAC: "00-15-5D-70-41-2D", Network: "2E6C4B8A", Remote: true},
+	}
+
+	cases := []struct {
+		desc string
+		res  *pb.HCNResponse
+	}{
+		{"TestHnsdiagBackend", &pb.HCNResponse{HcnResult: []byte(hnsdiag)}},
+		{"TestHCNBackend", &pb.HCNResponse{HcnResult: []byte("[]"), Objects: hcn}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actual, err := hnsEndpoints(tc.res)
+			if err != nil {
+				t.Fatalf("hnsEndpoints failed: %v", err)
+			}
+
+			if !reflect.DeepEqual(actual, expected) {
+				t.Fatalf("expected: %+v, got: %+v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestCheckEndpoints(t *testing.T) {
+	node := Node{Name: "win1", Ip: "10.240.0.4"}
+	pod := func(name string, nodeName string, ip string) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       v1.PodSpec{NodeName: nodeName},
+			Status:     v1.PodStatus{Phase: v1.PodRunning, PodIP: ip},
+		}
+	}
+	hostPod := pod("kube-proxy", "win1", "10.240.0.4")
+	hostPod.Spec.HostNetwork = true
+
+	pods := []v1.Pod{
+		pod("web", "win1", "10.240.0.10"),
+		pod("api", "win1", "10.240.0.11"),
+		pod("db", "win2", "10.240.0.20"),
+		pod("cache", "win1", "10.240.0.30"),
+		pod("queue", "win2", "10.240.0.30"),
+		hostPod,
+	}
+
+	endpoints := []HNSEndpoint{
+		{ID: "host", IP: "10.240.0.4", MAC: "00-15-5D-00-00-04"},
+		{ID: "web", IP: "10.240.0.10", MAC: "00-15-5D-00-00-10", Network: "net"},
+		{ID: "stale", IP: "10.240.0.12", MAC: "00-15-5D-00-00-12", Network: "net"},
+		{ID: "moved", IP: "10.240.0.20", MAC: "00-15-5D-00-00-20", Network: "net"},
+		{ID: "cache", IP: "10.240.0.30", MAC: "00-15-5D-00-00-30", Network: "net"},
+		{ID: "copy", IP: "10.240.0.30", MAC: "00-15-5d-00-00-30", Network: "net"},
+		{ID: "remote", IP: "10.240.0.21", Network: "net", Remote: true},
+	}
+
+	expected := []EndpointIssue{
+		{Issue: IssueDuplicateIP, IP: "10.240.0.30", Pod: "default/cache", Detail: "also assigned to default/queue on win2"},
+		{Issue: IssueDuplicateIP, IP: "10.240.0.30", MAC: "00-15-5D-00-00-30", Endpoint: "cache", Network: "net", Detail: "2 endpoints have this IP"},
+		{Issue: IssueDuplicateIP, IP: "10.240.0.30", MAC: "00-15-5d-00-00-30", Endpoint: "copy", Network: "net", Detail: "2 endpoints have this IP"},
+		{Issue: IssueDuplicateMAC, IP: "10.240.0.30", MAC: "00-15-5D-00-00-30", Endpoint: "cache", Network: "net", Detail: "2 endpoints have this MAC"},
+		{Issue: IssueDuplicateMAC, IP: "10.240.0.30", MAC: "00-15-5D-00-00-30", Endpoint: "copy", Network: "net", Detail: "2 endpoints have this MAC"},
+		{Issue: IssueOrphanedEndpoint, IP: "10.240.0.12", MAC: "00-15-5D-00-00-12", Endpoint: "stale", Network: "net", Detail: "no pod has this IP"},
+		{Issue: IssueOrphanedEndpoint, IP: "10.240.0.20", MAC: "00-15-5D-00-00-20", Endpoint: "moved", Network: "net", Pod: "default/db", Detail: "pod is scheduled on win2"},
+		{Issue: IssueMissingEndpoint, IP: "10.240.0.11", Pod: "default/api"},
+	}
+
+	if actual := CheckEndpoints(node, endpoints, pods); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected:\n%+v\ngot:\n%+v", expected, actual)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Failures collects the errors returned by each node, so that one failing node doesn't stop requests to the others.
+type Failures struct {
+	mu    sync.Mutex
+	nodes []Node
+	errs  map[string]error // node name -> error
+}
+
+func NewFailures() *Failures {
+	return &Failures{errs: map[string]error{}}
+}
+
+// Record records err as the outcome of the request sent to node. Nil errors are ignored.
+func (f *Failures) Record(node Node, err error) {
+	if err == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.errs[node.Name]; !ok {
+		f.nodes = append(f.nodes, node)
+	}
+	f.errs[node.Name] = err
+}
+
+func (f *Failures) Len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.nodes)
+}
+
+// Err returns an error summarizing which nodes failed and why, or nil if none did.
+func (f *Failures) Err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.nodes) == 0 {
+		return nil
+	}
+
+	nodes := append([]Node{}, f.nodes...)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	lines := []string{}
+	for _, node := range nodes {
+		lines = append(lines, fmt.Sprintf("  %s (IP: %s): %v", node.Name, node.Ip, f.errs[node.Name]))
+	}
+
+	noun := "nodes"
+	if len(nodes) == 1 {
+		noun = "node"
+	}
+
+	return fmt.Errorf("request failed on %d %s:\n%s", len(nodes), noun, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFailures(t *testing.T) {
+	win1, win2 := Node{Name: "win1", Ip: "10.240.0.4"}, Node{Name: "win2", Ip: "10.240.0.5"}
+
+	failures := NewFailures()
+	if err := failures.Err(); err != nil {
+		t.Fatalf("expected no error without failures, got: %v", err)
+	}
+
+	var buf bytes.Buffer
+	out, _ := NewOutput("json", &buf)
+
+	// Requests finish concurrently, and successful ones aren't recorded
+	var wg sync.WaitGroup
+	wg.Add(3)
+	for _, tc := range []struct {
+		node Node
+		err  error
+	}{
+		{win2, errors.New("connection refused")},
+		{win1, errors.New("unable to start capture: pktmon is in use")},
+		{Node{Name: "win3", Ip: "10.240.0.6"}, nil},
+	} {
+		ctx := &ReqContext{Server: tc.node, Wg: &wg, Output: out, Failures: failures}
+		go func(err error) {
+			if actual := ctx.Done(err); actual != err {
+				t.Errorf("expected Done to return: %v, got: %v", err, actual)
+			}
+		}(tc.err)
+	}
+	wg.Wait()
+
+	if failures.Len() != 2 {
+		t.Fatalf("expected 2 failures, got: %d", failures.Len())
+	}
+
+	expected := strings.Join([]string{
+		"request failed on 2 nodes:",
+		"  win1 (IP: 10.240.0.4): unable to start capture: pktmon is in use",
+		"  win2 (IP: 10.240.0.5): connection refused",
+	}, "\n")
+	if actual := failures.Err().Error(); actual != expected {
+		t.Fatalf("expected: \n%s\n got: \n%s\n", expected, actual)
+	}
+
+	// Each failure is also written as the node's result
+	if strings.Count(buf.String(), `"error":`) != 2 {
+		t.Fatalf("expected an error result per failed node, got: \n%s\n", buf.String())
+	}
+}
@@ -0,0 +1,389 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+// Filter expressions follow a small subset of BPF. pktmon captures a packet if it matches every parameter of any of
+// its filters, so an expression is rewritten as an OR of ANDs, each AND becoming one set of filters.
+//
+//	expr      = and { ("or" | "||") and }
+//	and       = unary { ("and" | "&&") unary }
+//	unary     = "(" expr ")" | primitive | protocol-primitive ["src" | "dst"] "port" port
+//	primitive = "tcp" | "udp" | "icmp" | "icmp6" | ["ip"] "proto" protocol
+//	          | "ip" | "ip6" | "arp" | "ether" "proto" ethertype
+//	          | ["src" | "dst"] ("host" | "net") ip | ["src" | "dst"] "port" port
+//	          | "ether" ["src" | "dst"] "host" mac | "vlan" id
+//
+// pktmon doesn't match on direction, so "src" and "dst" match either side of the traffic.
+
+// FilterError is returned for an invalid filter expression, pointing at where it went wrong.
+type FilterError struct {
+	Expr string
+	Pos  int
+	Msg  string
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("invalid filter at position %d: %s\n\t%s\n\t%s^", e.Pos+1, e.Msg, e.Expr, strings.Repeat(" ", e.Pos))
+}
+
+type filterToken struct {
+	text string
+	pos  int
+}
+
+// A value a packet has to match, as one of the parameters of a pktmon filter
+type filterTerm struct {
+	param string
+	value string
+	pos   int
+}
+
+type filterParser struct {
+	expr   string
+	tokens []filterToken
+	next   int
+}
+
+// ParseFilter parses a filter expression into the sets of filters it matches.
+func ParseFilter(expr string) ([]*pb.Filters, error) {
+	p := &filterParser{expr: expr, tokens: tokenizeFilter(expr)}
+	if len(p.tokens) == 0 {
+		return nil, p.errorf(0, "empty filter")
+	}
+
+	conjunctions, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if token, ok := p.peek(); ok {
+		return nil, p.errorf(token.pos, "unexpected '%s'", token.text)
+	}
+
+	sets := []*pb.Filters{}
+	for _, terms := range conjunctions {
+		set, err := p.toFilters(terms)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, set)
+	}
+
+	return sets, nil
+}
+
+func tokenizeFilter(expr string) []filterToken {
+	tokens := []filterToken{}
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			tokens = append(tokens, filterToken{expr[start:end], start})
+			start = -1
+		}
+	}
+
+	for i, c := range expr {
+		switch {
+		case c == '(' || c == ')':
+			flush(i)
+			tokens = append(tokens, filterToken{string(c), i})
+		case unicode.IsSpace(c):
+			flush(i)
+		case start < 0:
+			start = i
+		}
+	}
+	flush(len(expr))
+
+	return tokens
+}
+
+func (p *filterParser) errorf(pos int, format string, a ...interface{}) error {
+	return &FilterError{Expr: p.expr, Pos: pos, Msg: fmt.Sprintf(format, a...)}
+}
+
+func (p *filterParser) peek() (filterToken, bool) {
+	if p.next >= len(p.tokens) {
+		return filterToken{}, false
+	}
+
+	return p.tokens[p.next], true
+}
+
+// Returns whether the next token is one of the keywords, consuming it if so
+func (p *filterParser) accept(keywords ...string) bool {
+	token, ok := p.peek()
+	if !ok {
+		return false
+	}
+
+	for _, keyword := range keywords {
+		if strings.EqualFold(token.text, keyword) {
+			p.next++
+			return true
+		}
+	}
+
+	return false
+}
+
+// Consumes the next token, failing with what was expected if there's none
+func (p *filterParser) expect(what string) (filterToken, error) {
+	token, ok := p.peek()
+	if !ok {
+		return token, p.errorf(len(p.expr), "expected %s", what)
+	}
+	p.next++
+
+	return token, nil
+}
+
+func (p *filterParser) parseOr() ([][]filterTerm, error) {
+	conjunctions, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("or", "||") {
+		rhs, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		conjunctions = append(conjunctions, rhs...)
+	}
+
+	return conjunctions, nil
+}
+
+func (p *filterParser) parseAnd() ([][]filterTerm, error) {
+	conjunctions, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("and", "&&") {
+		rhs, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		// (a or b) and (c or d) is (a and c) or (a and d) or (b and c) or (b and d)
+		distributed := [][]filterTerm{}
+		for _, l := range conjunctions {
+			for _, r := range rhs {
+				distributed = append(distributed, append(append([]filterTerm{}, l...), r...))
+			}
+		}
+		conjunctions = distributed
+	}
+
+	return conjunctions, nil
+}
+
+func (p *filterParser) parseUnary() ([][]filterTerm, error) {
+	token, ok := p.peek()
+	if ok && (strings.EqualFold(token.text, "not") || token.text == "!") {
+		return nil, p.errorf(token.pos, "pktmon can't exclude packets, so '%s' isn't supported", token.text)
+	}
+
+	if p.accept("(") {
+		conjunctions, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if next, ok := p.peek(); !ok || next.text != ")" {
+			return nil, p.errorf(p.pos(), "expected ')' to close '(' at position %d", token.pos+1)
+		}
+		p.next++
+
+		return conjunctions, nil
+	}
+
+	term, err := p.parsePrimitive()
+	if err != nil {
+		return nil, err
+	}
+
+	// As in BPF, a protocol can qualify the port that follows it, e.g. "udp port 53"
+	if term.param == "protocols" && p.atPort() {
+		port, err := p.parsePrimitive()
+		if err != nil {
+			return nil, err
+		}
+
+		return [][]filterTerm{{term, port}}, nil
+	}
+
+	return [][]filterTerm{{term}}, nil
+}
+
+// Returns whether the next tokens are a port primitive
+func (p *filterParser) atPort() bool {
+	i := p.next
+	if i < len(p.tokens) && (strings.EqualFold(p.tokens[i].text, "src") || strings.EqualFold(p.tokens[i].text, "dst")) {
+		i++
+	}
+
+	return i < len(p.tokens) && strings.EqualFold(p.tokens[i].text, "port")
+}
+
+// Returns the position of the next token, or the end of the expression if there's none
+func (p *filterParser) pos() int {
+	if token, ok := p.peek(); ok {
+		return token.pos
+	}
+
+	return len(p.expr)
+}
+
+func (p *filterParser) parsePrimitive() (filterTerm, error) {
+	token, err := p.expect("a filter")
+	if err != nil {
+		return filterTerm{}, err
+	}
+
+	switch keyword := strings.ToLower(token.text); keyword {
+	case "tcp", "udp", "icmp":
+		return filterTerm{"protocols", strings.ToUpper(keyword), token.pos}, nil
+	case "icmp6", "icmpv6":
+		return filterTerm{"protocols", "ICMPv6", token.pos}, nil
+	case "proto":
+		return p.parseValue("protocols", "a protocol", func(v string) error { return ValidateProtocols([]string{v}) })
+	case "ip":
+		if p.accept("proto") {
+			return p.parseValue("protocols", "a protocol", func(v string) error { return ValidateProtocols([]string{v}) })
+		}
+		return filterTerm{"ethertypes", "IPv4", token.pos}, nil
+	case "ip6":
+		return filterTerm{"ethertypes", "IPv6", token.pos}, nil
+	case "arp":
+		return filterTerm{"ethertypes", "ARP", token.pos}, nil
+	case "ether":
+		if p.accept("proto") {
+			return p.parseValue("ethertypes", "an ethertype", func(v string) error { return ValidateEthertypes([]string{v}) })
+		}
+
+		p.accept("src", "dst")
+		if !p.accept("host") {
+			return filterTerm{}, p.errorf(p.pos(), "expected 'proto' or 'host' after 'ether'")
+		}
+		return p.parseValue("macs", "a MAC address", func(v string) error { return ValidateMACAddrs([]string{v}) })
+	case "vlan":
+		return p.parseValue("vlans", "a VLAN ID", func(v string) error {
+			if id, err := strconv.ParseUint(v, 10, 32); err != nil || ValidateVLANs([]uint32{uint32(id)}) != nil {
+				return fmt.Errorf("invalid VLAN ID: %s", v)
+			}
+			return nil
+		})
+	case "src", "dst":
+		if !p.accept("host", "net", "port") {
+			return filterTerm{}, p.errorf(p.pos(), "expected 'host', 'net' or 'port' after '%s'", token.text)
+		}
+
+		if strings.EqualFold(p.tokens[p.next-1].text, "port") {
+			return p.parseValue("ports", "a port", func(v string) error { return ValidatePorts([]string{v}) })
+		}
+		return p.parseValue("ips", "an IP address", func(v string) error { return ValidateIPAddrs([]string{v}) })
+	case "host", "net":
+		return p.parseValue("ips", "an IP address", func(v string) error { return ValidateIPAddrs([]string{v}) })
+	case "port":
+		return p.parseValue("ports", "a port", func(v string) error { return ValidatePorts([]string{v}) })
+	}
+
+	if token.text == ")" {
+		return filterTerm{}, p.errorf(token.pos, "expected a filter")
+	}
+
+	return filterTerm{}, p.errorf(token.pos, "unknown filter '%s'", token.text)
+}
+
+// Parses the value of a primitive, checking it with validate
+func (p *filterParser) parseValue(param string, what string, validate func(string) error) (filterTerm, error) {
+	token, ok := p.peek()
+	if !ok || token.text == "(" || token.text == ")" {
+		return filterTerm{}, p.errorf(p.pos(), "expected %s", what)
+	}
+	p.next++
+
+	if err := validate(token.text); err != nil {
+		return filterTerm{}, p.errorf(token.pos, "%v", err)
+	}
+
+	return filterTerm{param, token.text, token.pos}, nil
+}
+
+// Filter parameters, in the order conflicts between their values are reported
+var filterParams = []string{"protocols", "ethertypes", "vlans", "ips", "ports", "macs"}
+
+// Builds the filters matching every term. pktmon matches one value per parameter, or traffic between two IPs, ports
+// or MACs.
+func (p *filterParser) toFilters(terms []filterTerm) (*pb.Filters, error) {
+	values := map[string][]filterTerm{}
+	for _, term := range terms {
+		duplicate := false
+		for _, v := range values[term.param] {
+			duplicate = duplicate || v.value == term.value
+		}
+
+		if !duplicate {
+			values[term.param] = append(values[term.param], term)
+		}
+	}
+
+	filters := &pb.Filters{}
+	for _, param := range filterParams {
+		terms := values[param]
+		switch {
+		case len(terms) == 0:
+			continue
+		case len(terms) > 1 && param != "ips" && param != "ports" && param != "macs":
+			return nil, p.errorf(terms[1].pos, "no packet matches both '%s' and '%s'", terms[0].value, terms[1].value)
+		case len(terms) > 2:
+			return nil, p.errorf(terms[2].pos, "pktmon can't match '%s' along with both '%s' and '%s', only traffic between two of them",
+				terms[2].value, terms[0].value, terms[1].value)
+		}
+
+		if len(terms) == 2 {
+			pair := &pb.FilterPair{First: terms[0].value, Second: terms[1].value}
+			switch param {
+			case "ips":
+				filters.IpPairs = append(filters.IpPairs, pair)
+			case "ports":
+				filters.PortPairs = append(filters.PortPairs, pair)
+			case "macs":
+				filters.MacPairs = append(filters.MacPairs, pair)
+			}
+			continue
+		}
+
+		value := terms[0].value
+		switch param {
+		case "ips":
+			filters.Ips = append(filters.Ips, value)
+		case "ports":
+			filters.Ports = append(filters.Ports, value)
+		case "macs":
+			filters.Macs = append(filters.Macs, value)
+		case "protocols":
+			filters.Protocols = append(filters.Protocols, value)
+		case "ethertypes":
+			filters.Ethertypes = append(filters.Ethertypes, value)
+		case "vlans":
+			id, _ := strconv.ParseUint(value, 10, 32)
+			filters.Vlans = append(filters.Vlans, uint32(id))
+		}
+	}
+
+	return filters, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"errors"
+	"testing"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseFilter(t *testing.T) {
+	cases := []struct {
+		desc     string
+		expr     string
+		expected []*pb.Filters
+	}{
+		{"TestPrimitive", "tcp", []*pb.Filters{{Protocols: []string{"TCP"}}}},
+		{
+			"TestAndOr",
+			"tcp and dst host 10.0.0.5 and dst port 443 or udp port 53",
+			[]*pb.Filters{
+				{Ips: []string{"10.0.0.5"}, Protocols: []string{"TCP"}, Ports: []string{"443"}},
+				{Protocols: []string{"UDP"}, Ports: []string{"53"}},
+			},
+		},
+		{
+			"TestParentheses",
+			"ip and (port 80 || port 443) && vlan 5",
+			[]*pb.Filters{
+				{Ports: []string{"80"}, Ethertypes: []string{"IPv4"}, Vlans: []uint32{5}},
+				{Ports: []string{"443"}, Ethertypes: []string{"IPv4"}, Vlans: []uint32{5}},
+			},
+		},
+		{
+			"TestPairs",
+			"host 10.244.1.5 and host 10.244.2.7 and ether host 00-15-5D-01-02-03",
+			[]*pb.Filters{
+				{IpPairs: []*pb.FilterPair{{First: "10.244.1.5", Second: "10.244.2.7"}}, Macs: []string{"00-15-5D-01-02-03"}},
+			},
+		},
+		{
+			"TestProtocolNumbers",
+			"ip proto 47 or ether proto 0x86DD or icmp6",
+			[]*pb.Filters{{Protocols: []string{"47"}}, {Ethertypes: []string{"0x86DD"}}, {Protocols: []string{"ICMPv6"}}},
+		},
+		{"TestQualifiedPort", "tcp src port 8080", []*pb.Filters{{Protocols: []string{"TCP"}, Ports: []string{"8080"}}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actual, err := ParseFilter(tc.expr)
+			if err != nil {
+				t.Fatalf("ParseFilter failed: %v", err)
+			}
+
+			if len(actual) != len(tc.expected) {
+				t.Fatalf("expected: %v, got: %v", tc.expected, actual)
+			}
+
+			for i := range actual {
+				if !proto.Equal(actual[i], tc.expected[i]) {
+					t.Fatalf("expected: %v, got: %v", tc.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	cases := []struct {
+		desc string
+		expr string
+		pos  int
+	}{
+		{"TestEmpty", "  ", 0},
+		{"TestUnknown", "tcp and foo", 8},
+		{"TestMissingValue", "tcp and port", 12},
+		{"TestInvalidPort", "port 70000", 5},
+		{"TestInvalidIP", "src host 10.0.0", 9},
+		{"TestUnclosed", "(tcp or udp", 11},
+		{"TestUnexpected", "tcp udp", 4},
+		{"TestQualifiedHost", "tcp src host 10.0.0.1", 4},
+		{"TestNot", "not tcp", 0},
+		{"TestConflict", "tcp and udp", 8},
+		{"TestTooManyHosts", "host 10.0.0.1 and host 10.0.0.2 and host 10.0.0.3", 41},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := ParseFilter(tc.expr)
+
+			var filterErr *FilterError
+			if !errors.As(err, &filterErr) {
+				t.Fatalf("expected a filter error, got: %v", err)
+			}
+
+			if filterErr.Pos != tc.pos {
+				t.Fatalf("expected the error at position %d, got: %v", tc.pos, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+// Names of the protocols HNS load balancers commonly map, by IP protocol number
+var protocolNames = map[uint32]string{
+	1:  "ICMP",
+	6:  "TCP",
+	17: "UDP",
+}
+
+// FormatHCNObjects renders a table for each type of HNS object retrieved.
+func FormatHCNObjects(objs *pb.HCNObjects) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	section := func(title string, header string) {
+		if buf.Len() > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, title)
+		fmt.Fprintln(w, header)
+	}
+
+	if networks := objs.GetNetworks(); len(networks) > 0 {
+		section("Networks:", "Id\tName\tType\tSubnets")
+		for _, n := range networks {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", n.GetId(), n.GetName(), n.GetType(), strings.Join(n.GetSubnets(), ","))
+		}
+		w.Flush()
+	}
+
+	if endpoints := objs.GetEndpoints(); len(endpoints) > 0 {
+		section("Endpoints:", "Id\tName\tIP Addresses\tMAC Address\tPolicies")
+		for _, e := range endpoints {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				e.GetId(), e.GetName(), strings.Join(e.GetIpAddresses(), ","), e.GetMacAddress(), strings.Join(e.GetPolicies(), ","))
+		}
+		w.Flush()
+	}
+
+	if lbs := objs.GetLoadBalancers(); len(lbs) > 0 {
+		section("Load Balancers:", "Id\tFrontend VIPs\tPort Mappings\tEndpoints")
+		for _, lb := range lbs {
+			mappings := []string{}
+			for _, m := range lb.GetPortMappings() {
+				protocol, ok := protocolNames[m.GetProtocol()]
+				if !ok {
+					protocol = fmt.Sprint(m.GetProtocol())
+				}
+				mappings = append(mappings, fmt.Sprintf("%d->%d/%s", m.GetExternalPort(), m.GetInternalPort(), protocol))
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\n",
+				lb.GetId(), strings.Join(lb.GetFrontendVips(), ","), strings.Join(mappings, ","), len(lb.GetEndpointIds()))
+		}
+		w.Flush()
+	}
+
+	if namespaces := objs.GetNamespaces(); len(namespaces) > 0 {
+		section("Namespaces:", "Id\tCompartment\tType\tEndpoints\tContainers")
+		for _, n := range namespaces {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%d\n",
+				n.GetId(), n.GetNamespaceId(), n.GetType(), len(n.GetEndpointIds()), len(n.GetContainerIds()))
+		}
+		w.Flush()
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+func TestFormatHCNObjects(t *testing.T) {
+	objs := &pb.HCNObjects{
+		Endpoints: []*pb.HCNEndpoint{
+			{Id: "3F2A1B0C", Name: "3f2a1b0c_eth0", IpAddresses: []string{"10.240.0.35/24"}, MacAddress: "00-15-5D-70-41-2C"},
+		},
+		LoadBalancers: []*pb.HCNLoadBalancer{
+			{
+				Id:           "7C1D9E2B",
+				FrontendVips: []string{"10.0.12.7"},
+				PortMappings: []*pb.HCNPortMapping{{Protocol: 6, InternalPort: 8080, ExternalPort: 80}},
+				EndpointIds:  []string{"3F2A1B0C", "D9E8F7A6"},
+			},
+		},
+	}
+
+	// Empty trailing cells are padded, so ignore trailing spaces
+	lines := strings.Split(FormatHCNObjects(objs), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	actual := strings.Join(lines, "\n")
+
+	expected := []string{
+		"Endpoints:",
+		"3F2A1B0C  3f2a1b0c_eth0  10.240.0.35/24  00-15-5D-70-41-2C",
+		"",
+		"Load Balancers:",
+		"7C1D9E2B  10.0.12.7      80->8080/TCP   2",
+	}
+	for _, line := range expected {
+		if !strings.Contains(actual, line+"\n") {
+			t.Fatalf("expected output to contain line: \n%s\n got: \n%s\n", line, actual)
+		}
+	}
+
+	if strings.Contains(actual, "Networks:") || strings.Contains(actual, "Namespaces:") {
+		t.Fatalf("expected no tables for object types that weren't retrieved, got: \n%s\n", actual)
+	}
+}
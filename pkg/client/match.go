@@ -0,0 +1,384 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/microsoft/wcnspect/common"
+	"github.com/microsoft/wcnspect/pkg/comprise"
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+// Match expressions follow a small subset of Wireshark's display filters, and are evaluated against the packets
+// parsed from a capture.
+//
+//	expr  = and { ("||" | "or") and }
+//	and   = unary { ("&&" | "and") unary }
+//	unary = ("!" | "not") unary | "(" expr ")" | field [("==" | "!=" | ">" | "<" | ">=" | "<=") value]
+//
+// A field given on its own matches packets that have it, and a field with several values, like ip.addr, matches if
+// any of them does.
+
+// Predicate reports whether a packet matches a match expression.
+type Predicate func(packet *pb.Packet) bool
+
+type matchKind int
+
+const (
+	matchBool matchKind = iota
+	matchNumber
+	matchIP
+	matchMAC
+	matchText
+)
+
+type matchField struct {
+	kind   matchKind
+	values func(packet *pb.Packet) []string // The field's values, none if the packet doesn't have it
+}
+
+var matchFields = newMatchFields()
+
+func newMatchFields() map[string]matchField {
+	fields := map[string]matchField{
+		"len":           {matchNumber, func(p *pb.Packet) []string { return numbers(p.GetLength()) }},
+		"frame.len":     {matchNumber, func(p *pb.Packet) []string { return numbers(p.GetLength()) }},
+		"component":     {matchNumber, func(p *pb.Packet) []string { return numbers(p.GetComponentId()) }},
+		"direction":     {matchText, func(p *pb.Packet) []string { return texts(p.GetDirection().String()) }},
+		"eth.src":       {matchMAC, func(p *pb.Packet) []string { return texts(p.GetSrcMac()) }},
+		"eth.dst":       {matchMAC, func(p *pb.Packet) []string { return texts(p.GetDstMac()) }},
+		"eth.addr":      {matchMAC, func(p *pb.Packet) []string { return texts(p.GetSrcMac(), p.GetDstMac()) }},
+		"eth.type":      {matchText, func(p *pb.Packet) []string { return texts(p.GetEthertype()) }},
+		"ip.src":        {matchIP, func(p *pb.Packet) []string { return texts(p.GetSrcIp()) }},
+		"ip.dst":        {matchIP, func(p *pb.Packet) []string { return texts(p.GetDstIp()) }},
+		"ip.addr":       {matchIP, func(p *pb.Packet) []string { return texts(p.GetSrcIp(), p.GetDstIp()) }},
+		"proto":         {matchText, func(p *pb.Packet) []string { return texts(p.GetProtocol()) }},
+		"drop":          {matchBool, func(p *pb.Packet) []string { return texts(p.GetDropReason()) }},
+		"drop.reason":   {matchText, func(p *pb.Packet) []string { return texts(p.GetDropReason()) }},
+		"drop.location": {matchText, func(p *pb.Packet) []string { return texts(p.GetDropLocation()) }},
+		"ip":            {matchBool, ethertype("IPv4")},
+		"ipv6":          {matchBool, ethertype("IPv6")},
+		"arp":           {matchBool, ethertype("ARP")},
+		"port":          {matchNumber, ports("", true, true)},
+		"srcport":       {matchNumber, ports("", true, false)},
+		"dstport":       {matchNumber, ports("", false, true)},
+	}
+
+	for _, protocol := range strings.Split(common.ValidProtocols, " ") {
+		protocol, name := protocol, strings.ToLower(protocol)
+		fields[name] = matchField{matchBool, func(p *pb.Packet) []string {
+			if p.GetProtocol() != protocol {
+				return nil
+			}
+			return []string{protocol}
+		}}
+
+		if protocol == "TCP" || protocol == "UDP" {
+			fields[name+".port"] = matchField{matchNumber, ports(protocol, true, true)}
+			fields[name+".srcport"] = matchField{matchNumber, ports(protocol, true, false)}
+			fields[name+".dstport"] = matchField{matchNumber, ports(protocol, false, true)}
+		}
+	}
+
+	for _, flag := range strings.Split(common.ValidTCPFlags, " ") {
+		flag := flag
+		fields["tcp.flags."+strings.ToLower(flag)] = matchField{matchBool, func(p *pb.Packet) []string {
+			if !comprise.Contains(p.GetTcpFlags(), flag) {
+				return nil
+			}
+			return []string{flag}
+		}}
+	}
+
+	return fields
+}
+
+// Returns the non-empty values
+func texts(values ...string) []string {
+	ret := []string{}
+	for _, value := range values {
+		if value != "" {
+			ret = append(ret, value)
+		}
+	}
+
+	return ret
+}
+
+// Returns the non-zero values
+func numbers(values ...uint32) []string {
+	ret := []string{}
+	for _, value := range values {
+		if value != 0 {
+			ret = append(ret, strconv.FormatUint(uint64(value), 10))
+		}
+	}
+
+	return ret
+}
+
+func ethertype(name string) func(p *pb.Packet) []string {
+	return func(p *pb.Packet) []string {
+		if p.GetEthertype() != name {
+			return nil
+		}
+		return []string{name}
+	}
+}
+
+// Returns the packet's source and/or destination ports, only for packets of the protocol unless it's empty
+func ports(protocol string, src bool, dst bool) func(p *pb.Packet) []string {
+	return func(p *pb.Packet) []string {
+		if protocol != "" && p.GetProtocol() != protocol {
+			return nil
+		}
+
+		ret := []string{}
+		if src {
+			ret = append(ret, numbers(p.GetSrcPort())...)
+		}
+		if dst {
+			ret = append(ret, numbers(p.GetDstPort())...)
+		}
+		return ret
+	}
+}
+
+var matchOperators = []string{"==", "!=", ">=", "<=", ">", "<", "&&", "||", "!", "(", ")"}
+
+type matchParser struct {
+	filterParser
+}
+
+// ParseMatch parses a match expression into the predicate it stands for.
+func ParseMatch(expr string) (Predicate, error) {
+	p := &matchParser{filterParser{expr: expr, tokens: tokenizeMatch(expr)}}
+	if len(p.tokens) == 0 {
+		return nil, p.errorf(0, "empty match expression")
+	}
+
+	predicate, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if token, ok := p.peek(); ok {
+		return nil, p.errorf(token.pos, "unexpected '%s'", token.text)
+	}
+
+	return predicate, nil
+}
+
+// Splits the expression into words and operators, which don't need to be separated by spaces
+func tokenizeMatch(expr string) []filterToken {
+	tokens := []filterToken{}
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			tokens = append(tokens, filterToken{expr[start:end], start})
+			start = -1
+		}
+	}
+
+	for i := 0; i < len(expr); {
+		operator := ""
+		for _, op := range matchOperators {
+			if strings.HasPrefix(expr[i:], op) {
+				operator = op
+				break
+			}
+		}
+
+		switch {
+		case operator != "":
+			flush(i)
+			tokens = append(tokens, filterToken{operator, i})
+			i += len(operator)
+			continue
+		case expr[i] == ' ' || expr[i] == '\t':
+			flush(i)
+		case start < 0:
+			start = i
+		}
+		i++
+	}
+	flush(len(expr))
+
+	return tokens
+}
+
+func (p *matchParser) parseOr() (Predicate, error) {
+	lhs, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("||", "or") {
+		rhs, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		l := lhs
+		lhs = func(packet *pb.Packet) bool { return l(packet) || rhs(packet) }
+	}
+
+	return lhs, nil
+}
+
+func (p *matchParser) parseAnd() (Predicate, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept("&&", "and") {
+		rhs, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		l := lhs
+		lhs = func(packet *pb.Packet) bool { return l(packet) && rhs(packet) }
+	}
+
+	return lhs, nil
+}
+
+func (p *matchParser) parseUnary() (Predicate, error) {
+	if p.accept("!", "not") {
+		predicate, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return func(packet *pb.Packet) bool { return !predicate(packet) }, nil
+	}
+
+	open, ok := p.peek()
+	if ok && p.accept("(") {
+		predicate, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if next, ok := p.peek(); !ok || next.text != ")" {
+			return nil, p.errorf(p.pos(), "expected ')' to close '(' at position %d", open.pos+1)
+		}
+		p.next++
+
+		return predicate, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *matchParser) parseComparison() (Predicate, error) {
+	token, err := p.expect("a field")
+	if err != nil {
+		return nil, err
+	}
+
+	field, ok := matchFields[strings.ToLower(token.text)]
+	if !ok {
+		return nil, p.errorf(token.pos, "unknown field '%s'", token.text)
+	}
+
+	op, ok := p.peek()
+	if !ok || !comprise.Contains([]string{"==", "!=", ">", "<", ">=", "<="}, op.text) {
+		return func(packet *pb.Packet) bool { return len(field.values(packet)) > 0 }, nil
+	}
+	p.next++
+
+	if field.kind == matchBool {
+		return nil, p.errorf(op.pos, "'%s' can't be compared, only matched on its own", token.text)
+	}
+
+	if field.kind != matchNumber && op.text != "==" && op.text != "!=" {
+		return nil, p.errorf(op.pos, "'%s' can only be compared with == or !=", token.text)
+	}
+
+	operand, ok := p.peek()
+	if !ok || comprise.Contains(matchOperators, operand.text) {
+		return nil, p.errorf(p.pos(), "expected a value to compare '%s' with", token.text)
+	}
+	p.next++
+
+	equal, err := compareValue(field.kind, operand.text)
+	if err != nil {
+		return nil, p.errorf(operand.pos, "%v", err)
+	}
+
+	anyValue := func(packet *pb.Packet, compare func(string) bool) bool {
+		for _, value := range field.values(packet) {
+			if compare(value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch op.text {
+	case "==":
+		return func(packet *pb.Packet) bool { return anyValue(packet, equal) }, nil
+	case "!=":
+		return func(packet *pb.Packet) bool { return !anyValue(packet, equal) }, nil
+	}
+
+	// Only numbers are ordered
+	operandNumber, _ := strconv.ParseUint(operand.text, 0, 32)
+	ordered := map[string]func(uint64) bool{
+		">":  func(n uint64) bool { return n > operandNumber },
+		"<":  func(n uint64) bool { return n < operandNumber },
+		">=": func(n uint64) bool { return n >= operandNumber },
+		"<=": func(n uint64) bool { return n <= operandNumber },
+	}[op.text]
+
+	return func(packet *pb.Packet) bool {
+		return anyValue(packet, func(value string) bool {
+			n, err := strconv.ParseUint(value, 10, 32)
+			return err == nil && ordered(n)
+		})
+	}, nil
+}
+
+// Returns whether a field's value equals the operand, which for IPs can also be a CIDR the value is in
+func compareValue(kind matchKind, operand string) (func(string) bool, error) {
+	switch kind {
+	case matchNumber:
+		n, err := strconv.ParseUint(operand, 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number: %s", operand)
+		}
+
+		return func(value string) bool { return value == strconv.FormatUint(n, 10) }, nil
+	case matchIP:
+		if _, network, err := net.ParseCIDR(operand); err == nil {
+			return func(value string) bool { return network.Contains(net.ParseIP(value)) }, nil
+		}
+
+		ip := net.ParseIP(operand)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address format: %s", operand)
+		}
+
+		return func(value string) bool { return ip.Equal(net.ParseIP(value)) }, nil
+	case matchMAC:
+		mac, err := net.ParseMAC(operand)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MAC address format: %v", err)
+		}
+
+		return func(value string) bool {
+			other, err := net.ParseMAC(value)
+			return err == nil && other.String() == mac.String()
+		}, nil
+	}
+
+	return func(value string) bool { return strings.EqualFold(value, operand) }, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"errors"
+	"testing"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+func TestParseMatch(t *testing.T) {
+	syn := &pb.Packet{
+		Direction: pb.Direction_rx,
+		SrcMac:    "00-0D-3A-6B-AE-F1",
+		DstMac:    "00-15-5D-70-41-2C",
+		Ethertype: "IPv4",
+		SrcIp:     "10.240.0.4",
+		DstIp:     "10.240.0.35",
+		SrcPort:   443,
+		DstPort:   52636,
+		Protocol:  "TCP",
+		TcpFlags:  []string{"SYN", "ACK"},
+		Length:    1400,
+	}
+
+	cases := []struct {
+		desc     string
+		expr     string
+		expected bool
+	}{
+		{"TestFlagAndLength", "tcp.flags.syn && len>1000", true},
+		{"TestLengthTooSmall", "tcp.flags.syn && len > 1500", false},
+		{"TestMissingFlag", "tcp.flags.rst", false},
+		{"TestOr", "udp || tcp.port == 443", true},
+		{"TestNot", "!(ip.src == 10.240.0.4)", false},
+		{"TestCIDR", "ip.addr == 10.240.0.0/24 and not drop", true},
+		{"TestNotEqual", "tcp.dstport != 443", true},
+		{"TestProtocolPort", "udp.port == 443", false},
+		{"TestMAC", "eth.dst == 00:15:5d:70:41:2c", true},
+		{"TestText", "direction == Rx && proto == tcp && ip", true},
+		{"TestHexNumber", "port <= 0x1bb", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			predicate, err := ParseMatch(tc.expr)
+			if err != nil {
+				t.Fatalf("ParseMatch failed: %v", err)
+			}
+
+			if actual := predicate(syn); actual != tc.expected {
+				t.Fatalf("expected '%s' to match: %t, got: %t", tc.expr, tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseMatchErrors(t *testing.T) {
+	cases := []struct {
+		desc string
+		expr string
+		pos  int
+	}{
+		{"TestEmpty", "", 0},
+		{"TestUnknownField", "tcp && foo.bar", 7},
+		{"TestComparedBool", "tcp.flags.syn == 1", 14},
+		{"TestOrderedText", "proto > tcp", 6},
+		{"TestInvalidNumber", "len > big", 6},
+		{"TestInvalidIP", "ip.src == 10.240", 10},
+		{"TestMissingValue", "len >", 5},
+		{"TestUnclosed", "(tcp || udp", 11},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := ParseMatch(tc.expr)
+
+			var filterErr *FilterError
+			if !errors.As(err, &filterErr) {
+				t.Fatalf("expected a filter error, got: %v", err)
+			}
+
+			if filterErr.Pos != tc.pos {
+				t.Fatalf("expected the error at position %d, got: %v", tc.pos, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sigs.k8s.io/yaml"
+)
+
+// Machine-readable output formats
+var OutputFormats = []string{"json", "yaml", "table"}
+
+// Result is the outcome of a request sent to a single node.
+type Result struct {
+	Node      string          `json:"node"`
+	IP        string          `json:"ip"`
+	Timestamp time.Time       `json:"timestamp"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	Error     string          `json:"error,omitempty"`
+
+	summary string // Shown in place of the payload in tables
+}
+
+// Output writes per-node results in a machine-readable format, in place of the usual banners.
+// JSON results are written as they arrive, one per line, while YAML and table output is written
+// as a single document once the Output is closed.
+type Output struct {
+	format string
+	out    io.Writer
+
+	mu      sync.Mutex
+	results []Result
+	closed  bool
+}
+
+// Payload of HCN responses, since hnsdiag output is sent as bytes but is text
+type hcnPayload struct {
+	Type    string          `json:"type"`
+	Objects json.RawMessage `json:"objects,omitempty"`
+	Raw     string          `json:"raw,omitempty"`
+
+	objects *pb.HCNObjects
+}
+
+// Payload of pcapng captures, which are written to files rather than output
+type pcapPayload struct {
+	File  string `json:"file"`
+	Bytes int    `json:"bytes"`
+}
+
+func newHCNPayload(hcntype string, res *pb.HCNResponse) hcnPayload {
+	p := hcnPayload{Type: hcntype, Raw: string(res.GetHcnResult()), objects: res.GetObjects()}
+
+	if p.objects != nil {
+		if b, err := (protojson.MarshalOptions{UseProtoNames: true}).Marshal(p.objects); err == nil {
+			p.Objects = b
+		}
+	}
+
+	return p
+}
+
+func NewOutput(format string, out io.Writer) (*Output, error) {
+	for _, f := range OutputFormats {
+		if f == format {
+			return &Output{format: format, out: out}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid output format '%s', must be one of: %s", format, strings.Join(OutputFormats, ", "))
+}
+
+// Write records the result of a request sent to node. If timestamp is nil, the current time is used.
+func (o *Output) Write(node Node, timestamp *timestamppb.Timestamp, payload interface{}, err error) {
+	res := Result{
+		Node:      node.Name,
+		IP:        node.Ip,
+		Timestamp: time.Now(),
+	}
+
+	if timestamp != nil {
+		res.Timestamp = timestamp.AsTime()
+	}
+
+	if err != nil {
+		res.Error = err.Error()
+	} else if payload != nil {
+		res.Payload, res.summary = encodePayload(payload)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.closed {
+		return
+	}
+
+	if o.format == "json" {
+		o.writeJSON(res)
+		return
+	}
+
+	o.results = append(o.results, res)
+}
+
+// Close writes out any buffered results. Results written afterwards are dropped. Closing a nil Output does nothing.
+func (o *Output) Close() {
+	if o == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.closed {
+		return
+	}
+	o.closed = true
+
+	switch o.format {
+	case "yaml":
+		o.writeYAML()
+	case "table":
+		o.writeTable()
+	}
+}
+
+func (o *Output) writeJSON(res Result) {
+	b, err := json.Marshal(res)
+	if err != nil {
+		fmt.Fprintf(o.out, "{\"node\":%q,\"error\":%q}\n", res.Node, err.Error())
+		return
+	}
+
+	fmt.Fprintf(o.out, "%s\n", b)
+}
+
+func (o *Output) writeYAML() {
+	results := o.results
+	if results == nil {
+		results = []Result{}
+	}
+
+	b, err := json.Marshal(results)
+	if err == nil {
+		b, err = yaml.JSONToYAML(b)
+	}
+
+	if err != nil {
+		fmt.Fprintf(o.out, "error: %v\n", err)
+		return
+	}
+
+	o.out.Write(b)
+}
+
+func (o *Output) writeTable() {
+	w := tabwriter.NewWriter(o.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tIP\tTIMESTAMP\tPAYLOAD\tERROR")
+
+	for _, res := range o.results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			res.Node, res.IP, res.Timestamp.Format(time.RFC3339Nano), res.summary, oneLine(res.Error))
+	}
+
+	w.Flush()
+}
+
+// Encodes a payload as JSON, along with a one line summary for tables
+func encodePayload(payload interface{}) (json.RawMessage, string) {
+	var b []byte
+	var err error
+
+	if msg, ok := payload.(proto.Message); ok {
+		b, err = (protojson.MarshalOptions{UseProtoNames: true}).Marshal(msg)
+	} else {
+		b, err = json.Marshal(payload)
+	}
+
+	if err != nil {
+		b, _ = json.Marshal(map[string]string{"encoding_error": err.Error()})
+	}
+
+	return json.RawMessage(b), summarize(payload)
+}
+
+func summarize(payload interface{}) string {
+	switch p := payload.(type) {
+	case *pb.CaptureResponse:
+		if len(p.GetCounters()) > 0 {
+			return summarizeCounters(p.GetCounters())
+		}
+		return oneLine(p.GetResult())
+	case *pb.StopCaptureResponse:
+		if len(p.GetCounters()) > 0 {
+			return "stopped, " + summarizeCounters(p.GetCounters())
+		}
+		return "stopped"
+	case *pb.CountersResponse:
+		return summarizeCounters(p.GetCounters())
+	case *pb.VFPCountersResponse:
+		ports := []string{}
+		for _, port := range p.GetPorts() {
+			dirs := []string{}
+			for _, d := range port.GetDirections() {
+				dirs = append(dirs, fmt.Sprintf("%s %d packets (%d dropped)", d.GetDirection(), d.GetPackets(), d.GetDroppedPackets()))
+			}
+			ports = append(ports, fmt.Sprintf("%s: %s", port.GetPortType(), strings.Join(dirs, ", ")))
+		}
+		return strings.Join(ports, "; ")
+	case *pb.VFPRulesResponse:
+		groups, rules := 0, 0
+		for _, layer := range p.GetLayers() {
+			groups += len(layer.GetGroups())
+			for _, group := range layer.GetGroups() {
+				rules += len(group.GetRules())
+			}
+		}
+		return fmt.Sprintf("port %s: %d layers, %d groups, %d rules", p.GetPortGuid(), len(p.GetLayers()), groups, rules)
+	case *pb.ListCapturesResponse:
+		ids := []string{}
+		for _, session := range p.GetSessions() {
+			ids = append(ids, session.GetSessionId())
+		}
+		return fmt.Sprintf("%d sessions %v", len(ids), ids)
+	case hcnPayload:
+		if p.objects == nil {
+			return fmt.Sprintf("%s: %d bytes of hnsdiag output", p.Type, len(p.Raw))
+		}
+		return fmt.Sprintf("%s: %d networks, %d endpoints, %d load balancers, %d namespaces", p.Type,
+			len(p.objects.GetNetworks()), len(p.objects.GetEndpoints()), len(p.objects.GetLoadBalancers()), len(p.objects.GetNamespaces()))
+	case []ServiceCheck:
+		issues := 0
+		for _, check := range p {
+			if len(check.Issues) > 0 {
+				issues++
+			}
+		}
+		return fmt.Sprintf("%d service ports, %d with issues", len(p), issues)
+	case []EndpointIssue:
+		counts := map[string]int{}
+		for _, issue := range p {
+			counts[issue.Issue]++
+		}
+		return fmt.Sprintf("%d issues %v", len(p), counts)
+	case pcapPayload:
+		return fmt.Sprintf("wrote %d bytes to %s", p.Bytes, p.File)
+	case *pb.ServerStatusResponse:
+		cleanup := p.GetCleanup()
+		return fmt.Sprintf("running since %s, %d sessions, cleaned up %d sessions and %d filters at startup",
+			p.GetStarted().AsTime().Format(time.RFC3339), len(p.GetSessions()), len(cleanup.GetSessions()), len(cleanup.GetFilters()))
+	case *pb.ListArtifactsResponse:
+		names := []string{}
+		for _, artifact := range p.GetArtifacts() {
+			names = append(names, artifact.GetName())
+		}
+		return fmt.Sprintf("%d capture files %v", len(names), names)
+	case artifactPayload:
+		return fmt.Sprintf("fetched %s, wrote %d bytes to %s", p.Artifact, p.Bytes, p.File)
+	}
+
+	return ""
+}
+
+func summarizeCounters(rows []*pb.ComponentCounters) string {
+	var in, out, dropped uint64
+	for _, c := range rows {
+		in += c.GetPacketsIn()
+		out += c.GetPacketsOut()
+		dropped += droppedPackets(c)
+	}
+
+	return fmt.Sprintf("%d components, %d packets in, %d packets out, %d dropped", len(rows), in, out, dropped)
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
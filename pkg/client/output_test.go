@@ -0,0 +1,99 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestOutput(t *testing.T) {
+	timestamp := timestamppb.New(time.Date(2022, 6, 20, 20, 35, 58, 0, time.UTC))
+	win1, win2 := Node{Name: "win1", Ip: "10.240.0.4"}, Node{Name: "win2", Ip: "10.240.0.5"}
+	counters := &pb.CountersResponse{
+		Timestamp: timestamp,
+		Counters:  []*pb.ComponentCounters{{ComponentId: 14, Name: "Container NIC 3f2a1b0c", PacketsIn: 2105, PacketsOut: 1616}},
+	}
+
+	cases := []struct {
+		desc     string
+		format   string
+		expected []string
+	}{
+		{
+			"TestJSON",
+			"json",
+			[]string{
+				`{"node":"win1","ip":"10.240.0.4","timestamp":"2022-06-20T20:35:58Z","payload":{"timestamp":"2022-06-20T20:35:58Z","counters":[{"component_id":14,"name":"Container NIC 3f2a1b0c","packets_in":"2105","packets_out":"1616"}]}}`,
+				`{"node":"win2","ip":"10.240.0.5","timestamp":"2022-06-20T20:35:58Z","error":"connection refused"}`,
+			},
+		},
+		{
+			"TestYAML",
+			"yaml",
+			[]string{
+				"- ip: 10.240.0.4",
+				"  node: win1",
+				"  payload:",
+				"    counters:",
+				"    - component_id: 14",
+				"- error: connection refused",
+				"  node: win2",
+			},
+		},
+		{
+			"TestTable",
+			"table",
+			[]string{
+				"NODE  IP          TIMESTAMP             PAYLOAD                                                     ERROR",
+				"win1  10.240.0.4  2022-06-20T20:35:58Z  1 components, 2105 packets in, 1616 packets out, 0 dropped",
+				"win2  10.240.0.5  2022-06-20T20:35:58Z                                                              connection refused",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			out, err := NewOutput(tc.format, &buf)
+			if err != nil {
+				t.Fatalf("NewOutput failed: %v", err)
+			}
+
+			out.Write(win1, counters.GetTimestamp(), counters, nil)
+			out.Write(win2, timestamp, nil, errors.New("connection refused"))
+			out.Close()
+
+			// Results written after closing are dropped
+			out.Write(win1, timestamp, counters, nil)
+
+			// Empty trailing cells in tables are padded, so ignore trailing spaces
+			lines := strings.Split(buf.String(), "\n")
+			for i := range lines {
+				lines[i] = strings.TrimRight(lines[i], " ")
+			}
+			actual := strings.Join(lines, "\n")
+			for _, line := range tc.expected {
+				if !strings.Contains(actual, line+"\n") {
+					t.Fatalf("expected output to contain line: \n%s\n got: \n%s\n", line, actual)
+				}
+			}
+
+			if tc.format == "json" && strings.Count(actual, "\n") != 2 {
+				t.Fatalf("expected a line per result, got: \n%s\n", actual)
+			}
+		})
+	}
+
+	if _, err := NewOutput("xml", &bytes.Buffer{}); err == nil {
+		t.Fatalf("expected an error for an invalid output format")
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+// FormatVFPRules renders a VFP port's layers, groups and rules as an indented tree.
+func FormatVFPRules(layers []*pb.VFPLayer) string {
+	var b strings.Builder
+
+	for _, layer := range layers {
+		fmt.Fprintf(&b, "Layer %s (priority: %d, flags: %s)\n", layer.GetName(), layer.GetPriority(), layer.GetFlags())
+
+		for _, group := range layer.GetGroups() {
+			fmt.Fprintf(&b, "  Group %s (priority: %d, direction: %s, type: %s)\n",
+				group.GetName(), group.GetPriority(), group.GetDirection(), group.GetType())
+			if len(group.GetConditions()) > 0 {
+				fmt.Fprintf(&b, "    Conditions: %s\n", formatConditions(group.GetConditions()))
+			}
+
+			for _, rule := range group.GetRules() {
+				fmt.Fprintf(&b, "    Rule %s (priority: %d, action: %s, flags: %s)\n",
+					rule.GetName(), rule.GetPriority(), rule.GetAction(), rule.GetFlags())
+				if len(rule.GetConditions()) > 0 {
+					fmt.Fprintf(&b, "      Conditions: %s\n", formatConditions(rule.GetConditions()))
+				}
+
+				keys := []string{}
+				for key := range rule.GetProperties() {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+
+				for _, key := range keys {
+					fmt.Fprintf(&b, "      %s: %s\n", key, rule.GetProperties()[key])
+				}
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func formatConditions(conditions []*pb.VFPCondition) string {
+	ret := []string{}
+	for _, c := range conditions {
+		ret = append(ret, c.GetField()+": "+c.GetValue())
+	}
+
+	return strings.Join(ret, ", ")
+}
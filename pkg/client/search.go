@@ -0,0 +1,131 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"regexp"
+
+	"github.com/microsoft/wcnspect/pkg/pkt"
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	highlightStart = "\033[1;31m"
+	highlightEnd   = "\033[0m"
+)
+
+// Search narrows the packets shown from capture streams to those matching a predicate and/or a pattern, without
+// changing the filters pktmon captures with. Output that isn't part of a packet, like counters, is always shown.
+type Search struct {
+	match     Predicate      // If set, packets must match it
+	grep      *regexp.Regexp // If set, one of the lines of packets must match it
+	highlight bool           // Whether matches of grep are highlighted
+}
+
+// NewSearch returns the search for packets matching the match expression and grep pattern, either of which can be
+// empty. Matches of the pattern are highlighted if highlight is set, which should only be done when printing to a
+// terminal.
+func NewSearch(match string, grep string, highlight bool) (*Search, error) {
+	s := &Search{highlight: highlight}
+
+	if match != "" {
+		predicate, err := ParseMatch(match)
+		if err != nil {
+			return nil, err
+		}
+		s.match = predicate
+	}
+
+	if grep != "" {
+		re, err := regexp.Compile(grep)
+		if err != nil {
+			return nil, err
+		}
+		s.grep = re
+	}
+
+	return s, nil
+}
+
+// Returns the record to show, with matches highlighted if enabled, and whether it should be shown at all
+func (s *Search) apply(record captureRecord) (captureRecord, bool) {
+	if !record.inPacket {
+		return record, true
+	}
+
+	// Packets the server couldn't parse can only be matched by pattern
+	if s.match != nil && (record.packet == nil || !s.match(record.packet)) {
+		return record, false
+	}
+
+	if s.grep == nil {
+		return record, true
+	}
+
+	matched := false
+	for _, line := range record.lines {
+		matched = matched || s.grep.MatchString(line.GetResult())
+	}
+
+	if !matched || !s.highlight {
+		return record, matched
+	}
+
+	highlighted := captureRecord{packet: record.packet, inPacket: true}
+	for _, line := range record.lines {
+		line = proto.Clone(line).(*pb.CaptureResponse)
+		line.Result = s.grep.ReplaceAllStringFunc(line.GetResult(), func(m string) string {
+			return highlightStart + m + highlightEnd
+		})
+		highlighted.lines = append(highlighted.lines, line)
+	}
+
+	return highlighted, true
+}
+
+// captureRecord is either a packet's lines from a capture stream, or a single line that isn't part of a packet.
+type captureRecord struct {
+	lines    []*pb.CaptureResponse
+	packet   *pb.Packet // The packet parsed by the server, if it could parse it
+	inPacket bool
+}
+
+// captureRecords groups the responses from a node's capture stream into records, so that a packet's lines are shown
+// or hidden, and ordered, together.
+//
+// The server parses a packet once its last line is streamed, and sends the packet along with that line. A packet
+// without a header line is only complete once the next one starts, so its packet comes with the next packet's first
+// line, or on its own once the stream ends.
+type captureRecords struct {
+	pending []*pb.CaptureResponse // The lines of the packet being streamed, which pktmon splits over two lines
+}
+
+// Takes the next response from the stream and returns the records it completes, if any
+func (r *captureRecords) add(res *pb.CaptureResponse) []captureRecord {
+	switch {
+	case pkt.IsPacketStart(res.GetResult()):
+		complete := r.flush(res.GetPacket())
+		r.pending = []*pb.CaptureResponse{res}
+		return complete
+	case len(r.pending) > 0 && res.GetPacket() != nil:
+		r.pending = append(r.pending, res)
+		return r.flush(res.GetPacket())
+	}
+
+	return []captureRecord{{lines: []*pb.CaptureResponse{res}}}
+}
+
+// Returns the pending packet as a record, packet being what the server parsed of it
+func (r *captureRecords) flush(packet *pb.Packet) []captureRecord {
+	if len(r.pending) == 0 {
+		return nil
+	}
+
+	record := captureRecord{lines: r.pending, packet: packet, inPacket: true}
+	r.pending = nil
+
+	return []captureRecord{record}
+}
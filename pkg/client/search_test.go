@@ -0,0 +1,103 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+// A capture stream as sent by the server, with each packet sent along with its last line
+var searchStream = []*pb.CaptureResponse{
+	{Result: "Logger Parameters:"},
+	{Result: "[00]0000.0000::2022-06-20 20:35:58.123601200 [Microsoft-Windows-PktMon] PktGroupId 1, PktNumber 1, Appearance 1, Direction Rx , Type Ethernet , Component 14, Edge 1, Filter 1, OriginalSize 74, LoggedSize 74"},
+	{
+		Result: "\t00-0D-3A-6B-AE-F1 > 00-15-5D-70-41-2C, ethertype IPv4 (0x0800), length 74: 10.240.0.4.443 > 10.240.0.35.52636: Flags [S.], seq 1, ack 2, win 65535, length 0",
+		Packet: &pb.Packet{SrcIp: "10.240.0.4", DstIp: "10.240.0.35", SrcPort: 443, DstPort: 52636, Protocol: "TCP", TcpFlags: []string{"SYN", "ACK"}, Length: 74},
+	},
+	{Result: "[01]0000.0000::2022-06-20 20:35:58.124010900 [Microsoft-Windows-PktMon] PktGroupId 2, PktNumber 1, Appearance 1, Direction Tx , Type Ethernet , Component 14, Edge 1, Filter 2, OriginalSize 84, LoggedSize 84"},
+	{
+		Result: "\t00-15-5D-70-41-2C > 00-0D-3A-6B-AE-F1, ethertype IPv4 (0x0800), length 84: 10.240.0.35.61207 > 10.0.0.10.53: 4821+ A? kubernetes.default.svc.cluster.local. (42)",
+		Packet: &pb.Packet{SrcIp: "10.240.0.35", DstIp: "10.0.0.10", SrcPort: 61207, DstPort: 53, Protocol: "UDP", Length: 84},
+	},
+	{Result: "[00]0000.0000::2022-06-20 20:36:01.000000100 [Microsoft-Windows-PktMon] PktGroupId 3, PktNumber 1, Appearance 1, Direction Rx , Type Ethernet , Component 9, Edge 1, Filter 0, OriginalSize 60, LoggedSize 60"},
+}
+
+// Returns the responses shown from the stream with the search applied
+func searchResponses(search *Search, stream []*pb.CaptureResponse) []*pb.CaptureResponse {
+	records := &captureRecords{}
+	complete := []captureRecord{}
+	for _, res := range stream {
+		complete = append(complete, records.add(res)...)
+	}
+	complete = append(complete, records.flush(nil)...)
+
+	shown := []*pb.CaptureResponse{}
+	for _, record := range complete {
+		if record, ok := search.apply(record); ok {
+			shown = append(shown, record.lines...)
+		}
+	}
+
+	return shown
+}
+
+func TestSearchStream(t *testing.T) {
+	cases := []struct {
+		desc      string
+		match     string
+		grep      string
+		highlight bool
+		expected  []int // indexes of the responses shown
+	}{
+		{"TestNoSearch", "", "", false, []int{0, 1, 2, 3, 4, 5}},
+		{"TestMatch", "tcp.flags.syn", "", false, []int{0, 1, 2}},
+		{"TestGrep", "", "kubernetes", false, []int{0, 3, 4}},
+		{"TestGrepMetadata", "", "Component 9", false, []int{0, 5}},
+		{"TestMatchAndGrep", "udp", "Component 14", false, []int{0, 3, 4}},
+		{"TestNoMatch", "icmp", "", false, []int{0}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			search, err := NewSearch(tc.match, tc.grep, tc.highlight)
+			if err != nil {
+				t.Fatalf("NewSearch failed: %v", err)
+			}
+
+			shown := searchResponses(search, searchStream)
+
+			expected := []*pb.CaptureResponse{}
+			for _, i := range tc.expected {
+				expected = append(expected, searchStream[i])
+			}
+
+			if !reflect.DeepEqual(shown, expected) {
+				t.Fatalf("expected: %v, got: %v", expected, shown)
+			}
+		})
+	}
+}
+
+func TestSearchHighlight(t *testing.T) {
+	search, err := NewSearch("", "10\\.0\\.0\\.10", true)
+	if err != nil {
+		t.Fatalf("NewSearch failed: %v", err)
+	}
+
+	shown := searchResponses(search, searchStream[3:5])
+
+	expected := "\t00-15-5D-70-41-2C > 00-0D-3A-6B-AE-F1, ethertype IPv4 (0x0800), length 84: 10.240.0.35.61207 > " +
+		highlightStart + "10.0.0.10" + highlightEnd + ".53: 4821+ A? kubernetes.default.svc.cluster.local. (42)"
+	if len(shown) != 2 || shown[1].GetResult() != expected {
+		t.Fatalf("expected the match to be highlighted, got: %v", shown)
+	}
+
+	// The stream's responses are left as they were
+	if searchStream[4].GetResult() == expected {
+		t.Fatal("expected the streamed response not to be modified")
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/microsoft/wcnspect/common"
+	"github.com/microsoft/wcnspect/pkg/k8sapi"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Servers maps each node to the wcnspect-server pod running on it, so the client connects to the address
+// and port the server actually listens on.
+type Servers struct {
+	namespace string
+	selector  string
+	port      string // Used when a pod's port can't be worked out from its spec
+
+	pods map[string]*v1.Pod // node name -> running server pod
+}
+
+// DiscoverServers lists the running server pods matching selector in namespace.
+func DiscoverServers(k8s *k8sapi.K8sapi, namespace string, selector string, port string) (*Servers, error) {
+	pods, err := k8s.ListPods(namespace, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wcnspect server pods: %v", err)
+	}
+
+	return newServers(pods.Items, namespace, selector, port), nil
+}
+
+func newServers(pods []v1.Pod, namespace string, selector string, port string) *Servers {
+	s := &Servers{namespace: namespace, selector: selector, port: port, pods: map[string]*v1.Pod{}}
+	for i := range pods {
+		if pods[i].Status.Phase == v1.PodRunning && pods[i].Spec.NodeName != "" {
+			s.pods[pods[i].Spec.NodeName] = &pods[i]
+		}
+	}
+
+	return s
+}
+
+func (s *Servers) Len() int {
+	return len(s.pods)
+}
+
+// Pod returns the server pod running on node, or an error if there isn't one.
+func (s *Servers) Pod(node string) (*v1.Pod, error) {
+	pod, ok := s.pods[node]
+	if !ok {
+		return nil, fmt.Errorf("no wcnspect server pod matching '%s' is running in namespace %s", s.selector, s.namespace)
+	}
+
+	return pod, nil
+}
+
+// Address returns the host and port of the server on node.
+func (s *Servers) Address(node Node) (string, string, error) {
+	pod, err := s.Pod(node.Name)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Server pods use the host's network, so fall back to the node's IP until the pod has one
+	host := pod.Status.PodIP
+	if host == "" {
+		host = node.Ip
+	}
+
+	return host, ServerPort(pod, s.port), nil
+}
+
+// ServerPort returns the port the server in pod listens on, or fallback if the pod doesn't say. Declared container
+// ports are preferred, otherwise the server's -p/--port argument is looked for in the container's command.
+func ServerPort(pod *v1.Pod, fallback string) string {
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.HostPort != 0 {
+				return strconv.Itoa(int(port.HostPort))
+			}
+
+			if port.ContainerPort != 0 {
+				return strconv.Itoa(int(port.ContainerPort))
+			}
+		}
+	}
+
+	for _, container := range pod.Spec.Containers {
+		// The server is usually started through powershell, so its arguments may all be in a single string
+		words := []string{}
+		for _, arg := range append(append([]string{}, container.Command...), container.Args...) {
+			words = append(words, strings.Fields(arg)...)
+		}
+
+		if port, ok := portArg(words); ok {
+			return port
+		}
+	}
+
+	if fallback == "" {
+		return common.DefaultServerPort
+	}
+
+	return fallback
+}
+
+// Looks for the value of the server's port flag among the words following the server executable
+func portArg(words []string) (string, bool) {
+	server := false
+	for i, word := range words {
+		if strings.Contains(word, common.ServerName) {
+			server = true
+			continue
+		}
+
+		if !server {
+			continue
+		}
+
+		var value string
+		switch {
+		case word == "-p" || word == "--port":
+			if i+1 < len(words) {
+				value = words[i+1]
+			}
+		case strings.HasPrefix(word, "-p="), strings.HasPrefix(word, "--port="):
+			value = word[strings.Index(word, "=")+1:]
+		default:
+			continue
+		}
+
+		if _, err := strconv.ParseUint(value, 10, 16); err == nil {
+			return value, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func serverPod(node string, ip string, phase v1.PodPhase, container v1.Container) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "wcnspect-server-" + node, Namespace: "kube-system"},
+		Spec:       v1.PodSpec{NodeName: node, Containers: []v1.Container{container}},
+		Status:     v1.PodStatus{Phase: phase, PodIP: ip},
+	}
+}
+
+func TestServerPort(t *testing.T) {
+	cases := []struct {
+		desc      string
+		container v1.Container
+		expected  string
+	}{
+		{
+			"TestDefault",
+			v1.Container{Command: []string{"powershell.exe", "-command", "./wcnspectserv.exe"}},
+			"50051",
+		},
+		{
+			"TestPortFlag",
+			v1.Container{Command: []string{"powershell.exe", "-command", "./wcnspectserv.exe -p 50052 --hns-backend hnsdiag"}},
+			"50052",
+		},
+		{
+			"TestLongPortFlag",
+			v1.Container{Command: []string{"./wcnspectserv.exe"}, Args: []string{"--port=50053"}},
+			"50053",
+		},
+		{
+			"TestFlagBeforeServer",
+			v1.Container{Command: []string{"powershell.exe", "-p", "1", "-command", "./wcnspectserv.exe"}},
+			"50051",
+		},
+		{
+			"TestContainerPort",
+			v1.Container{Command: []string{"./wcnspectserv.exe", "-p", "50052"}, Ports: []v1.ContainerPort{{ContainerPort: 50054}}},
+			"50054",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pod := serverPod("win1", "10.240.0.4", v1.PodRunning, tc.container)
+			if actual := ServerPort(&pod, "50051"); actual != tc.expected {
+				t.Fatalf("expected: %s, got: %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestServers(t *testing.T) {
+	server := v1.Container{Command: []string{"powershell.exe", "-command", "./wcnspectserv.exe -p 50052"}}
+	servers := newServers([]v1.Pod{
+		serverPod("win1", "10.240.0.4", v1.PodRunning, server),
+		serverPod("win2", "", v1.PodRunning, server),
+		serverPod("win3", "10.240.0.6", v1.PodPending, server),
+	}, "kube-system", "app=wcnspect-server", "50051")
+
+	cases := []struct {
+		desc      string
+		node      Node
+		expected  string
+		expectErr bool
+	}{
+		{"TestRunningPod", Node{Name: "win1", Ip: "10.240.0.4"}, "10.240.0.4:50052", false},
+		{"TestPodWithoutIP", Node{Name: "win2", Ip: "10.240.0.5"}, "10.240.0.5:50052", false},
+		{"TestPendingPod", Node{Name: "win3", Ip: "10.240.0.6"}, "", true},
+		{"TestMissingPod", Node{Name: "win4", Ip: "10.240.0.7"}, "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			host, port, err := servers.Address(tc.node)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error for node %s", tc.node.Name)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Address failed: %v", err)
+			}
+
+			if actual := host + ":" + port; actual != tc.expected {
+				t.Fatalf("expected: %s, got: %s", tc.expected, actual)
+			}
+		})
+	}
+
+	if servers.Len() != 2 {
+		t.Fatalf("expected 2 running server pods, got: %d", servers.Len())
+	}
+}
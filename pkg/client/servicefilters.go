@@ -0,0 +1,98 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"net"
+
+	"github.com/microsoft/wcnspect/pkg/k8sapi"
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ServiceFilters narrows filters to the traffic of service on the node named node: traffic to the service's VIPs on
+// its ports, and to the backends on the node on the ports the service targets. If nodeIP is set, traffic to it on the
+// service's NodePorts is matched too.
+//
+// The IPs and ports of filters narrow which of the service's addresses and ports are matched, rather than adding to
+// them. The filters returned have a set per kind of traffic left to match, and none if there's none.
+func ServiceFilters(filters *pb.Filters, service *k8sapi.ServiceEndpoints, node string, nodeIP string) *pb.Filters {
+	targets := []struct{ ips, ports []string }{
+		{service.VIPs, service.Ports},
+		{service.Backends[node], service.TargetPorts},
+	}
+
+	if nodeIP != "" {
+		targets = append(targets, struct{ ips, ports []string }{[]string{nodeIP}, service.NodePorts})
+	}
+
+	// A filter expression is made of sets, while filters passed as flags are one
+	sets := filters.GetSets()
+	if len(sets) == 0 {
+		sets = []*pb.Filters{filters}
+	}
+
+	ret := &pb.Filters{}
+	for _, set := range sets {
+		for _, target := range targets {
+			ips, ipPairs := narrowFilter(target.ips, set.GetIps(), set.GetIpPairs(), filterMatchesIP)
+			ports, portPairs := narrowFilter(target.ports, set.GetPorts(), set.GetPortPairs(), filterMatchesPort)
+			if len(ips)+len(ipPairs) == 0 || len(ports)+len(portPairs) == 0 {
+				continue
+			}
+
+			narrowed := proto.Clone(set).(*pb.Filters)
+			narrowed.Sets = nil
+			narrowed.Ips, narrowed.IpPairs = ips, ipPairs
+			narrowed.Ports, narrowed.PortPairs = ports, portPairs
+			ret.Sets = append(ret.Sets, narrowed)
+		}
+	}
+
+	return ret
+}
+
+// Returns the values matched by one of the filter's values, or all of them if it has none, along with the filter's
+// pairs that have a side matching one of them.
+func narrowFilter(values []string, filter []string, pairs []*pb.FilterPair, matches func(value string, filter string) bool) ([]string, []*pb.FilterPair) {
+	if len(filter)+len(pairs) == 0 {
+		return values, nil
+	}
+
+	narrowed, narrowedPairs := []string{}, []*pb.FilterPair{}
+	for _, value := range values {
+		for _, f := range filter {
+			if matches(value, f) {
+				narrowed = append(narrowed, value)
+				break
+			}
+		}
+	}
+
+	for _, pair := range pairs {
+		for _, value := range values {
+			if matches(value, pair.GetFirst()) || matches(value, pair.GetSecond()) {
+				narrowedPairs = append(narrowedPairs, pair)
+				break
+			}
+		}
+	}
+
+	return narrowed, narrowedPairs
+}
+
+// Returns whether ip is the filter's address, or in its CIDR
+func filterMatchesIP(ip string, filter string) bool {
+	if _, network, err := net.ParseCIDR(filter); err == nil {
+		return network.Contains(net.ParseIP(ip))
+	}
+
+	parsed := net.ParseIP(ip)
+	return parsed != nil && parsed.Equal(net.ParseIP(filter))
+}
+
+func filterMatchesPort(port string, filter string) bool {
+	return port == filter
+}
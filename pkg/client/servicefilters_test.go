@@ -0,0 +1,140 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/microsoft/wcnspect/pkg/k8sapi"
+	"github.com/microsoft/wcnspect/pkg/pkt"
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestServiceFilters(t *testing.T) {
+	service := &k8sapi.ServiceEndpoints{
+		VIPs:        []string{"10.0.0.10", "20.1.2.3", "20.1.2.4"},
+		Ports:       []string{"80", "443", "8443", "9090"},
+		Backends:    map[string][]string{},
+		TargetPorts: []string{"8080", "8081", "8082", "9091"},
+		NodePorts:   []string{"30080"},
+		Nodes:       []string{"win1", "win2", "win3"},
+	}
+	for i, node := range service.Nodes {
+		for j := 1; j <= 4; j++ {
+			service.Backends[node] = append(service.Backends[node], fmt.Sprintf("10.240.%d.%d", i, j))
+		}
+	}
+
+	cases := []struct {
+		desc     string
+		filters  *pb.Filters
+		node     string
+		nodeIP   string
+		expected *pb.Filters
+	}{
+		{
+			"TestServiceOnly",
+			&pb.Filters{Protocols: []string{"TCP"}},
+			"win2",
+			"",
+			&pb.Filters{Sets: []*pb.Filters{
+				{Protocols: []string{"TCP"}, Ips: service.VIPs, Ports: service.Ports},
+				{Protocols: []string{"TCP"}, Ips: service.Backends["win2"], Ports: service.TargetPorts},
+			}},
+		},
+		{
+			"TestNodePorts",
+			&pb.Filters{},
+			"win1",
+			"10.240.0.100",
+			&pb.Filters{Sets: []*pb.Filters{
+				{Ips: service.VIPs, Ports: service.Ports},
+				{Ips: service.Backends["win1"], Ports: service.TargetPorts},
+				{Ips: []string{"10.240.0.100"}, Ports: service.NodePorts},
+			}},
+		},
+		{
+			"TestNarrowedByFlags",
+			&pb.Filters{Ips: []string{"20.1.2.0/24", "10.240.0.2"}, Ports: []string{"443", "8080"}},
+			"win1",
+			"",
+			&pb.Filters{Sets: []*pb.Filters{
+				{Ips: []string{"20.1.2.3", "20.1.2.4"}, Ports: []string{"443"}},
+				{Ips: []string{"10.240.0.2"}, Ports: []string{"8080"}},
+			}},
+		},
+		{
+			"TestNarrowedByPair",
+			&pb.Filters{IpPairs: []*pb.FilterPair{{First: "10.0.0.10", Second: "10.244.1.5"}}},
+			"win1",
+			"",
+			&pb.Filters{Sets: []*pb.Filters{
+				{IpPairs: []*pb.FilterPair{{First: "10.0.0.10", Second: "10.244.1.5"}}, Ports: service.Ports},
+			}},
+		},
+		{
+			"TestNarrowedByExpression",
+			&pb.Filters{Sets: []*pb.Filters{{Ips: []string{"10.240.1.3"}}, {Ports: []string{"9090"}}}},
+			"win2",
+			"",
+			&pb.Filters{Sets: []*pb.Filters{
+				{Ips: []string{"10.240.1.3"}, Ports: service.TargetPorts},
+				{Ips: service.VIPs, Ports: []string{"9090"}},
+			}},
+		},
+		{
+			"TestNoMatchOnNode",
+			&pb.Filters{Ips: []string{"10.240.0.2"}},
+			"win2",
+			"",
+			&pb.Filters{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if actual := ServiceFilters(tc.filters, service, tc.node, tc.nodeIP); !proto.Equal(actual, tc.expected) {
+				t.Fatalf("expected: %v, got: %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestServiceFiltersWithinLimit(t *testing.T) {
+	service := &k8sapi.ServiceEndpoints{
+		VIPs:        []string{"10.0.0.10", "20.1.2.3"},
+		Ports:       []string{"80", "443", "8443"},
+		Backends:    map[string][]string{},
+		TargetPorts: []string{"8080", "8443", "9443"},
+		NodePorts:   []string{"30080", "30443", "30843"},
+	}
+
+	// Matching every address on every port would take far more pktmon filters than a capture may add
+	all := []string{}
+	for i := 1; i <= 4; i++ {
+		node := fmt.Sprintf("win%d", i)
+		for j := 1; j <= 5; j++ {
+			service.Backends[node] = append(service.Backends[node], fmt.Sprintf("10.240.%d.%d", i, j))
+		}
+		all = append(all, service.Backends[node]...)
+	}
+
+	ips := append(append(append([]string{}, service.VIPs...), all...), "10.240.1.100")
+	ports := append(append(append([]string{}, service.Ports...), service.TargetPorts...), service.NodePorts...)
+	if n := pkt.CountFilters(&pb.Filters{Ips: ips, Ports: ports}); n <= pkt.MaxFilters {
+		t.Fatalf("expected the service to take more than %d pktmon filters as a whole, got %d", pkt.MaxFilters, n)
+	}
+
+	filters := ServiceFilters(&pb.Filters{}, service, "win1", "10.240.1.100")
+	if n := pkt.CountFilters(filters); n != 2*3+5*3+1*3 {
+		t.Fatalf("expected %d pktmon filters for the node, got %d", 2*3+5*3+1*3, n)
+	}
+
+	if err := ValidateFilters(filters); err != nil {
+		t.Fatalf("expected the node's filters to be valid, got: %v", err)
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/microsoft/wcnspect/pkg/comprise"
+	"github.com/microsoft/wcnspect/pkg/netutil"
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+)
+
+// IP protocol numbers of the protocols Services can use
+var serviceProtocols = map[v1.Protocol]uint32{
+	v1.ProtocolTCP:  6,
+	v1.ProtocolUDP:  17,
+	v1.ProtocolSCTP: 132,
+}
+
+// ServiceSpec is what kube-proxy should have programmed in HNS for a Service on every node.
+type ServiceSpec struct {
+	Name       string // namespace/name
+	ClusterIPs []string
+	VIPs       []string // External and load balancer IPs
+	Ports      []ServicePort
+}
+
+// ServicePort is a single port of a Service, along with the backends it's forwarded to.
+type ServicePort struct {
+	Name        string
+	Protocol    v1.Protocol
+	Port        uint32
+	NodePort    uint32
+	TargetPorts []uint32 // As resolved in the Service's EndpointSlices
+	Backends    []string // IPs of the ready backends
+}
+
+// ServiceCheck reports whether a port of a Service is programmed as expected on a node.
+type ServiceCheck struct {
+	Service string   `json:"service"`
+	Port    string   `json:"port"`
+	Issues  []string `json:"issues,omitempty"`
+}
+
+// NewServiceSpec builds the spec of service from its EndpointSlices. Headless and ExternalName services aren't
+// load balanced by kube-proxy, so they return false.
+func NewServiceSpec(service *v1.Service, slices []discoveryv1.EndpointSlice) (ServiceSpec, bool) {
+	spec := ServiceSpec{Name: service.GetNamespace() + "/" + service.GetName()}
+	if service.Spec.Type == v1.ServiceTypeExternalName {
+		return spec, false
+	}
+
+	for _, ip := range service.Spec.ClusterIPs {
+		if ip != "" && ip != v1.ClusterIPNone {
+			spec.ClusterIPs = append(spec.ClusterIPs, ip)
+		}
+	}
+
+	if len(spec.ClusterIPs) == 0 {
+		return spec, false
+	}
+
+	spec.VIPs = append(spec.VIPs, service.Spec.ExternalIPs...)
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			spec.VIPs = append(spec.VIPs, ingress.IP)
+		}
+	}
+
+	for _, port := range service.Spec.Ports {
+		p := ServicePort{Name: port.Name, Protocol: port.Protocol, Port: uint32(port.Port), NodePort: uint32(port.NodePort)}
+		if p.Protocol == "" {
+			p.Protocol = v1.ProtocolTCP
+		}
+
+		for _, slice := range slices {
+			found := false
+			for _, slicePort := range slice.Ports {
+				if slicePort.Port != nil && (slicePort.Name == nil && port.Name == "" || slicePort.Name != nil && *slicePort.Name == port.Name) {
+					p.TargetPorts = append(p.TargetPorts, uint32(*slicePort.Port))
+					found = true
+				}
+			}
+
+			if !found {
+				continue
+			}
+
+			for _, endpoint := range slice.Endpoints {
+				if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+					p.Backends = append(p.Backends, endpoint.Addresses...)
+				}
+			}
+		}
+
+		p.Backends = comprise.Unique(p.Backends)
+		spec.Ports = append(spec.Ports, p)
+	}
+
+	return spec, true
+}
+
+// Returns the endpoints and load balancers in an HCN response, whichever HNS backend the server uses
+func hnsServiceObjects(res *pb.HCNResponse) (*pb.HCNObjects, error) {
+	if objs := res.GetObjects(); objs != nil {
+		return objs, nil
+	}
+
+	diagObjs, err := netutil.ParseHNSDiagLogs(res.GetHcnResult())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse hnsdiag output: %v", err)
+	}
+
+	objs := &pb.HCNObjects{}
+	for _, obj := range diagObjs {
+		if e := obj.HNSDiagEndpoint; e.IPAddress != "" {
+			objs.Endpoints = append(objs.Endpoints, &pb.HCNEndpoint{Id: e.ID, Name: e.Name, IpAddresses: []string{e.IPAddress}})
+		}
+
+		endpointIDs := []string{}
+		for _, ref := range obj.References {
+			if id := strings.TrimPrefix(ref, "/endpoints/"); id != ref {
+				endpointIDs = append(endpointIDs, id)
+			}
+		}
+
+		// Every port of a v1 load balancer is a policy with its own VIPs
+		for _, policy := range obj.Policies {
+			if policy.Type != "ELB" {
+				continue
+			}
+
+			objs.LoadBalancers = append(objs.LoadBalancers, &pb.HCNLoadBalancer{
+				Id:           obj.HNSDiagEndpoint.ID,
+				EndpointIds:  endpointIDs,
+				SourceVip:    obj.SourceVIP,
+				FrontendVips: policy.VIPs,
+				PortMappings: []*pb.HCNPortMapping{{Protocol: policy.Protocol, InternalPort: policy.InternalPort, ExternalPort: policy.ExternalPort}},
+			})
+		}
+	}
+
+	return objs, nil
+}
+
+// CheckServices compares the load balancers HNS has on a node with what every service should have.
+func CheckServices(services []ServiceSpec, objs *pb.HCNObjects) []ServiceCheck {
+	// Endpoint ID -> IPs, without their prefix length
+	endpointIPs := map[string][]string{}
+	for _, endpoint := range objs.GetEndpoints() {
+		for _, ip := range endpoint.GetIpAddresses() {
+			endpointIPs[strings.ToLower(endpoint.GetId())] = append(endpointIPs[strings.ToLower(endpoint.GetId())], strings.Split(ip, "/")[0])
+		}
+	}
+
+	ret := []ServiceCheck{}
+	for _, service := range services {
+		for _, port := range service.Ports {
+			check := ServiceCheck{Service: service.Name, Port: fmt.Sprintf("%d/%s", port.Port, port.Protocol)}
+			check.Issues = checkServicePort(service, port, objs.GetLoadBalancers(), endpointIPs)
+			ret = append(ret, check)
+		}
+	}
+
+	return ret
+}
+
+func checkServicePort(service ServiceSpec, port ServicePort, lbs []*pb.HCNLoadBalancer, endpointIPs map[string][]string) []string {
+	issues := []string{}
+	protocol := serviceProtocols[port.Protocol]
+
+	// VIP -> load balancers for the service port, and VIP -> ports it's programmed on instead
+	programmed := map[string][]*pb.HCNLoadBalancer{}
+	otherPorts := map[string][]uint32{}
+	nodePort := false
+	for _, lb := range lbs {
+		for _, mapping := range lb.GetPortMappings() {
+			if mapping.GetProtocol() != protocol {
+				continue
+			}
+
+			if port.NodePort != 0 && mapping.GetExternalPort() == port.NodePort {
+				nodePort = true
+			}
+
+			for _, vip := range lb.GetFrontendVips() {
+				if mapping.GetExternalPort() == port.Port {
+					programmed[vip] = append(programmed[vip], lb)
+				} else {
+					otherPorts[vip] = append(otherPorts[vip], mapping.GetExternalPort())
+				}
+			}
+		}
+	}
+
+	vips := append(append([]string{}, service.ClusterIPs...), service.VIPs...)
+	missing := []string{}
+	for _, vip := range vips {
+		if _, ok := programmed[vip]; ok {
+			continue
+		}
+
+		if ports := unexpectedPorts(otherPorts[vip], service.Ports); len(ports) > 0 {
+			issues = append(issues, fmt.Sprintf("VIP %s is programmed on port %v instead of %d", vip, ports, port.Port))
+			continue
+		}
+		missing = append(missing, vip)
+	}
+
+	if len(missing) == len(vips) && len(issues) == 0 && (port.NodePort == 0 || !nodePort) {
+		return []string{"not programmed by kube-proxy"}
+	}
+
+	if len(missing) > 0 {
+		issues = append(issues, fmt.Sprintf("missing VIPs %v", missing))
+	}
+
+	if port.NodePort != 0 && !nodePort {
+		issues = append(issues, fmt.Sprintf("missing NodePort %d", port.NodePort))
+	}
+
+	// Only cluster IPs are sure to balance across every backend, whatever the traffic policies
+	for _, vip := range service.ClusterIPs {
+		for _, lb := range programmed[vip] {
+			issues = append(issues, checkBackends(vip, port, lb, endpointIPs)...)
+		}
+	}
+
+	return issues
+}
+
+// Returns the ports which don't belong to any of the service's ports
+func unexpectedPorts(ports []uint32, servicePorts []ServicePort) []uint32 {
+	ret := []uint32{}
+	for _, p := range ports {
+		expected := false
+		for _, servicePort := range servicePorts {
+			expected = expected || servicePort.Port == p
+		}
+
+		if !expected {
+			ret = append(ret, p)
+		}
+	}
+
+	return ret
+}
+
+func checkBackends(vip string, port ServicePort, lb *pb.HCNLoadBalancer, endpointIPs map[string][]string) []string {
+	issues := []string{}
+
+	for _, mapping := range lb.GetPortMappings() {
+		if mapping.GetExternalPort() != port.Port || len(port.TargetPorts) == 0 {
+			continue
+		}
+
+		target := false
+		for _, p := range port.TargetPorts {
+			target = target || mapping.GetInternalPort() == p
+		}
+
+		if !target {
+			issues = append(issues, fmt.Sprintf("VIP %s forwards to port %d instead of %v", vip, mapping.GetInternalPort(), comprise.Unique(formatPorts(port.TargetPorts))))
+		}
+	}
+
+	lbIPs, unknown := []string{}, []string{}
+	for _, id := range lb.GetEndpointIds() {
+		ips, ok := endpointIPs[strings.ToLower(id)]
+		if !ok {
+			unknown = append(unknown, id)
+		}
+		lbIPs = append(lbIPs, ips...)
+	}
+
+	stale := []string{}
+	for _, ip := range lbIPs {
+		if !comprise.Contains(port.Backends, ip) {
+			stale = append(stale, ip)
+		}
+	}
+
+	missing := []string{}
+	for _, ip := range port.Backends {
+		if !comprise.Contains(lbIPs, ip) {
+			missing = append(missing, ip)
+		}
+	}
+
+	if len(stale) > 0 {
+		issues = append(issues, fmt.Sprintf("VIP %s has stale backends %v", vip, stale))
+	}
+
+	if len(unknown) > 0 {
+		issues = append(issues, fmt.Sprintf("VIP %s has backends without an HNS endpoint %v", vip, unknown))
+	}
+
+	if len(missing) > 0 {
+		issues = append(issues, fmt.Sprintf("VIP %s is missing backends %v", vip, missing))
+	}
+
+	return issues
+}
+
+func formatPorts(ports []uint32) []string {
+	ret := []string{}
+	for _, p := range ports {
+		ret = append(ret, fmt.Sprint(p))
+	}
+
+	return ret
+}
+
+// FormatServiceChecks renders a table with the status of every service port.
+func FormatServiceChecks(checks []ServiceCheck) string {
+	sort.SliceStable(checks, func(i, j int) bool { return checks[i].Service < checks[j].Service })
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Service\tPort\tStatus")
+	for _, check := range checks {
+		status := "ok"
+		if len(check.Issues) > 0 {
+			status = strings.Join(check.Issues, "; ")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", check.Service, check.Port, status)
+	}
+	w.Flush()
+
+	return buf.String()
+}
+
+func PrintServices(c pb.HCNServiceClient, services []ServiceSpec, reqCtx *ReqContext) error {
+	name, ip := reqCtx.Server.Name, reqCtx.Server.Ip
+
+	// Send request
+	// Servers shelling out to hnsdiag only list load balancers' ports and backends in detailed output
+	res, err := c.GetHCNLogs(context.Background(), &pb.HCNRequest{Hcntype: pb.HCNType_all, Verbose: true})
+	if err != nil {
+		return reqCtx.Done(fmt.Errorf("error while calling GetHCNLogs RPC: %v", err))
+	}
+
+	objs, err := hnsServiceObjects(res)
+	if err != nil {
+		return reqCtx.Done(err)
+	}
+
+	checks := CheckServices(services, objs)
+
+	if reqCtx.Output != nil {
+		reqCtx.Output.Write(reqCtx.Server, nil, checks, nil)
+		return reqCtx.Done(nil)
+	}
+
+	fmt.Printf("Services on %s (IP: %s):\n\n%s\n", name, ip, FormatServiceChecks(checks))
+
+	return reqCtx.Done(nil)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewServiceSpec(t *testing.T) {
+	port, name, ready, notReady := int32(8080), "http", true, false
+
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Spec: v1.ServiceSpec{
+			Type:        v1.ServiceTypeLoadBalancer,
+			ClusterIPs:  []string{"10.0.0.10"},
+			ExternalIPs: []string{"20.1.2.3"},
+			Ports:       []v1.ServicePort{{Name: "http", Protocol: v1.ProtocolTCP, Port: 80, NodePort: 30080}},
+		},
+		Status: v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "20.1.2.4"}}}},
+	}
+	slices := []discoveryv1.EndpointSlice{{
+		Ports: []discoveryv1.EndpointPort{{Name: &name, Port: &port}},
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.240.0.10"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready}},
+			{Addresses: []string{"10.240.0.11"}},
+			{Addresses: []string{"10.240.0.12"}, Conditions: discoveryv1.EndpointConditions{Ready: &notReady}},
+		},
+	}}
+
+	expected := ServiceSpec{
+		Name:       "shop/web",
+		ClusterIPs: []string{"10.0.0.10"},
+		VIPs:       []string{"20.1.2.3", "20.1.2.4"},
+		Ports: []ServicePort{{
+			Name: "http", Protocol: v1.ProtocolTCP, Port: 80, NodePort: 30080,
+			TargetPorts: []uint32{8080}, Backends: []string{"10.240.0.10", "10.240.0.11"},
+		}},
+	}
+
+	actual, ok := NewServiceSpec(service, slices)
+	if !ok || !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected: %+v, got: %+v (%t)", expected, actual, ok)
+	}
+
+	headless := &v1.Service{Spec: v1.ServiceSpec{ClusterIPs: []string{v1.ClusterIPNone}}}
+	if _, ok := NewServiceSpec(headless, nil); ok {
+		t.Fatalf("expected headless services to be skipped")
+	}
+}
+
+func TestCheckServices(t *testing.T) {
+	service := ServiceSpec{
+		Name:       "shop/web",
+		ClusterIPs: []string{"10.0.0.10"},
+		VIPs:       []string{"20.1.2.3"},
+		Ports: []ServicePort{{
+			Name: "http", Protocol: v1.ProtocolTCP, Port: 80, NodePort: 30080,
+			TargetPorts: []uint32{8080}, Backends: []string{"10.240.0.10", "10.240.0.11"},
+		}},
+	}
+
+	endpoints := []*pb.HCNEndpoint{
+		{Id: "EP1", IpAddresses: []string{"10.240.0.10/24"}},
+		{Id: "EP2", IpAddresses: []string{"10.240.0.11/24"}},
+		{Id: "EP3", IpAddresses: []string{"10.240.0.99/24"}},
+	}
+	lb := func(vip string, external uint32, internal uint32, ids ...string) *pb.HCNLoadBalancer {
+		lb := &pb.HCNLoadBalancer{EndpointIds: ids, PortMappings: []*pb.HCNPortMapping{{Protocol: 6, ExternalPort: external, InternalPort: internal}}}
+		if vip != "" {
+			lb.FrontendVips = []string{vip}
+		}
+		return lb
+	}
+
+	cases := []struct {
+		desc     string
+		lbs      []*pb.HCNLoadBalancer
+		expected []string
+	}{
+		{
+			"TestProgrammed",
+			[]*pb.HCNLoadBalancer{lb("10.0.0.10", 80, 8080, "ep1", "ep2"), lb("20.1.2.3", 80, 8080, "ep1"), lb("", 30080, 8080, "ep1", "ep2")},
+			[]string{},
+		},
+		{"TestNotProgrammed", []*pb.HCNLoadBalancer{}, []string{"not programmed by kube-proxy"}},
+		{
+			"TestMissingVIPAndNodePort",
+			[]*pb.HCNLoadBalancer{lb("10.0.0.10", 80, 8080, "ep1", "ep2")},
+			[]string{"missing VIPs [20.1.2.3]", "missing NodePort 30080"},
+		},
+		{
+			"TestWrongPorts",
+			[]*pb.HCNLoadBalancer{lb("10.0.0.10", 80, 9090, "ep1", "ep2"), lb("20.1.2.3", 81, 8080, "ep1"), lb("", 30080, 8080)},
+			[]string{"VIP 20.1.2.3 is programmed on port [81] instead of 80", "VIP 10.0.0.10 forwards to port 9090 instead of [8080]"},
+		},
+		{
+			"TestBackends",
+			[]*pb.HCNLoadBalancer{lb("10.0.0.10", 80, 8080, "ep1", "ep3", "ep4"), lb("20.1.2.3", 80, 8080), lb("", 30080, 8080)},
+			[]string{"VIP 10.0.0.10 has stale backends [10.240.0.99]", "VIP 10.0.0.10 has backends without an HNS endpoint [ep4]", "VIP 10.0.0.10 is missing backends [10.240.0.11]"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			checks := CheckServices([]ServiceSpec{service}, &pb.HCNObjects{Endpoints: endpoints, LoadBalancers: tc.lbs})
+			if len(checks) != 1 || checks[0].Service != "shop/web" || checks[0].Port != "80/TCP" {
+				t.Fatalf("expected a single check for shop/web 80/TCP, got: %+v", checks)
+			}
+
+			if !reflect.DeepEqual(checks[0].Issues, tc.expected) {
+				t.Fatalf("expected: %q, got: %q", tc.expected, checks[0].Issues)
+			}
+		})
+	}
+}
+
+func TestCheckServicesHnsdiag(t *testing.T) {
+	service := ServiceSpec{
+		Name:       "shop/web",
+		ClusterIPs: []string{"10.0.0.10"},
+		Ports: []ServicePort{{
+			Name: "http", Protocol: v1.ProtocolTCP, Port: 80, NodePort: 30080,
+			TargetPorts: []uint32{8080}, Backends: []string{"10.240.0.10", "10.240.0.11"},
+		}},
+	}
+
+	// Detailed output of hnsdiag list all, a v1 load balancer listing a policy per port
+	hnsdiag := `{"ID":"EP1","IPAddress":"10.240.0.10","MacAddress":"00-15-5D-70-41-2C","Policies":[{"Type":"OutBoundNAT"}],"VirtualNetwork":"2E6C4B8A"}
+{"ID":"EP2","IPAddress":"10.240.0.11","MacAddress":"00-15-5D-70-41-2D","Policies":[{"Type":"ROUTE"}],"VirtualNetwork":"2E6C4B8A"}
+{"ID":"2E6C4B8A","ManagementIP":"10.240.0.4","Name":"azure","Policies":[]}
+{"ID":"LB1","Policies":[{"ExternalPort":80,"InternalPort":8080,"Protocol":6,"Type":"ELB","VIPs":["10.0.0.10"]},{"ExternalPort":30080,"InternalPort":8080,"Protocol":6,"Type":"ELB","VIPs":[]}],"References":["/endpoints/EP1"],"SourceVIP":"10.240.0.60"}
+`
+
+	objs, err := hnsServiceObjects(&pb.HCNResponse{HcnResult: []byte(hnsdiag)})
+	if err != nil {
+		t.Fatalf("hnsServiceObjects failed: %v", err)
+	}
+
+	checks := CheckServices([]ServiceSpec{service}, objs)
+	expected := []string{"VIP 10.0.0.10 is missing backends [10.240.0.11]"}
+	if len(checks) != 1 || !reflect.DeepEqual(checks[0].Issues, expected) {
+		t.Fatalf("expected: %q, got: %+v", expected, checks)
+	}
+}
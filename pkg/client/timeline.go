@@ -0,0 +1,139 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"container/heap"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Timeline merges capture output streamed from several nodes into a single time-ordered view.
+// Lines are buffered for the reorder window before being written, so lines which arrive late
+// (by at most the window) are still written in order.
+type Timeline struct {
+	out    io.Writer
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	entries   timelineHeap
+	seq       int
+	done      chan struct{}
+	closeOnce sync.Once
+	stopped   sync.WaitGroup
+}
+
+type timelineEntry struct {
+	node      string
+	timestamp time.Time
+	lines     []string // Written together, like the lines of a packet
+	arrival   time.Time
+	seq       int // Keeps entries with equal timestamps in arrival order
+}
+
+func NewTimeline(out io.Writer, window time.Duration) *Timeline {
+	return &Timeline{
+		out:    out,
+		window: window,
+		now:    time.Now,
+		done:   make(chan struct{}),
+	}
+}
+
+// Start periodically writes out lines that have been held for the reorder window, until Close is called.
+func (t *Timeline) Start() {
+	interval := t.window / 4
+	if interval <= 0 {
+		interval = time.Millisecond * 100
+	}
+
+	t.stopped.Add(1)
+	go func() {
+		defer t.stopped.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				t.Flush()
+			case <-t.done:
+				return
+			}
+		}
+	}()
+}
+
+// Add buffers lines captured on node at timestamp, which are written together.
+func (t *Timeline) Add(node string, timestamp time.Time, lines ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.seq++
+	heap.Push(&t.entries, &timelineEntry{
+		node:      node,
+		timestamp: timestamp,
+		lines:     lines,
+		arrival:   t.now(),
+		seq:       t.seq,
+	})
+}
+
+// Flush writes out, in time order, every line that has been held for at least the reorder window.
+func (t *Timeline) Flush() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cutoff := t.now().Add(-t.window)
+	for len(t.entries) > 0 && !t.entries[0].arrival.After(cutoff) {
+		t.write(heap.Pop(&t.entries).(*timelineEntry))
+	}
+}
+
+// Close stops the timeline and writes out every remaining line.
+func (t *Timeline) Close() {
+	t.closeOnce.Do(func() { close(t.done) })
+	t.stopped.Wait()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for len(t.entries) > 0 {
+		t.write(heap.Pop(&t.entries).(*timelineEntry))
+	}
+}
+
+func (t *Timeline) write(e *timelineEntry) {
+	for _, line := range e.lines {
+		fmt.Fprintf(t.out, "[%s] %s %s\n", e.node, e.timestamp.UTC().Format(time.RFC3339Nano), line)
+	}
+}
+
+// timelineHeap implements heap.Interface, ordering entries by timestamp
+type timelineHeap []*timelineEntry
+
+func (h timelineHeap) Len() int { return len(h) }
+
+func (h timelineHeap) Less(i, j int) bool {
+	if h[i].timestamp.Equal(h[j].timestamp) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].timestamp.Before(h[j].timestamp)
+}
+
+func (h timelineHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *timelineHeap) Push(x any) { *h = append(*h, x.(*timelineEntry)) }
+
+func (h *timelineHeap) Pop() any {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	*h = old[:n-1]
+	return e
+}
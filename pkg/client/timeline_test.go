@@ -0,0 +1,151 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Replays a node's capture stream
+type fakeCaptureClient struct {
+	pb.CaptureServiceClient
+	responses []*pb.CaptureResponse
+}
+
+func (c *fakeCaptureClient) StartCapture(ctx context.Context, in *pb.CaptureRequest, opts ...grpc.CallOption) (pb.CaptureService_StartCaptureClient, error) {
+	return &fakeCaptureStream{responses: c.responses}, nil
+}
+
+type fakeCaptureStream struct {
+	grpc.ClientStream
+	responses []*pb.CaptureResponse
+}
+
+func (s *fakeCaptureStream) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+func (s *fakeCaptureStream) Recv() (*pb.CaptureResponse, error) {
+	if len(s.responses) == 0 {
+		return nil, io.EOF
+	}
+
+	res := s.responses[0]
+	s.responses = s.responses[1:]
+	return res, nil
+}
+
+func TestTimeline(t *testing.T) {
+	start := time.Date(2022, 6, 20, 20, 35, 58, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	var out bytes.Buffer
+	clock := start
+	timeline := NewTimeline(&out, time.Second)
+	timeline.now = func() time.Time { return clock }
+
+	// Lines from win2 arrive after win1's, despite some being captured earlier
+	timeline.Add("win1", at(100), "a")
+	timeline.Add("win1", at(300), "c")
+	clock = at(500)
+	timeline.Add("win2", at(200), "b")
+	timeline.Add("win2", at(300), "d")
+
+	// Nothing has been held for the full window yet
+	timeline.Flush()
+	if out.Len() != 0 {
+		t.Fatalf("expected no output before the reorder window passed, got: \n%s", out.String())
+	}
+
+	// Only win1's lines have been held for the full window, but 'b' must wait since it's still in the window
+	clock = at(1000)
+	timeline.Flush()
+	expected := "[win1] 2022-06-20T20:35:58.1Z a\n"
+	if out.String() != expected {
+		t.Fatalf("expected: \n%s\n got: \n%s\n", expected, out.String())
+	}
+
+	timeline.Add("win3", at(250), "late")
+	timeline.Close()
+
+	expected += strings.Join([]string{
+		"[win2] 2022-06-20T20:35:58.2Z b",
+		"[win3] 2022-06-20T20:35:58.25Z late",
+		"[win1] 2022-06-20T20:35:58.3Z c",
+		"[win2] 2022-06-20T20:35:58.3Z d",
+	}, "\n") + "\n"
+	if out.String() != expected {
+		t.Fatalf("expected: \n%s\n got: \n%s\n", expected, out.String())
+	}
+}
+
+func TestTimelineCaptureStream(t *testing.T) {
+	start := time.Date(2022, 6, 20, 20, 35, 58, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	// Returns a packet's lines as streamed by the server, sent well after pktmon captured it
+	packet := func(ms int, frame string) []*pb.CaptureResponse {
+		sent := timestamppb.New(at(ms + 5000))
+		return []*pb.CaptureResponse{
+			{
+				Timestamp: sent,
+				Result: fmt.Sprintf("[00]0000.0000::%s [Microsoft-Windows-PktMon] PktGroupId %d, PktNumber 1, Appearance 1, Direction Rx , Type Ethernet , Component 14, Edge 1, Filter 1, OriginalSize 74, LoggedSize 74",
+					at(ms).Format("2006-01-02 15:04:05.000000000"), ms),
+			},
+			{
+				Timestamp: sent,
+				Result:    "\t" + frame,
+				Packet:    &pb.Packet{Timestamp: timestamppb.New(at(ms))},
+			},
+		}
+	}
+
+	frames := map[int]string{
+		100: "00-0D-3A-6B-AE-F1 > 00-15-5D-70-41-2C, ethertype IPv4 (0x0800), length 74: 10.240.0.4.443 > 10.240.0.35.52636: Flags [S.], seq 1, ack 2, win 65535, length 0",
+		200: "00-15-5D-70-41-2C > 00-0D-3A-6B-AE-F1, ethertype IPv4 (0x0800), length 74: 10.240.0.35.52636 > 10.240.0.4.443: Flags [.], ack 1, win 65535, length 0",
+		300: "00-15-5D-70-41-2C > 00-0D-3A-6B-AE-F1, ethertype IPv4 (0x0800), length 84: 10.240.0.35.61207 > 10.0.0.10.53: 4821+ A? kubernetes.default.svc.cluster.local. (42)",
+		400: "00-0D-3A-6B-AE-F1 > 00-15-5D-70-41-2C, ethertype IPv4 (0x0800), length 100: 10.0.0.10.53 > 10.240.0.35.61207: 4821* 1/0/0 A 10.0.0.1 (58)",
+	}
+
+	// Packets are captured alternately on each node
+	nodes := map[int]string{100: "win1", 200: "win2", 300: "win1", 400: "win2"}
+	streams := map[string][]*pb.CaptureResponse{}
+	for _, ms := range []int{100, 200, 300, 400} {
+		streams[nodes[ms]] = append(streams[nodes[ms]], packet(ms, frames[ms])...)
+	}
+
+	var out bytes.Buffer
+	timeline := NewTimeline(&out, time.Minute)
+	for _, node := range []string{"win1", "win2"} {
+		reqCtx := &ReqContext{Server: Node{Name: node}, Timeline: timeline}
+		if err := RunCaptureStream(&fakeCaptureClient{responses: streams[node]}, &pb.CaptureRequest{}, reqCtx); err != nil {
+			t.Fatalf("RunCaptureStream failed: %v", err)
+		}
+	}
+	timeline.Close()
+
+	// Each packet's lines are kept together, at the time pktmon captured it
+	expected := []string{}
+	for _, ms := range []int{100, 200, 300, 400} {
+		for _, res := range packet(ms, frames[ms]) {
+			expected = append(expected, fmt.Sprintf("[%s] %s %s", nodes[ms], at(ms).Format(time.RFC3339Nano), res.GetResult()))
+		}
+	}
+
+	if out.String() != strings.Join(expected, "\n")+"\n" {
+		t.Fatalf("expected: \n%s\n got: \n%s\n", strings.Join(expected, "\n"), out.String())
+	}
+}
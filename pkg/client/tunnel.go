@@ -0,0 +1,80 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/microsoft/wcnspect/pkg/k8sapi"
+)
+
+// Tunnel reaches the server on each node through a port-forward to its wcnspect-server pod, made via the
+// Kubernetes API server. This lets the client run from anywhere the API server is reachable, rather than
+// only from machines in the nodes' network.
+type Tunnel struct {
+	forward func(namespace string, podName string, port string, stop <-chan struct{}) (uint16, error)
+	servers *Servers
+
+	mu       sync.Mutex
+	forwards map[string]*portForward // node name -> port-forward to the node's server
+}
+
+// A port-forward shared by the connections to a node's server, which runs until all of them are closed
+type portForward struct {
+	addr  string
+	stop  chan struct{}
+	users int
+}
+
+func NewTunnel(k8s *k8sapi.K8sapi, servers *Servers) *Tunnel {
+	return &Tunnel{forward: k8s.PortForward, servers: servers, forwards: map[string]*portForward{}}
+}
+
+// Address returns the local address forwarded to port on node's server pod, starting the port-forward if needed, along
+// with a function releasing it. The port-forward stops once every address returned for the node is released.
+func (t *Tunnel) Address(node Node, port string) (string, func(), error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fw, ok := t.forwards[node.Name]
+	if !ok {
+		pod, err := t.servers.Pod(node.Name)
+		if err != nil {
+			return "", nil, err
+		}
+
+		stop := make(chan struct{})
+		local, err := t.forward(pod.GetNamespace(), pod.GetName(), port, stop)
+		if err != nil {
+			return "", nil, err
+		}
+
+		fw = &portForward{addr: fmt.Sprintf("127.0.0.1:%d", local), stop: stop}
+		t.forwards[node.Name] = fw
+	}
+	fw.users++
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() { t.release(node.Name, fw) })
+	}
+
+	return fw.addr, release, nil
+}
+
+// Stops the node's port-forward once its last user releases it
+func (t *Tunnel) release(nodeName string, fw *portForward) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if fw.users--; fw.users > 0 {
+		return
+	}
+
+	close(fw.stop)
+	if t.forwards[nodeName] == fw {
+		delete(t.forwards, nodeName)
+	}
+}
@@ -11,10 +11,13 @@ import (
 
 	"github.com/microsoft/wcnspect/common"
 	"github.com/microsoft/wcnspect/pkg/comprise"
+	"github.com/microsoft/wcnspect/pkg/pkt"
+	pb "github.com/microsoft/wcnspect/rpc"
 )
 
 var validTCPFormats = comprise.Map(strings.Split(common.ValidTCPFlags, " "), func(s string) string { return "TCP_" + s })
-var validProtocols = append(strings.Split(common.ValidProtocols, " "), validTCPFormats...)
+var validProtocols = comprise.Map(append(strings.Split(common.ValidProtocols, " "), validTCPFormats...), strings.ToUpper)
+var validEthertypes = comprise.Map(strings.Split(common.ValidEthertypes, " "), strings.ToUpper)
 var validPktTypes = strings.Split(common.ValidPacketTypes, " ")
 
 func ValidateNodes(nodes []string, winNodes []string) error {
@@ -53,7 +56,8 @@ func ValidateIPAddrs(ips []string) error {
 
 func ValidateProtocols(protocols []string) error {
 	for _, prot := range protocols {
-		if !comprise.Contains(validProtocols, strings.ToUpper(prot)) {
+		// pktmon also takes the IP protocol number
+		if _, err := strconv.ParseUint(prot, 10, 8); err != nil && !comprise.Contains(validProtocols, strings.ToUpper(prot)) {
 			return fmt.Errorf("invalid protocol: %s", prot)
 		}
 	}
@@ -79,6 +83,107 @@ func ValidateMACAddrs(macs []string) error {
 	return nil
 }
 
+func ValidateEthertypes(ethertypes []string) error {
+	for _, ethertype := range ethertypes {
+		// Numbers can be given in decimal or hex, e.g. 0x86DD
+		if _, err := strconv.ParseUint(ethertype, 0, 16); err != nil && !comprise.Contains(validEthertypes, strings.ToUpper(ethertype)) {
+			return fmt.Errorf("invalid ethertype: %s", ethertype)
+		}
+	}
+	return nil
+}
+
+func ValidateVLANs(vlans []uint32) error {
+	for _, vlan := range vlans {
+		if vlan > 4095 {
+			return fmt.Errorf("invalid VLAN ID: %d", vlan)
+		}
+	}
+	return nil
+}
+
+// SplitFilterPairs separates the values matched on either side from the pairs, given as "a=b", matched only on
+// traffic between both sides.
+func SplitFilterPairs(values []string) ([]string, []*pb.FilterPair) {
+	singles, pairs := []string{}, []*pb.FilterPair{}
+	for _, value := range values {
+		if first, second, ok := strings.Cut(value, "="); ok {
+			pairs = append(pairs, &pb.FilterPair{First: first, Second: second})
+		} else {
+			singles = append(singles, value)
+		}
+	}
+
+	return singles, pairs
+}
+
+// Returns the values of the filter pairs, both sides of each
+func pairValues(pairs []*pb.FilterPair) []string {
+	values := []string{}
+	for _, pair := range pairs {
+		values = append(values, pair.GetFirst(), pair.GetSecond())
+	}
+
+	return values
+}
+
+// ValidateFilters checks every value of the filters, and that encapsulation is only matched along with them.
+func ValidateFilters(filters *pb.Filters) error {
+	if err := ValidateIPAddrs(append(filters.GetIps(), pairValues(filters.GetIpPairs())...)); err != nil {
+		return err
+	}
+
+	if err := ValidateProtocols(filters.GetProtocols()); err != nil {
+		return err
+	}
+
+	if err := ValidatePorts(append(filters.GetPorts(), pairValues(filters.GetPortPairs())...)); err != nil {
+		return err
+	}
+
+	if err := ValidateMACAddrs(append(filters.GetMacs(), pairValues(filters.GetMacPairs())...)); err != nil {
+		return err
+	}
+
+	if err := ValidateEthertypes(filters.GetEthertypes()); err != nil {
+		return err
+	}
+
+	if err := ValidateVLANs(filters.GetVlans()); err != nil {
+		return err
+	}
+
+	if filters.GetVxlanPort() != 0 && !filters.GetEncap() {
+		return fmt.Errorf("a VXLAN port can only be given when matching encapsulated packets")
+	}
+
+	if filters.GetVxlanPort() > 65535 {
+		return fmt.Errorf("invalid VXLAN port: %d", filters.GetVxlanPort())
+	}
+
+	// pktmon has nothing to match against the inner headers otherwise
+	empty := len(filters.GetIps())+len(filters.GetIpPairs())+len(filters.GetProtocols())+len(filters.GetPorts())+
+		len(filters.GetPortPairs())+len(filters.GetMacs())+len(filters.GetMacPairs())+len(filters.GetEthertypes())+
+		len(filters.GetVlans()) == 0
+	if filters.GetEncap() && empty {
+		return fmt.Errorf("matching encapsulated packets requires other filters to match them against")
+	}
+
+	for _, set := range filters.GetSets() {
+		if err := ValidateFilters(set); err != nil {
+			return err
+		}
+	}
+
+	// pktmon needs a filter for every combination of values, which adds up quickly
+	if n := pkt.CountFilters(filters); n > pkt.MaxFilters {
+		return fmt.Errorf("the filters make up %d pktmon filters, one per combination of their IPs, ports, MACs, "+
+			"ethertypes, VLANs and protocols, more than the %d a capture may add; filter on fewer values", n, pkt.MaxFilters)
+	}
+
+	return nil
+}
+
 func ValidatePktType(pktType string) error {
 	if !comprise.Contains(validPktTypes, strings.ToUpper(pktType)) {
 		return fmt.Errorf("invalid packet type: %s", pktType)
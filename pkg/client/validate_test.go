@@ -0,0 +1,69 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package client
+
+import (
+	"fmt"
+	"testing"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+func TestValidateFilters(t *testing.T) {
+	ips := func(n int) []string {
+		ret := []string{}
+		for i := 1; i <= n; i++ {
+			ret = append(ret, fmt.Sprintf("10.0.0.%d", i))
+		}
+		return ret
+	}
+	ports := func(n int) []string {
+		ret := []string{}
+		for i := 1; i <= n; i++ {
+			ret = append(ret, fmt.Sprint(8000+i))
+		}
+		return ret
+	}
+
+	cases := []struct {
+		desc    string
+		filters *pb.Filters
+		fails   bool
+	}{
+		{"TestNoFilters", &pb.Filters{}, false},
+		{"TestProtocols", &pb.Filters{Protocols: []string{"icmpv6", "TCP_SYN", "47"}}, false},
+		{"TestInvalidProtocolNumber", &pb.Filters{Protocols: []string{"256"}}, true},
+		{"TestEthertypes", &pb.Filters{Ethertypes: []string{"IPv4", "arp", "0x86DD", "2048"}}, false},
+		{"TestInvalidEthertype", &pb.Filters{Ethertypes: []string{"0x10000"}}, true},
+		{"TestInvalidVLAN", &pb.Filters{Vlans: []uint32{4096}}, true},
+		{"TestPairs", &pb.Filters{IpPairs: []*pb.FilterPair{{First: "10.244.1.5", Second: "10.244.2.0/24"}}}, false},
+		{"TestInvalidPair", &pb.Filters{PortPairs: []*pb.FilterPair{{First: "80", Second: ""}}}, true},
+		{"TestEncap", &pb.Filters{Ips: []string{"10.244.1.5"}, Encap: true, VxlanPort: 4790}, false},
+		{"TestOnlyEncap", &pb.Filters{Encap: true}, true},
+		{"TestVXLANPortWithoutEncap", &pb.Filters{Ips: []string{"10.244.1.5"}, VxlanPort: 4790}, true},
+		{"TestTooManyCombinations", &pb.Filters{Ips: ips(9), Ports: ports(8)}, true},
+		{"TestTooManyAcrossSets", &pb.Filters{Sets: []*pb.Filters{{Ips: ips(5), Ports: ports(8)}, {Ips: ips(5), Ports: ports(8)}}}, true},
+		{"TestCombinationsWithinLimit", &pb.Filters{Ips: ips(8), Ports: ports(8)}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if err := ValidateFilters(tc.filters); (err != nil) != tc.fails {
+				t.Fatalf("expected failure: %t got error: %v", tc.fails, err)
+			}
+		})
+	}
+}
+
+func TestSplitFilterPairs(t *testing.T) {
+	singles, pairs := SplitFilterPairs([]string{"10.240.0.4", "10.244.1.5=10.244.2.7"})
+
+	if len(singles) != 1 || singles[0] != "10.240.0.4" {
+		t.Fatalf("expected 10.240.0.4 to be matched on either side, got: %v", singles)
+	}
+
+	if len(pairs) != 1 || pairs[0].GetFirst() != "10.244.1.5" || pairs[0].GetSecond() != "10.244.2.7" {
+		t.Fatalf("expected a pair of 10.244.1.5 and 10.244.2.7, got: %v", pairs)
+	}
+}
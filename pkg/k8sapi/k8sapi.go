@@ -5,14 +5,18 @@ package k8sapi
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
 	"path/filepath"
 
 	"github.com/microsoft/wcnspect/common"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -26,7 +30,7 @@ type K8sapi struct {
 }
 
 // Constructor for K8sapi
-func New(config string) K8sapi {
+func New(config string) (K8sapi, error) {
 	if config == "" {
 		// $HOME/.kube/config
 		home := homedir.HomeDir()
@@ -39,52 +43,61 @@ func New(config string) K8sapi {
 		} else if _, err := ioutil.ReadFile(filename); err == nil {
 			config = filename
 		} else {
-			log.Fatal("Error reading $KUBECONFIG environment variable. Exiting...")
+			return K8sapi{}, errors.New("error reading $KUBECONFIG environment variable")
 		}
 	}
 	// Use the current context in kubeconfig
 	kubeconfig, err := clientcmd.BuildConfigFromFlags("", config)
 	if err != nil {
-		log.Fatalln("Error reading kubeconfig: ", err)
+		return K8sapi{}, fmt.Errorf("error reading kubeconfig: %v", err)
 	}
 	// Create the clientset
 	clientset, err := kubernetes.NewForConfig(kubeconfig)
 	if err != nil {
-		log.Fatalln("Error reading kubeconfig: ", err)
+		return K8sapi{}, fmt.Errorf("error reading kubeconfig: %v", err)
 	}
-	return K8sapi{kubeconfig, clientset}
+	return K8sapi{kubeconfig, clientset}, nil
 }
 
-func (k8sclient *K8sapi) GetPod(podName string, kubenamespace string) *v1.Pod {
-	pod, err := k8sclient.conn.CoreV1().Pods(kubenamespace).Get(context.TODO(), podName, metav1.GetOptions{})
-	if err != nil {
-		log.Fatal(err)
-	}
-	return pod
+func (k8sclient *K8sapi) GetPod(podName string, kubenamespace string) (*v1.Pod, error) {
+	return k8sclient.conn.CoreV1().Pods(kubenamespace).Get(context.TODO(), podName, metav1.GetOptions{})
 }
 
-func (k8sclient *K8sapi) GetNode(nodeName string) *v1.Node {
-	node, err := k8sclient.conn.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
-	if err != nil {
-		log.Fatal(err)
-	}
-	return node
+func (k8sclient *K8sapi) GetNode(nodeName string) (*v1.Node, error) {
+	return k8sclient.conn.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
 }
 
-func (k8sclient *K8sapi) GetAllNodesWindows() *v1.NodeList {
-	nodes, err := k8sclient.conn.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{LabelSelector: "kubernetes.io/os=windows"})
-	if err != nil {
-		log.Fatal(err)
-	}
-	return nodes
+func (k8sclient *K8sapi) GetAllNodesWindows() (*v1.NodeList, error) {
+	return k8sclient.conn.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{LabelSelector: "kubernetes.io/os=windows"})
 }
 
-func (k8sclient *K8sapi) GetNamespace(namespace string) *v1.Namespace {
-	ns, err := k8sclient.conn.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
-	if err != nil {
-		log.Fatal(err)
-	}
-	return ns
+func (k8sclient *K8sapi) ListPods(namespace string, selector string) (*v1.PodList, error) {
+	return k8sclient.conn.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+}
+
+func (k8sclient *K8sapi) GetDeployment(name string, namespace string) (*appsv1.Deployment, error) {
+	return k8sclient.conn.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (k8sclient *K8sapi) GetService(name string, namespace string) (*v1.Service, error) {
+	return k8sclient.conn.CoreV1().Services(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (k8sclient *K8sapi) ListServices(namespace string) (*v1.ServiceList, error) {
+	return k8sclient.conn.CoreV1().Services(namespace).List(context.TODO(), metav1.ListOptions{})
+}
+
+func (k8sclient *K8sapi) ListAllEndpointSlices(namespace string) (*discoveryv1.EndpointSliceList, error) {
+	return k8sclient.conn.DiscoveryV1().EndpointSlices(namespace).List(context.TODO(), metav1.ListOptions{})
+}
+
+func (k8sclient *K8sapi) ListEndpointSlices(service string, namespace string) (*discoveryv1.EndpointSliceList, error) {
+	selector := labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: service}).String()
+	return k8sclient.conn.DiscoveryV1().EndpointSlices(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+}
+
+func (k8sclient *K8sapi) GetNamespace(namespace string) (*v1.Namespace, error) {
+	return k8sclient.conn.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
 }
 
 // Operations on v1.Node objects
@@ -148,3 +161,41 @@ func WindowsOS(node v1.Node) bool {
 }
 
 /* Pod Methods */
+
+// Returns the label selector matching the pods of a deployment
+func DeploymentSelector(deployment *appsv1.Deployment) (string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return "", fmt.Errorf("invalid selector in deployment %s: %v", deployment.GetName(), err)
+	}
+
+	return selector.String(), nil
+}
+
+// Returns the label selector matching the pods backing a service
+func ServiceSelector(service *v1.Service) (string, error) {
+	if len(service.Spec.Selector) == 0 {
+		return "", fmt.Errorf("service %s has no selector", service.GetName())
+	}
+
+	return labels.SelectorFromSet(service.Spec.Selector).String(), nil
+}
+
+/* Groups the IPs of running pods by the node they're scheduled on.
+Pods using the host's network are skipped, since they don't have an endpoint of their own.
+*/
+func GroupPodIPs(pods []v1.Pod) map[string][]string {
+	ret := map[string][]string{}
+	seen := map[string]bool{}
+	for _, pod := range pods {
+		nodeName, podIP := pod.Spec.NodeName, pod.Status.PodIP
+		if pod.Status.Phase != v1.PodRunning || pod.Spec.HostNetwork || nodeName == "" || podIP == "" || seen[podIP] {
+			continue
+		}
+
+		seen[podIP] = true
+		ret[nodeName] = append(ret[nodeName], podIP)
+	}
+
+	return ret
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package k8sapi
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func pod(name string, node string, ip string, phase v1.PodPhase, hostNetwork bool) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1.PodSpec{NodeName: node, HostNetwork: hostNetwork},
+		Status:     v1.PodStatus{Phase: phase, PodIP: ip},
+	}
+}
+
+func TestGroupPodIPs(t *testing.T) {
+	pods := []v1.Pod{
+		pod("web-1", "win1", "10.240.0.10", v1.PodRunning, false),
+		pod("web-2", "win2", "10.240.0.20", v1.PodRunning, false),
+		pod("web-3", "win1", "10.240.0.11", v1.PodRunning, false),
+		pod("web-4", "win1", "10.240.0.12", v1.PodRunning, false),
+		pod("pending", "", "", v1.PodPending, false),
+		pod("completed", "win2", "10.240.0.21", v1.PodSucceeded, false),
+		pod("kube-proxy", "win2", "10.240.0.5", v1.PodRunning, true),
+		pod("web-1", "win1", "10.240.0.10", v1.PodRunning, false), // Matched by both name and selector
+	}
+
+	expected := map[string][]string{
+		"win1": {"10.240.0.10", "10.240.0.11", "10.240.0.12"},
+		"win2": {"10.240.0.20"},
+	}
+
+	if actual := GroupPodIPs(pods); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, actual)
+	}
+}
+
+func TestSelectors(t *testing.T) {
+	deployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{
+		MatchLabels:      map[string]string{"app": "web"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"frontend"}}},
+	}}}
+	if selector, err := DeploymentSelector(deployment); err != nil || selector != "app=web,tier in (frontend)" {
+		t.Fatalf("expected: app=web,tier in (frontend), got: %s (%v)", selector, err)
+	}
+
+	service := &v1.Service{Spec: v1.ServiceSpec{Selector: map[string]string{"app": "web", "tier": "frontend"}}}
+	if selector, err := ServiceSelector(service); err != nil || selector != "app=web,tier=frontend" {
+		t.Fatalf("expected: app=web,tier=frontend, got: %s (%v)", selector, err)
+	}
+
+	// Services without selectors have their endpoints managed by hand
+	if _, err := ServiceSelector(&v1.Service{}); err == nil {
+		t.Fatalf("expected an error for a service without a selector")
+	}
+}
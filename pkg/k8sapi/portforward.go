@@ -0,0 +1,50 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package k8sapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+/* Forwards a random local port to port on the pod through the API server's port-forward subresource.
+Returns the local port once it's listening. Forwarding stops when stop is closed.
+*/
+func (k8sclient *K8sapi) PortForward(namespace string, podName string, port string, stop <-chan struct{}) (uint16, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(k8sclient.config)
+	if err != nil {
+		return 0, err
+	}
+
+	url := k8sclient.conn.CoreV1().RESTClient().Post().Resource("pods").Namespace(namespace).Name(podName).SubResource("portforward").URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	ready := make(chan struct{})
+	fw, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{"0:" + port}, stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return 0, err
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- fw.ForwardPorts()
+	}()
+
+	select {
+	case <-ready:
+	case err := <-errs:
+		return 0, fmt.Errorf("failed to port-forward to pod %s/%s: %v", namespace, podName, err)
+	}
+
+	ports, err := fw.GetPorts()
+	if err != nil {
+		return 0, err
+	}
+
+	return ports[0].Local, nil
+}
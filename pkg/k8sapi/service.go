@@ -0,0 +1,80 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package k8sapi
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/microsoft/wcnspect/pkg/comprise"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+)
+
+// ServiceEndpoints holds the addresses traffic to a service is sent to, and the nodes it ends up on.
+type ServiceEndpoints struct {
+	VIPs        []string            // Cluster, external and load balancer IPs
+	Ports       []string            // Ports the service listens on at its VIPs
+	Backends    map[string][]string // IPs of the pods backing the service, by the node hosting them ("" if unknown)
+	TargetPorts []string            // Ports the service's ports target on backends
+	NodePorts   []string
+	Nodes       []string // Nodes hosting backends
+}
+
+// ResolveService collects the addresses of service and the backends listed in its EndpointSlices.
+func ResolveService(service *v1.Service, slices []discoveryv1.EndpointSlice) ServiceEndpoints {
+	ret := ServiceEndpoints{Backends: map[string][]string{}}
+
+	for _, ip := range append(append([]string{service.Spec.ClusterIP}, service.Spec.ClusterIPs...), service.Spec.ExternalIPs...) {
+		if ip != "" && ip != v1.ClusterIPNone {
+			ret.VIPs = append(ret.VIPs, ip)
+		}
+	}
+
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			ret.VIPs = append(ret.VIPs, ingress.IP)
+		}
+	}
+
+	for _, port := range service.Spec.Ports {
+		ret.Ports = append(ret.Ports, strconv.Itoa(int(port.Port)))
+		if port.NodePort != 0 {
+			ret.NodePorts = append(ret.NodePorts, strconv.Itoa(int(port.NodePort)))
+		}
+	}
+
+	for _, slice := range slices {
+		// Named target ports are only resolved in EndpointSlices
+		for _, port := range slice.Ports {
+			if port.Port != nil {
+				ret.TargetPorts = append(ret.TargetPorts, strconv.Itoa(int(*port.Port)))
+			}
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			nodeName := ""
+			if endpoint.NodeName != nil {
+				nodeName = *endpoint.NodeName
+			}
+
+			ret.Backends[nodeName] = append(ret.Backends[nodeName], endpoint.Addresses...)
+			if nodeName != "" {
+				ret.Nodes = append(ret.Nodes, nodeName)
+			}
+		}
+	}
+
+	ret.VIPs = comprise.Unique(ret.VIPs)
+	for nodeName, backends := range ret.Backends {
+		ret.Backends[nodeName] = comprise.Unique(backends)
+	}
+	ret.Ports = comprise.Unique(ret.Ports)
+	ret.TargetPorts = comprise.Unique(ret.TargetPorts)
+	ret.NodePorts = comprise.Unique(ret.NodePorts)
+	ret.Nodes = comprise.Unique(ret.Nodes)
+	sort.Strings(ret.Nodes)
+
+	return ret
+}
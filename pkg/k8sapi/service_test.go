@@ -0,0 +1,63 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package k8sapi
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+)
+
+func TestResolveService(t *testing.T) {
+	port := func(p int32) *int32 { return &p }
+	node := func(name string) *string { return &name }
+
+	service := &v1.Service{
+		Spec: v1.ServiceSpec{
+			ClusterIP:   "10.0.0.10",
+			ClusterIPs:  []string{"10.0.0.10"},
+			ExternalIPs: []string{"20.1.2.3"},
+			Ports: []v1.ServicePort{
+				{Name: "http", Port: 80, NodePort: 30080},
+				{Name: "metrics", Port: 9090},
+			},
+		},
+		Status: v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "20.1.2.4"}, {Hostname: "lb.example.com"}}}},
+	}
+
+	slices := []discoveryv1.EndpointSlice{
+		{
+			Ports: []discoveryv1.EndpointPort{{Port: port(8080)}, {Port: port(9090)}},
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.240.0.20"}, NodeName: node("win2")},
+				{Addresses: []string{"10.240.0.10"}, NodeName: node("win1")},
+			},
+		},
+		{
+			Ports:     []discoveryv1.EndpointPort{{Port: port(8080)}, {Port: port(9090)}},
+			Endpoints: []discoveryv1.Endpoint{{Addresses: []string{"10.240.0.11"}, NodeName: node("win1")}, {Addresses: []string{"10.240.0.30"}}},
+		},
+	}
+
+	expected := ServiceEndpoints{
+		VIPs:        []string{"10.0.0.10", "20.1.2.3", "20.1.2.4"},
+		Ports:       []string{"80", "9090"},
+		Backends:    map[string][]string{"win1": {"10.240.0.10", "10.240.0.11"}, "win2": {"10.240.0.20"}, "": {"10.240.0.30"}},
+		TargetPorts: []string{"8080", "9090"},
+		NodePorts:   []string{"30080"},
+		Nodes:       []string{"win1", "win2"},
+	}
+
+	if actual := ResolveService(service, slices); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected: %+v, got: %+v", expected, actual)
+	}
+
+	// Headless services don't have a VIP
+	headless := &v1.Service{Spec: v1.ServiceSpec{ClusterIP: v1.ClusterIPNone, ClusterIPs: []string{v1.ClusterIPNone}}}
+	if actual := ResolveService(headless, nil); len(actual.VIPs) != 0 {
+		t.Fatalf("expected no VIPs for a headless service, got: %v", actual.VIPs)
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+//go:build !windows
+
+package netutil
+
+import "fmt"
+
+// The HCN API is only available on Windows, so pod endpoints can't be resolved elsewhere.
+func GetEndpointMACs(pods []string) ([]string, error) {
+	return nil, fmt.Errorf("unable to look up HNS endpoints for pods %v: HCN API is only available on Windows", pods)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package netutil
+
+import (
+	"fmt"
+
+	"github.com/Microsoft/hcsshim/hcn"
+)
+
+func GetEndpoint(endpoints []hcn.HostComputeEndpoint, ip string) (hcn.HostComputeEndpoint, error) {
+	for _, endpoint := range endpoints {
+		for _, ipconfig := range endpoint.IpConfigurations {
+			if ipconfig.IpAddress == ip {
+				return endpoint, nil
+			}
+		}
+	}
+	return hcn.HostComputeEndpoint{}, fmt.Errorf("endpoint with IP: %s not found", ip)
+}
+
+/* Retrieves the MAC address of the HNS endpoint for each pod IP passed.
+Returns string slice of these MAC addresses.
+*/
+func GetEndpointMACs(pods []string) (ret []string, err error) {
+	var endpoints []hcn.HostComputeEndpoint
+	var endpoint hcn.HostComputeEndpoint
+
+	endpoints, err = hcn.ListEndpoints()
+	if err != nil {
+		return
+	}
+
+	for _, pod := range pods {
+		endpoint, err = GetEndpoint(endpoints, pod)
+		if err != nil {
+			return
+		}
+
+		ret = append(ret, endpoint.MacAddress)
+	}
+
+	return
+}
@@ -0,0 +1,17 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+//go:build !windows
+
+package netutil
+
+import (
+	"fmt"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+// The HCN API is only available on Windows, so hnsdiag must be used elsewhere.
+func ListHCNObjects(hcntype pb.HCNType, verbose bool) (*pb.HCNObjects, []byte, error) {
+	return nil, nil, fmt.Errorf("unable to list HNS %s: HCN API is only available on Windows", hcntype)
+}
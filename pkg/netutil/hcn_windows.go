@@ -0,0 +1,162 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package netutil
+
+import (
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"github.com/Microsoft/hcsshim/hcn"
+)
+
+// The HNS objects retrieved from the HCN API, as HNS itself reports them
+type hcnObjects struct {
+	Networks      []hcn.HostComputeNetwork      `json:",omitempty"`
+	Endpoints     []hcn.HostComputeEndpoint     `json:",omitempty"`
+	LoadBalancers []hcn.HostComputeLoadBalancer `json:",omitempty"`
+	Namespaces    []hcn.HostComputeNamespace    `json:",omitempty"`
+}
+
+// Retrieves the HNS objects of the given type through the HCN API, rather than by running hnsdiag.
+// If verbose, also returns the objects as the JSON HNS reports them in.
+func ListHCNObjects(hcntype pb.HCNType, verbose bool) (*pb.HCNObjects, []byte, error) {
+	var objs hcnObjects
+	var err error
+	all := hcntype == pb.HCNType_all
+
+	if all || hcntype == pb.HCNType_networks {
+		if objs.Networks, err = hcn.ListNetworks(); err != nil {
+			return nil, nil, fmt.Errorf("failed to list HNS networks: %v", err)
+		}
+	}
+
+	if all || hcntype == pb.HCNType_endpoints {
+		if objs.Endpoints, err = hcn.ListEndpoints(); err != nil {
+			return nil, nil, fmt.Errorf("failed to list HNS endpoints: %v", err)
+		}
+	}
+
+	if all || hcntype == pb.HCNType_loadbalancers {
+		if objs.LoadBalancers, err = hcn.ListLoadBalancers(); err != nil {
+			return nil, nil, fmt.Errorf("failed to list HNS load balancers: %v", err)
+		}
+	}
+
+	if all || hcntype == pb.HCNType_namespaces {
+		if objs.Namespaces, err = hcn.ListNamespaces(); err != nil {
+			return nil, nil, fmt.Errorf("failed to list HNS namespaces: %v", err)
+		}
+	}
+
+	ret := &pb.HCNObjects{}
+	for _, network := range objs.Networks {
+		ret.Networks = append(ret.Networks, networkToProto(network))
+	}
+
+	for _, endpoint := range objs.Endpoints {
+		ret.Endpoints = append(ret.Endpoints, endpointToProto(endpoint))
+	}
+
+	for _, lb := range objs.LoadBalancers {
+		ret.LoadBalancers = append(ret.LoadBalancers, loadBalancerToProto(lb))
+	}
+
+	for _, namespace := range objs.Namespaces {
+		ret.Namespaces = append(ret.Namespaces, namespaceToProto(namespace))
+	}
+
+	if !verbose {
+		return ret, nil, nil
+	}
+
+	raw, err := json.MarshalIndent(objs, "", "    ")
+
+	return ret, raw, err
+}
+
+func networkToProto(network hcn.HostComputeNetwork) *pb.HCNNetwork {
+	ret := &pb.HCNNetwork{
+		Id:    network.Id,
+		Name:  network.Name,
+		Type:  string(network.Type),
+		Flags: uint32(network.Flags),
+	}
+
+	for _, ipam := range network.Ipams {
+		for _, subnet := range ipam.Subnets {
+			ret.Subnets = append(ret.Subnets, subnet.IpAddressPrefix)
+		}
+	}
+
+	return ret
+}
+
+func endpointToProto(endpoint hcn.HostComputeEndpoint) *pb.HCNEndpoint {
+	ret := &pb.HCNEndpoint{
+		Id:          endpoint.Id,
+		Name:        endpoint.Name,
+		NetworkId:   endpoint.HostComputeNetwork,
+		NamespaceId: endpoint.HostComputeNamespace,
+		MacAddress:  endpoint.MacAddress,
+		Flags:       uint32(endpoint.Flags),
+	}
+
+	for _, ipconfig := range endpoint.IpConfigurations {
+		ret.IpAddresses = append(ret.IpAddresses, fmt.Sprintf("%s/%d", ipconfig.IpAddress, ipconfig.PrefixLength))
+	}
+
+	for _, policy := range endpoint.Policies {
+		ret.Policies = append(ret.Policies, string(policy.Type))
+	}
+
+	return ret
+}
+
+func loadBalancerToProto(lb hcn.HostComputeLoadBalancer) *pb.HCNLoadBalancer {
+	ret := &pb.HCNLoadBalancer{
+		Id:           lb.Id,
+		EndpointIds:  lb.HostComputeEndpoints,
+		SourceVip:    lb.SourceVIP,
+		FrontendVips: lb.FrontendVIPs,
+		Flags:        uint32(lb.Flags),
+	}
+
+	for _, mapping := range lb.PortMappings {
+		ret.PortMappings = append(ret.PortMappings, &pb.HCNPortMapping{
+			Protocol:     mapping.Protocol,
+			InternalPort: uint32(mapping.InternalPort),
+			ExternalPort: uint32(mapping.ExternalPort),
+			Flags:        uint32(mapping.Flags),
+		})
+	}
+
+	return ret
+}
+
+func namespaceToProto(namespace hcn.HostComputeNamespace) *pb.HCNNamespace {
+	ret := &pb.HCNNamespace{
+		Id:          namespace.Id,
+		NamespaceId: namespace.NamespaceId,
+		Type:        string(namespace.Type),
+	}
+
+	for _, resource := range namespace.Resources {
+		switch resource.Type {
+		case hcn.NamespaceResourceTypeEndpoint:
+			var endpoint hcn.NamespaceResourceEndpoint
+			if err := json.Unmarshal(resource.Data, &endpoint); err == nil {
+				ret.EndpointIds = append(ret.EndpointIds, endpoint.Id)
+			}
+		case hcn.NamespaceResourceTypeContainer:
+			var container hcn.NamespaceResourceContainer
+			if err := json.Unmarshal(resource.Data, &container); err == nil {
+				ret.ContainerIds = append(ret.ContainerIds, container.Id)
+			}
+		}
+	}
+
+	return ret
+}
@@ -7,16 +7,16 @@ import (
 	"bufio"
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"regexp"
 	"strings"
 
-	"github.com/Microsoft/hcsshim/hcn"
+	"github.com/microsoft/wcnspect/pkg/runner"
 )
 
 type HNSDiagObj struct {
 	HNSDiagEndpoint
 	HNSDiagNetwork
+	HNSDiagLoadBalancer
 }
 
 type HNSDiagNetwork struct {
@@ -24,10 +24,28 @@ type HNSDiagNetwork struct {
 }
 
 type HNSDiagEndpoint struct {
-	IPAddress      string           `json:",omitempty"`
-	MacAddress     string           `json:",omitempty"`
-	Resources      HNSDiagResources `json:",omitempty"`
-	VirtualNetwork string           `json:",omitempty"`
+	ID               string           `json:",omitempty"`
+	Name             string           `json:",omitempty"`
+	IPAddress        string           `json:",omitempty"`
+	MacAddress       string           `json:",omitempty"`
+	Resources        HNSDiagResources `json:",omitempty"`
+	VirtualNetwork   string           `json:",omitempty"`
+	IsRemoteEndpoint bool             `json:",omitempty"`
+}
+
+// Load balancers are only listed by hnsdiag in HNS's v1 format, with a policy per port
+type HNSDiagLoadBalancer struct {
+	SourceVIP  string          `json:",omitempty"`
+	References []string        `json:",omitempty"` // "/endpoints/{ID}" of each backend
+	Policies   []HNSDiagPolicy `json:",omitempty"`
+}
+
+type HNSDiagPolicy struct {
+	Type         string   `json:",omitempty"` // ELB for load balancing policies
+	Protocol     uint32   `json:",omitempty"`
+	InternalPort uint32   `json:",omitempty"`
+	ExternalPort uint32   `json:",omitempty"`
+	VIPs         []string `json:",omitempty"`
 }
 
 type HNSDiagResources struct {
@@ -38,29 +56,18 @@ type HNSDiagAllocator struct {
 	EndpointPortGuid string `json:",omitempty"`
 }
 
-func GetEndpoint(endpoints []hcn.HostComputeEndpoint, ip string) (hcn.HostComputeEndpoint, error) {
-	for _, endpoint := range endpoints {
-		for _, ipconfig := range endpoint.IpConfigurations {
-			if ipconfig.IpAddress == ip {
-				return endpoint, nil
-			}
-		}
-	}
-	return hcn.HostComputeEndpoint{}, fmt.Errorf("endpoint with IP: %s not found", ip)
-}
-
-func GetLogs(option string, verbose bool) ([]byte, error) {
+func GetLogs(r runner.Runner, option string, verbose bool) ([]byte, error) {
 	cmd := fmt.Sprintf("hnsdiag list %s", option)
 
 	if verbose {
 		cmd += " -dl"
 	}
 
-	return exec.Command("cmd", "/c", cmd).CombinedOutput()
+	return r.CombinedOutput(cmd)
 }
 
-func GetPktmonID(mac string) (string, error) {
-	out, err := exec.Command("cmd", "/c", "pktmon list").Output()
+func GetPktmonID(r runner.Runner, mac string) (string, error) {
+	out, err := r.Output("pktmon list")
 	if err != nil {
 		return "", fmt.Errorf("failed to run 'pktmon list': %v", err)
 	}
@@ -85,23 +92,17 @@ func GetPktmonID(mac string) (string, error) {
 /* Retrieves pktmon component vNic ID for each pod IP passed.
 Returns string slice of these ids.
 */
-func GetPodIDs(pods []string) (ret []string, err error) {
-	var endpoints []hcn.HostComputeEndpoint
-	var endpoint hcn.HostComputeEndpoint
+func GetPodIDs(r runner.Runner, pods []string) (ret []string, err error) {
+	var macs []string
 	var id string
 
-	endpoints, err = hcn.ListEndpoints()
+	macs, err = GetEndpointMACs(pods)
 	if err != nil {
 		return
 	}
 
-	for _, pod := range pods {
-		endpoint, err = GetEndpoint(endpoints, pod)
-		if err != nil {
-			return
-		}
-
-		id, err = GetPktmonID(endpoint.MacAddress)
+	for _, mac := range macs {
+		id, err = GetPktmonID(r, mac)
 		if err != nil {
 			return
 		}
@@ -112,18 +113,23 @@ func GetPodIDs(pods []string) (ret []string, err error) {
 	return
 }
 
-func ListIPConfig() ([]byte, error) {
-	return exec.Command("cmd", "/c", "ipconfig /all").CombinedOutput()
+func ListIPConfig(r runner.Runner) ([]byte, error) {
+	return r.CombinedOutput("ipconfig /all")
 }
 
-func ParseHNSDiag(hnsType string) ([]HNSDiagObj, error) {
-	var hnsObjs []HNSDiagObj
-
+func ParseHNSDiag(r runner.Runner, hnsType string) ([]HNSDiagObj, error) {
 	// Get logs
-	bytelogs, err := GetLogs(hnsType, true)
+	bytelogs, err := GetLogs(r, hnsType, true)
 	if err != nil {
-		return hnsObjs, err
+		return nil, err
 	}
+
+	return ParseHNSDiagLogs(bytelogs)
+}
+
+// Parses the detailed output of hnsdiag, which lists one JSON object per line
+func ParseHNSDiagLogs(bytelogs []byte) ([]HNSDiagObj, error) {
+	var hnsObjs []HNSDiagObj
 	logs := string(bytelogs)
 
 	// Must modify logs string in order to parse as json
@@ -131,7 +137,7 @@ func ParseHNSDiag(hnsType string) ([]HNSDiagObj, error) {
 	logs = "[" + re.ReplaceAllString(logs, "\n,{") + "]"
 
 	// Unmarshal into struct
-	err = json.Unmarshal([]byte(logs), &hnsObjs)
+	err := json.Unmarshal([]byte(logs), &hnsObjs)
 
 	return hnsObjs, err
 }
@@ -0,0 +1,167 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package pkt
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/microsoft/wcnspect/pkg/runner"
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+// ParseCounters parses the tables printed by 'pktmon counter' and 'pktmon stop' into per-component rows.
+// Components are returned in the order they first appear, with drops attached to the component they were counted on.
+func ParseCounters(out string) []*pb.ComponentCounters {
+	ret := []*pb.ComponentCounters{}
+	byID := map[uint32]*pb.ComponentCounters{}
+
+	component := func(id uint32, name string) *pb.ComponentCounters {
+		if c, ok := byID[id]; ok {
+			return c
+		}
+
+		c := &pb.ComponentCounters{ComponentId: id, Name: name}
+		byID[id] = c
+		ret = append(ret, c)
+
+		return c
+	}
+
+	inDrops := false
+	reasonCol := -1
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "Drops:":
+			inDrops = true
+			continue
+		case strings.HasPrefix(trimmed, "Id "):
+			// Column headers, which locate the drop reason since both it and the name may contain spaces
+			reasonCol = strings.Index(line, "Drop Reason")
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		id, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			// Separators, group names and rows for a component's other edges
+			continue
+		}
+
+		if inDrops {
+			// Id Name Drop Reason Location Packets
+			if len(fields) < 4 || reasonCol < 0 || len(line) <= reasonCol {
+				continue
+			}
+
+			packets, location := parseCount(fields[len(fields)-1]), fields[len(fields)-2]
+			nameStart := strings.Index(line, fields[0]) + len(fields[0])
+			reasonEnd := strings.LastIndex(line, location)
+			if nameStart > reasonCol || reasonCol > reasonEnd {
+				continue
+			}
+
+			c := component(uint32(id), strings.TrimSpace(line[nameStart:reasonCol]))
+			c.Drops = append(c.Drops, &pb.DropCounter{
+				Reason:   strings.TrimSpace(line[reasonCol:reasonEnd]),
+				Location: location,
+				Packets:  packets,
+			})
+
+			continue
+		}
+
+		// Id Name Counter Direction Packets Bytes | Direction Packets Bytes
+		left, right, found := strings.Cut(line, "|")
+		if !found {
+			continue
+		}
+
+		leftFields := strings.Fields(left)
+		if len(leftFields) < 6 {
+			continue
+		}
+
+		n := len(leftFields)
+		c := component(uint32(id), strings.Join(leftFields[1:n-4], " "))
+		setDirectionCounts(c, leftFields[n-3:])
+		setDirectionCounts(c, strings.Fields(right))
+	}
+
+	return ret
+}
+
+// ParseComponentMACs parses the output of 'pktmon list' into a map of component ID to MAC address.
+func ParseComponentMACs(out string) map[uint32]string {
+	ret := map[uint32]string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+
+		id, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			continue
+		}
+
+		ret[uint32(id)] = fields[1]
+	}
+
+	return ret
+}
+
+// PullStructuredCounters parses counter output into rows, joining each component's MAC address from 'pktmon list'.
+func PullStructuredCounters(r runner.Runner, out string) ([]*pb.ComponentCounters, error) {
+	counters := ParseCounters(out)
+	if len(counters) == 0 {
+		return counters, nil
+	}
+
+	list, err := r.Output("pktmon list")
+	if err != nil {
+		return counters, fmt.Errorf("failed to run 'pktmon list': %v", err)
+	}
+
+	macs := ParseComponentMACs(string(list))
+	for _, c := range counters {
+		c.Mac = macs[c.GetComponentId()]
+	}
+
+	return counters, nil
+}
+
+// Sets the packet and byte counts from a "Direction Packets Bytes" triple
+func setDirectionCounts(c *pb.ComponentCounters, fields []string) {
+	if len(fields) != 3 {
+		return
+	}
+
+	packets, bytes := parseCount(fields[1]), parseCount(fields[2])
+	switch fields[0] {
+	case "Rx":
+		c.PacketsIn, c.BytesIn = packets, bytes
+	case "Tx":
+		c.PacketsOut, c.BytesOut = packets, bytes
+	}
+}
+
+// Parses counts printed with thousands separators, such as 1,981,422
+func parseCount(s string) uint64 {
+	n, _ := strconv.ParseUint(strings.ReplaceAll(s, ",", ""), 10, 64)
+	return n
+}
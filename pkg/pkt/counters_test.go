@@ -0,0 +1,95 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package pkt
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseCounters(t *testing.T) {
+	cases := []struct {
+		desc     string
+		out      []string
+		expected []*pb.ComponentCounters
+	}{
+		{
+			"TestCountersAndDrops",
+			[]string{
+				"Current Counters:",
+				"",
+				" Id Name                              Counter  Direction     Packets        Bytes | Direction     Packets        Bytes",
+				" -- ----                              -------  ---------     -------        ----- | ---------     -------        -----",
+				"  9 Ethernet 2                        Upper    Rx              2,417    1,981,422 | Tx              1,903      221,734",
+				"                                      Lower    Rx              2,417    1,981,422 | Tx              1,903      221,734",
+				" 14 Container NIC 3f2a1b0c            Upper    Tx              1,616      183,622 | Rx              2,105    1,939,494",
+				"",
+				"Drops:",
+				" Id Name                              Drop Reason                 Location           Packets",
+				" -- ----                              -----------                 --------           -------",
+				" 14 Container NIC 3f2a1b0c            Filtered VLAN               0xE0004A44               3",
+				" 17 Container NIC 7e6d5c4b            Invalid Packet              0xE000488C               1",
+			},
+			[]*pb.ComponentCounters{
+				{ComponentId: 9, Name: "Ethernet 2", PacketsIn: 2417, BytesIn: 1981422, PacketsOut: 1903, BytesOut: 221734},
+				{
+					ComponentId: 14,
+					Name:        "Container NIC 3f2a1b0c",
+					PacketsIn:   2105,
+					BytesIn:     1939494,
+					PacketsOut:  1616,
+					BytesOut:    183622,
+					Drops:       []*pb.DropCounter{{Reason: "Filtered VLAN", Location: "0xE0004A44", Packets: 3}},
+				},
+				{
+					ComponentId: 17,
+					Name:        "Container NIC 7e6d5c4b",
+					Drops:       []*pb.DropCounter{{Reason: "Invalid Packet", Location: "0xE000488C", Packets: 1}},
+				},
+			},
+		},
+		{
+			"TestNoCounters",
+			[]string{"All counters are zero."},
+			[]*pb.ComponentCounters{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actual := ParseCounters(strings.Join(tc.out, "\r\n"))
+
+			if len(actual) != len(tc.expected) {
+				t.Fatalf("expected %d rows, got %d: %v", len(tc.expected), len(actual), actual)
+			}
+
+			for i := range actual {
+				if !proto.Equal(actual[i], tc.expected[i]) {
+					t.Fatalf("expected: \n%v\n got: \n%v\n", tc.expected[i], actual[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseComponentMACs(t *testing.T) {
+	out := strings.Join([]string{
+		"Network Adapters:",
+		" Id MAC Address       Name",
+		" -- -----------       ----",
+		"  5 00-0D-3A-6B-AE-F1 Microsoft Hyper-V Network Adapter",
+		"",
+		"Hyper-V Switch: azure",
+		" 14 00-15-5D-70-41-2C Container NIC 3f2a1b0c",
+	}, "\r\n")
+
+	macs := ParseComponentMACs(out)
+	if len(macs) != 2 || macs[5] != "00-0D-3A-6B-AE-F1" || macs[14] != "00-15-5D-70-41-2C" {
+		t.Fatalf("unexpected component MACs: %v", macs)
+	}
+}
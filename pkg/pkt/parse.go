@@ -0,0 +1,199 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package pkt
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const pktmonTimeLayout = "2006-01-02 15:04:05.999999999"
+
+var (
+	// [00]0000.0000::2022-06-20 20:35:58.123456700 [Microsoft-Windows-PktMon] PktGroupId 1, PktNumber 1, ...
+	metadataRe = regexp.MustCompile(`^\[[0-9A-Fa-f]+\][0-9A-Fa-f]+\.[0-9A-Fa-f]+::(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d+) \[Microsoft-Windows-PktMon\] (?:Drop: )?(.*)$`)
+	// 00-15-5D-70-41-2C > 00-0D-3A-6B-AE-F1, ethertype IPv4 (0x0800), length 74: ...
+	frameRe = regexp.MustCompile(`^([0-9A-Fa-f]{2}(?:-[0-9A-Fa-f]{2}){5}) > ([0-9A-Fa-f]{2}(?:-[0-9A-Fa-f]{2}){5}), ethertype (\S+) \(0x[0-9A-Fa-f]+\), length (\d+): (.*)$`)
+	// vlan 5, p 0, ethertype IPv4 (0x0800), ...
+	vlanRe = regexp.MustCompile(`^vlan \d+, p \d+, ethertype (\S+) \(0x[0-9A-Fa-f]+\),? (.*)$`)
+	// 10.240.0.35.52636 > 10.240.0.4.443: ...
+	hostsRe    = regexp.MustCompile(`^(\S+) > (\S+?): (.*)$`)
+	tcpFlagsRe = regexp.MustCompile(`Flags \[([^\]]*)\]`)
+)
+
+var tcpFlagNames = map[rune]string{
+	'F': "FIN",
+	'S': "SYN",
+	'R': "RST",
+	'P': "PSH",
+	'.': "ACK",
+	'U': "URG",
+	'E': "ECE",
+	'W': "CWR",
+}
+
+/* PacketParser assembles pktmon real-time output into packet records.
+Pktmon logs a metadata line for every packet, followed by a tab-indented line describing its headers.
+*/
+type PacketParser struct {
+	pending *pb.Packet
+}
+
+func NewPacketParser() *PacketParser {
+	return &PacketParser{}
+}
+
+/* Consumes a single line of pktmon output.
+Returns a packet once its record is complete, otherwise returns nil.
+*/
+func (p *PacketParser) Parse(line string) *pb.Packet {
+	line = strings.TrimRight(line, "\r")
+
+	if packet, ok := parseMetadata(line); ok {
+		// A packet without a header line is complete as soon as the next one starts
+		prev := p.pending
+		p.pending = packet
+		return prev
+	}
+
+	if p.pending == nil || !strings.HasPrefix(line, "\t") {
+		return nil
+	}
+
+	packet := p.pending
+	p.pending = nil
+	parseFrame(strings.TrimSpace(line), packet)
+
+	return packet
+}
+
+/* Flush returns the packet still waiting for a header line, if any, once there's no more output to complete it.
+Pktmon doesn't log a header line for some packets, like many drops, so the last packet of a stream can be left pending.
+*/
+func (p *PacketParser) Flush() *pb.Packet {
+	packet := p.pending
+	p.pending = nil
+
+	return packet
+}
+
+// IsPacketStart returns whether the line of pktmon output is the metadata line starting a packet's record.
+func IsPacketStart(line string) bool {
+	return metadataRe.MatchString(strings.TrimRight(line, "\r"))
+}
+
+func parseMetadata(line string) (*pb.Packet, bool) {
+	match := metadataRe.FindStringSubmatch(line)
+	if match == nil {
+		return nil, false
+	}
+
+	packet := &pb.Packet{}
+	if t, err := time.ParseInLocation(pktmonTimeLayout, match[1], time.Local); err == nil {
+		packet.Timestamp = timestamppb.New(t)
+	}
+
+	for _, field := range strings.Split(match[2], ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(field), " ")
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "Component":
+			packet.ComponentId = parseUint(value)
+		case "Direction":
+			packet.Direction = pb.Direction(pb.Direction_value[strings.ToLower(value)])
+		case "DropReason":
+			packet.DropReason = value
+		case "DropLocation":
+			packet.DropLocation = value
+		case "OriginalSize":
+			packet.Length = parseUint(value)
+		}
+	}
+
+	return packet, true
+}
+
+func parseFrame(line string, packet *pb.Packet) {
+	match := frameRe.FindStringSubmatch(line)
+	if match == nil {
+		return
+	}
+
+	packet.SrcMac, packet.DstMac, packet.Ethertype = match[1], match[2], match[3]
+	packet.Length = parseUint(match[4])
+	payload := match[5]
+
+	// Unwrap any VLAN tags to get to the encapsulated ethertype
+	for vlan := vlanRe.FindStringSubmatch(payload); vlan != nil; vlan = vlanRe.FindStringSubmatch(payload) {
+		packet.Ethertype, payload = vlan[1], vlan[2]
+	}
+
+	if packet.Ethertype != "IPv4" && packet.Ethertype != "IPv6" {
+		packet.Protocol = packet.Ethertype
+		return
+	}
+
+	hosts := hostsRe.FindStringSubmatch(payload)
+	if hosts == nil {
+		return
+	}
+
+	var hasPorts bool
+	packet.SrcIp, packet.SrcPort, hasPorts = splitHostPort(hosts[1])
+	packet.DstIp, packet.DstPort, _ = splitHostPort(hosts[2])
+	rest := hosts[3]
+
+	switch {
+	case strings.HasPrefix(rest, "ICMP6"):
+		packet.Protocol = "ICMPv6"
+	case strings.HasPrefix(rest, "ICMP"):
+		packet.Protocol = "ICMP"
+	case tcpFlagsRe.MatchString(rest):
+		packet.Protocol = "TCP"
+		packet.TcpFlags = parseTCPFlags(tcpFlagsRe.FindStringSubmatch(rest)[1])
+	case hasPorts:
+		packet.Protocol = "UDP"
+	}
+}
+
+// Splits tcpdump's 'address.port' notation, returning whether a port was present
+func splitHostPort(s string) (string, uint32, bool) {
+	if net.ParseIP(s) != nil {
+		return s, 0, false
+	}
+
+	i := strings.LastIndex(s, ".")
+	if i < 0 || net.ParseIP(s[:i]) == nil {
+		return s, 0, false
+	}
+
+	port, err := strconv.ParseUint(s[i+1:], 10, 16)
+	if err != nil {
+		return s[:i], 0, false
+	}
+
+	return s[:i], uint32(port), true
+}
+
+func parseTCPFlags(flags string) (ret []string) {
+	for _, flag := range flags {
+		if name, ok := tcpFlagNames[flag]; ok {
+			ret = append(ret, name)
+		}
+	}
+	return
+}
+
+func parseUint(s string) uint32 {
+	n, _ := strconv.ParseUint(strings.ReplaceAll(s, ",", ""), 10, 32)
+	return uint32(n)
+}
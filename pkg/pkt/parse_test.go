@@ -0,0 +1,175 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package pkt
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestPacketParser(t *testing.T) {
+	timestamp := func(s string) *timestamppb.Timestamp {
+		ts, _ := time.ParseInLocation(pktmonTimeLayout, s, time.Local)
+		return timestamppb.New(ts)
+	}
+
+	cases := []struct {
+		desc     string
+		lines    []string
+		expected *pb.Packet
+	}{
+		{
+			"TestTCP",
+			[]string{
+				"[00]0000.0000::2022-06-20 20:35:58.123601200 [Microsoft-Windows-PktMon] PktGroupId 1125899906842626, PktNumber 1, Appearance 1, Direction Rx , Type Ethernet , Component 14, Edge 1, Filter 1, OriginalSize 74, LoggedSize 74",
+				"\t00-0D-3A-6B-AE-F1 > 00-15-5D-70-41-2C, ethertype IPv4 (0x0800), length 74: 10.240.0.4.443 > 10.240.0.35.52636: Flags [S.], seq 1812447501, ack 3263457789, win 65535, length 0",
+			},
+			&pb.Packet{
+				Timestamp:   timestamp("2022-06-20 20:35:58.123601200"),
+				ComponentId: 14,
+				Direction:   pb.Direction_rx,
+				SrcMac:      "00-0D-3A-6B-AE-F1",
+				DstMac:      "00-15-5D-70-41-2C",
+				Ethertype:   "IPv4",
+				SrcIp:       "10.240.0.4",
+				DstIp:       "10.240.0.35",
+				SrcPort:     443,
+				DstPort:     52636,
+				Protocol:    "TCP",
+				TcpFlags:    []string{"SYN", "ACK"},
+				Length:      74,
+			},
+		},
+		{
+			"TestUDP",
+			[]string{
+				"[01]0000.0000::2022-06-20 20:35:58.124010900 [Microsoft-Windows-PktMon] PktGroupId 1125899906842627, PktNumber 1, Appearance 1, Direction Tx , Type Ethernet , Component 14, Edge 1, Filter 2, OriginalSize 84, LoggedSize 84",
+				"\t00-15-5D-70-41-2C > 00-0D-3A-6B-AE-F1, ethertype IPv4 (0x0800), length 84: 10.240.0.35.61207 > 10.0.0.10.53: 4821+ A? kubernetes.default.svc.cluster.local. (42)",
+			},
+			&pb.Packet{
+				Timestamp:   timestamp("2022-06-20 20:35:58.124010900"),
+				ComponentId: 14,
+				Direction:   pb.Direction_tx,
+				SrcMac:      "00-15-5D-70-41-2C",
+				DstMac:      "00-0D-3A-6B-AE-F1",
+				Ethertype:   "IPv4",
+				SrcIp:       "10.240.0.35",
+				DstIp:       "10.0.0.10",
+				SrcPort:     61207,
+				DstPort:     53,
+				Protocol:    "UDP",
+				Length:      84,
+			},
+		},
+		{
+			"TestICMPv6",
+			[]string{
+				"[00]0000.0000::2022-06-20 20:36:01.000000100 [Microsoft-Windows-PktMon] PktGroupId 1125899906842700, PktNumber 1, Appearance 1, Direction Tx , Type Ethernet , Component 9, Edge 1, Filter 0, OriginalSize 86, LoggedSize 86",
+				"\t00-0D-3A-6B-AE-F1 > 33-33-00-00-00-01, ethertype IPv6 (0x86dd), length 86: fe80::9d2c:4a1b:6e3f:2a7d > ff02::1: ICMP6, neighbor advertisement, tgt is fe80::9d2c:4a1b:6e3f:2a7d, length 32",
+			},
+			&pb.Packet{
+				Timestamp:   timestamp("2022-06-20 20:36:01.000000100"),
+				ComponentId: 9,
+				Direction:   pb.Direction_tx,
+				SrcMac:      "00-0D-3A-6B-AE-F1",
+				DstMac:      "33-33-00-00-00-01",
+				Ethertype:   "IPv6",
+				SrcIp:       "fe80::9d2c:4a1b:6e3f:2a7d",
+				DstIp:       "ff02::1",
+				Protocol:    "ICMPv6",
+				Length:      86,
+			},
+		},
+		{
+			"TestVLANTaggedARP",
+			[]string{
+				"[00]0000.0000::2022-06-20 20:36:02.500000000 [Microsoft-Windows-PktMon] PktGroupId 1125899906842701, PktNumber 1, Appearance 1, Direction Rx , Type Ethernet , Component 5, Edge 1, Filter 0, OriginalSize 46, LoggedSize 46",
+				"\t00-0D-3A-11-22-33 > FF-FF-FF-FF-FF-FF, ethertype 802.1Q (0x8100), length 46: vlan 5, p 0, ethertype ARP (0x0806), Request who-has 10.240.0.1 tell 10.240.0.4, length 28",
+			},
+			&pb.Packet{
+				Timestamp:   timestamp("2022-06-20 20:36:02.500000000"),
+				ComponentId: 5,
+				Direction:   pb.Direction_rx,
+				SrcMac:      "00-0D-3A-11-22-33",
+				DstMac:      "FF-FF-FF-FF-FF-FF",
+				Ethertype:   "ARP",
+				Protocol:    "ARP",
+				Length:      46,
+			},
+		},
+		{
+			"TestDrop",
+			[]string{
+				"[01]0000.0000::2022-06-20 20:35:58.125877300 [Microsoft-Windows-PktMon] Drop: PktGroupId 1125899906842628, PktNumber 1, Appearance 2, Direction Rx , Type Ethernet , Component 14, Edge 1, Filter 1, DropReason Filtered VLAN , DropLocation 0xE0004A44 , OriginalSize 60, LoggedSize 60",
+				"\t00-0D-3A-6B-AE-F1 > 00-15-5D-70-41-2C, ethertype IPv4 (0x0800), length 60: 10.240.0.4.443 > 10.240.0.35.52636: Flags [R], seq 1812447502, win 0, length 0",
+			},
+			&pb.Packet{
+				Timestamp:    timestamp("2022-06-20 20:35:58.125877300"),
+				ComponentId:  14,
+				Direction:    pb.Direction_rx,
+				SrcMac:       "00-0D-3A-6B-AE-F1",
+				DstMac:       "00-15-5D-70-41-2C",
+				Ethertype:    "IPv4",
+				SrcIp:        "10.240.0.4",
+				DstIp:        "10.240.0.35",
+				SrcPort:      443,
+				DstPort:      52636,
+				Protocol:     "TCP",
+				TcpFlags:     []string{"RST"},
+				Length:       60,
+				DropReason:   "Filtered VLAN",
+				DropLocation: "0xE0004A44",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			parser := NewPacketParser()
+
+			if actual := parser.Parse(tc.lines[0]); actual != nil {
+				t.Fatalf("expected no packet from metadata line alone, got: %v", actual)
+			}
+
+			actual := parser.Parse(tc.lines[1])
+			if !proto.Equal(actual, tc.expected) {
+				t.Fatalf("expected: %v got: %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPacketParserIgnoresNonPacketLines(t *testing.T) {
+	parser := NewPacketParser()
+
+	for _, line := range []string{"Logger Parameters:", "    Logger name:        PktMon", "", "Processing...", "\tstray header line"} {
+		if actual := parser.Parse(line); actual != nil {
+			t.Fatalf("expected no packet for line '%s', got: %v", line, actual)
+		}
+	}
+}
+
+func TestPacketParserFlush(t *testing.T) {
+	parser := NewPacketParser()
+
+	// A drop logged without a header line is only complete once the stream ends
+	drop := "[01]0000.0000::2022-06-20 20:35:58.125877300 [Microsoft-Windows-PktMon] Drop: PktGroupId 1125899906842628, PktNumber 1, Appearance 0, Direction Rx , Type Ethernet , Component 14, Filter 0, DropReason Filtered VLAN , DropLocation 0xE0004A44 , OriginalSize 60, LoggedSize 60"
+	if actual := parser.Parse(drop); actual != nil {
+		t.Fatalf("expected no packet from metadata line alone, got: %v", actual)
+	}
+
+	actual := parser.Flush()
+	if actual == nil || actual.GetDropReason() != "Filtered VLAN" || actual.GetComponentId() != 14 {
+		t.Fatalf("expected the pending drop to be flushed, got: %v", actual)
+	}
+
+	if actual := parser.Flush(); actual != nil {
+		t.Fatalf("expected nothing left to flush, got: %v", actual)
+	}
+}
@@ -8,29 +8,80 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/microsoft/wcnspect/pkg/comprise"
 	"github.com/microsoft/wcnspect/pkg/netutil"
+	"github.com/microsoft/wcnspect/pkg/runner"
 	pb "github.com/microsoft/wcnspect/rpc"
 )
 
+// Prefix of the names of the pktmon filters wcnspect adds, which tells them apart from filters added by others
+const FilterPrefix = "wcnspect"
+
 var pktParams = map[string]string{
-	"protocols": "-t",
-	"ips":       "-i",
-	"ports":     "-p",
-	"macs":      "-m",
+	"protocols":  "-t",
+	"ips":        "-i",
+	"ports":      "-p",
+	"macs":       "-m",
+	"ethertypes": "-d",
+	"vlans":      "-v",
+	"heartbeat":  "-b",
+	"encap":      "-e",
 }
 
-func AddFilters(filters *pb.Filters) error {
-	protocols := filters.GetProtocols()
-	args := map[string][]string{
-		"ips":   filters.GetIps(),
-		"ports": filters.GetPorts(),
-		"macs":  filters.GetMacs(),
+// MaxFilters is the most pktmon filters a capture may add. Every filter is checked against every packet, and filters
+// are added one command at a time.
+const MaxFilters = 64
+
+/* Adds a pktmon filter for every combination of the filters' values, since pktmon only matches one value per parameter
+(or traffic between two values), then does the same for each of the filters' sets. Packets matching any of the pktmon
+filters are captured.
+*/
+func AddFilters(r runner.Runner, filters *pb.Filters) error {
+	if n := CountFilters(filters); n > MaxFilters {
+		return fmt.Errorf("the filters make up %d pktmon filters, more than the %d a capture may add", n, MaxFilters)
+	}
+
+	// Filters are numbered per protocol across the sets, so their names are unique
+	counts := map[string]int{}
+	for _, set := range append([]*pb.Filters{filters}, filters.GetSets()...) {
+		if err := addFilterSet(r, set, counts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CountFilters returns how many pktmon filters AddFilters adds for filters, one per combination of their values.
+func CountFilters(filters *pb.Filters) int {
+	total := 0
+	for _, set := range append([]*pb.Filters{filters}, filters.GetSets()...) {
+		args := filterArgs(set)
+		if len(args) == 0 && len(set.GetProtocols()) == 0 && !set.GetHeartbeat() {
+			continue
+		}
+
+		n := len(set.GetProtocols())
+		if n == 0 {
+			n = 1
+		}
+
+		for _, values := range args {
+			n *= len(values)
+		}
+		total += n
 	}
 
+	return total
+}
+
+func addFilterSet(r runner.Runner, filters *pb.Filters, counts map[string]int) error {
+	protocols := filters.GetProtocols()
+	args := filterArgs(filters)
+
 	// If empty, add an empty protocol since our filtering mechanism depends on there being one
 	if len(protocols) == 0 {
 		protocols = append(protocols, "")
@@ -41,45 +92,103 @@ func AddFilters(filters *pb.Filters) error {
 	pktProtocols := comprise.Map(protocols, formatTCPFlags)
 
 	for i, protocol := range protocols {
-		name := "wcnspect" + strings.ToUpper(protocol)
-		filterBuilder := []string{}
-
-		// Build filter slice
-		for arg, addrs := range args {
-			if len(addrs) > 0 {
-				newFilterFlag := pktParams[arg] + " " + strings.Join(addrs, " ")
-				filterBuilder = append(filterBuilder, newFilterFlag)
+		for _, filterBuilder := range product(args) {
+			if len(protocol) > 0 {
+				filterBuilder = append(filterBuilder, pktParams["protocols"]+" "+pktProtocols[i])
+			}
+
+			if filters.GetHeartbeat() {
+				filterBuilder = append(filterBuilder, pktParams["heartbeat"])
+			}
+
+			// If no filters, continue
+			if len(filterBuilder) == 0 {
+				continue
+			}
+
+			// Encapsulation only changes where the other parameters are matched, so it's no filter on its own
+			if filters.GetEncap() {
+				encap := pktParams["encap"]
+				if port := filters.GetVxlanPort(); port != 0 {
+					encap += fmt.Sprintf(" %d", port)
+				}
+				filterBuilder = append(filterBuilder, encap)
+			}
+
+			// The counter is separated from the protocol, which can be a number itself
+			key := strings.ToUpper(protocol)
+			counts[key]++
+			name := fmt.Sprintf("%s-%d", FilterPrefix, counts[key])
+			if key != "" {
+				name = fmt.Sprintf("%s-%s-%d", FilterPrefix, key, counts[key])
+			}
+			filter := "pktmon filter add" + " " + name + " " + strings.Join(filterBuilder, " ")
+			if err := r.Run(filter); err != nil {
+				return fmt.Errorf("failed to add %s filter: %v", name, err)
 			}
 		}
+	}
 
-		if len(protocol) > 0 {
-			filterBuilder = append(filterBuilder, pktParams["protocols"]+" "+pktProtocols[i])
+	return nil
+}
+
+// Returns the pktmon arguments for each parameter the filters have values for, one per value
+func filterArgs(filters *pb.Filters) [][]string {
+	vlans := []string{}
+	for _, vlan := range filters.GetVlans() {
+		vlans = append(vlans, fmt.Sprint(vlan))
+	}
+
+	// A pair is one more value of its parameter, matching traffic between both sides only
+	args := [][]string{}
+	for _, arg := range []struct {
+		param  string
+		values []string
+		pairs  []*pb.FilterPair
+	}{
+		{pktParams["ips"], filters.GetIps(), filters.GetIpPairs()},
+		{pktParams["ports"], filters.GetPorts(), filters.GetPortPairs()},
+		{pktParams["macs"], filters.GetMacs(), filters.GetMacPairs()},
+		{pktParams["ethertypes"], filters.GetEthertypes(), nil},
+		{pktParams["vlans"], vlans, nil},
+	} {
+		values := comprise.Map(arg.values, func(v string) string { return arg.param + " " + v })
+		for _, pair := range arg.pairs {
+			values = append(values, arg.param+" "+pair.GetFirst()+" "+pair.GetSecond())
 		}
 
-		// If no filters, continue
-		if len(filterBuilder) == 0 {
-			continue
+		if len(values) > 0 {
+			args = append(args, values)
 		}
+	}
 
-		//if verbose {
-		fmt.Println("Applying filters...")
-		//}
+	return args
+}
 
-		filter := "pktmon filter add" + " " + name + " " + strings.Join(filterBuilder, " ")
-		if err := exec.Command("cmd", "/c", filter).Run(); err != nil {
-			return fmt.Errorf("failed to add %s filter: %v", name, err)
+// Returns every combination made of one value from each list
+func product(lists [][]string) [][]string {
+	ret := [][]string{{}}
+	for _, list := range lists {
+		next := [][]string{}
+		for _, combination := range ret {
+			for _, value := range list {
+				next = append(next, append(append([]string{}, combination...), value))
+			}
 		}
+		ret = next
 	}
 
-	return nil
+	return ret
 }
 
+// Returns a channel receiving each line of stdout, which is closed once stdout has been read to the end
 func CreateStreamChannel(stdout *io.ReadCloser) <-chan string {
 	c := make(chan string)
 
 	scanner := bufio.NewScanner(*stdout)
 	scanner.Split(bufio.ScanLines)
 	go func(s *bufio.Scanner) {
+		defer close(c)
 		for s.Scan() {
 			c <- s.Text()
 		}
@@ -88,8 +197,27 @@ func CreateStreamChannel(stdout *io.ReadCloser) <-chan string {
 	return c
 }
 
-func ModifyCaptureCmd(mods *pb.Modifiers) (string, error) {
-	baseCmd := "pktmon start -c -m real-time"
+func ModifyCaptureCmd(r runner.Runner, mods *pb.Modifiers) (string, error) {
+	return buildCaptureCmd(r, "pktmon start -c -m real-time", mods)
+}
+
+/* Builds a pktmon command which logs full packets to the ETL file at etlPath instead of streaming them.
+Counters-only captures aren't supported, since there would be no packets to log.
+*/
+func FileCaptureCmd(r runner.Runner, mods *pb.Modifiers, etlPath string) (string, error) {
+	if mods.GetCountersOnly() {
+		return "", fmt.Errorf("cannot log packets to a file for a counters-only capture")
+	}
+
+	cmd, err := buildCaptureCmd(r, "pktmon start -c --pkt-size 0", mods)
+	if err != nil {
+		return "", err
+	}
+
+	return cmd + fmt.Sprintf(" -f %s", etlPath), nil
+}
+
+func buildCaptureCmd(r runner.Runner, baseCmd string, mods *pb.Modifiers) (string, error) {
 	pods, pktType, countersOnly := mods.GetPods(), mods.GetPacketType(), mods.GetCountersOnly()
 
 	// Add packet type (all, flow, drop)
@@ -97,7 +225,7 @@ func ModifyCaptureCmd(mods *pb.Modifiers) (string, error) {
 
 	// If we have pod IPs, then change the pktmonStartCommand
 	if len(pods) > 0 {
-		podIDs, err := netutil.GetPodIDs(pods)
+		podIDs, err := netutil.GetPodIDs(r, pods)
 
 		if err != nil {
 			return "", err
@@ -114,55 +242,105 @@ func ModifyCaptureCmd(mods *pb.Modifiers) (string, error) {
 	return baseCmd, nil
 }
 
-func PullCounters() (string, error) {
-	cmd := exec.Command("cmd", "/c", "pktmon stop")
-	out, err := cmd.CombinedOutput()
+func ConvertToPcap(r runner.Runner, etlPath string, pcapPath string) error {
+	if err := r.Run(fmt.Sprintf("pktmon etl2pcap %s --out %s", etlPath, pcapPath)); err != nil {
+		return fmt.Errorf("failed to convert %s to pcapng: %v", etlPath, err)
+	}
+
+	return nil
+}
+
+func PullCounters(r runner.Runner) (string, error) {
+	out, err := r.CombinedOutput("pktmon stop")
 	return string(out), err
 }
 
-func PullStreamCounters(includeHidden bool) (string, error) {
+func PullStreamCounters(r runner.Runner, includeHidden bool) (string, error) {
 	pktmonCmd := "pktmon counter"
 
 	if includeHidden {
 		pktmonCmd += " --include-hidden"
 	}
 
-	cmd := exec.Command("cmd", "/c", pktmonCmd)
-	out, err := cmd.Output()
+	out, err := r.Output(pktmonCmd)
 
 	return string(out), err
 }
 
-func ResetFilters() error {
-	if err := exec.Command("cmd", "/c", "pktmon filter remove").Run(); err != nil {
+// Returns the names of the pktmon filters wcnspect added
+func ListFilters(r runner.Runner) ([]string, error) {
+	out, err := r.Output("pktmon filter list")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list filters: %v", err)
+	}
+
+	ret := []string{}
+	for _, name := range ParseFilterNames(string(out)) {
+		if strings.HasPrefix(name, FilterPrefix) {
+			ret = append(ret, name)
+		}
+	}
+
+	return ret, nil
+}
+
+// ParseFilterNames parses the table printed by 'pktmon filter list' into the names of the filters.
+func ParseFilterNames(out string) []string {
+	ret := []string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		// # Name EtherType Protocol IP Address Port
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		if _, err := strconv.ParseUint(fields[0], 10, 32); err == nil {
+			ret = append(ret, fields[1])
+		}
+	}
+
+	return ret
+}
+
+func ResetFilters(r runner.Runner) error {
+	if err := r.Run("pktmon filter remove"); err != nil {
 		return fmt.Errorf("failed to remove old filters: %v", err)
 	}
 
 	return nil
 }
 
-func ResetCaptureProgram() error {
-	if err := exec.Command("cmd", "/c", "pktmon stop").Run(); err != nil {
+func ResetCaptureProgram(r runner.Runner) error {
+	if err := r.Run("pktmon stop"); err != nil {
 		return fmt.Errorf("failed to stop pktmon: %v", err)
 	}
 
 	return nil
 }
 
-func StartStream(ctx context.Context, strCmd string) (*exec.Cmd, *io.ReadCloser, error) {
-	if err := ResetCaptureProgram(); err != nil {
-		return nil, nil, err
+func StartFileCapture(r runner.Runner, strCmd string) error {
+	if err := ResetCaptureProgram(r); err != nil {
+		return err
 	}
 
-	cmd := exec.CommandContext(ctx, "cmd", "/c", strCmd)
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
+	if err := r.Run(strCmd); err != nil {
+		return fmt.Errorf("failed to start pktmon: %v", err)
+	}
+
+	return nil
+}
+
+func StartStream(ctx context.Context, r runner.Runner, strCmd string) (runner.Process, *io.ReadCloser, error) {
+	if err := ResetCaptureProgram(r); err != nil {
 		return nil, nil, err
 	}
 
-	if err := cmd.Start(); err != nil {
+	proc, stdout, err := r.Start(ctx, strCmd)
+	if err != nil {
 		return nil, nil, err
 	}
 
-	return cmd, &stdout, nil
+	return proc, &stdout, nil
 }
@@ -4,58 +4,243 @@
 package pkt
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/microsoft/wcnspect/pkg/runner"
 	pb "github.com/microsoft/wcnspect/rpc"
 )
 
 func TestAddFilters(t *testing.T) {
 	cases := []struct {
-		desc    string
-		filters *pb.Filters
+		desc     string
+		filters  *pb.Filters
+		expected int // number of pktmon filters added
 	}{
-		{"TestNoFilters", &pb.Filters{}},
+		{"TestNoFilters", &pb.Filters{}, 0},
 		{"TestNoProtocol", &pb.Filters{
 			Ips:   []string{"126.167.32.175"},
 			Ports: []string{"43"},
-		}},
+		}, 1},
 		{"TestOnlyProtocol", &pb.Filters{
 			Protocols: []string{"UDP"},
-		}},
+		}, 1},
 		{"TestMultipleProtocols", &pb.Filters{
 			Protocols: []string{"TCP", "ICMP"},
-		}},
+		}, 2},
 		{"TestTCPFlagProtocols", &pb.Filters{
 			Protocols: []string{"TCP_SYN", "TCP_ACK", "TCP_ECE"},
-		}},
+		}, 3},
 		{"TestAllFiltersOneArgs", &pb.Filters{
 			Ips:       []string{"251.151.118.164"},
 			Protocols: []string{"UDP"},
 			Ports:     []string{"6788"},
 			Macs:      []string{"BA-3E-32-37-7F-1A"},
-		}},
+		}, 1},
 		{"TestAllFiltersMaxArgs", &pb.Filters{
 			Ips:       []string{"251.151.118.164", "16.74.81.164"},
 			Protocols: []string{"UDP", "ICMP", "TCP_ACK"},
 			Ports:     []string{"6788", "10022"},
 			Macs:      []string{"BA-3E-32-37-7F-1A", "F1-0E-44-23-E8-72"},
-		}},
+		}, 24},
+		{"TestManyIPs", &pb.Filters{
+			Ips:   []string{"10.0.0.10", "10.240.0.4", "10.240.0.5", "10.240.0.6"},
+			Ports: []string{"80", "8080"},
+		}, 8},
+		{"TestPairs", &pb.Filters{
+			Ips:     []string{"10.240.0.4"},
+			IpPairs: []*pb.FilterPair{{First: "10.244.1.5", Second: "10.244.2.7"}},
+		}, 2},
+		{"TestEthertypesAndVLANs", &pb.Filters{
+			Ethertypes: []string{"IPv4", "ARP"},
+			Vlans:      []uint32{100, 200},
+		}, 4},
+		{"TestOnlyHeartbeat", &pb.Filters{Heartbeat: true}, 1},
+		{"TestOnlyEncap", &pb.Filters{Encap: true, VxlanPort: 4789}, 0},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			if err := AddFilters(tc.filters); err != nil {
+			fake := runner.NewFake("testdata")
+			if err := AddFilters(fake, tc.filters); err != nil {
 				t.Log("error should be nil -", err)
 				t.Fail()
 			}
 
-			if err := ResetFilters(); err != nil {
+			if actual := len(fake.Calls()); actual != tc.expected {
+				t.Fatalf("expected %d filters to be added, got %d: %v", tc.expected, actual, fake.Calls())
+			}
+
+			if err := ResetFilters(fake); err != nil {
 				t.Log("error resetting filters -", err)
 			}
 		})
 	}
 }
 
+func TestAddFiltersCombinations(t *testing.T) {
+	fake := runner.NewFake("testdata")
+	filters := &pb.Filters{
+		Ips:       []string{"10.0.0.10", "10.240.0.4"},
+		Protocols: []string{"TCP_SYN"},
+		Ports:     []string{"80"},
+	}
+	if err := AddFilters(fake, filters); err != nil {
+		t.Fatalf("AddFilters failed: %v", err)
+	}
+
+	expected := []string{
+		"pktmon filter add wcnspect-TCP_SYN-1 -i 10.0.0.10 -p 80 -t TCP SYN",
+		"pktmon filter add wcnspect-TCP_SYN-2 -i 10.240.0.4 -p 80 -t TCP SYN",
+	}
+	if actual := fake.Calls(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, actual)
+	}
+}
+
+func TestAddFiltersEncap(t *testing.T) {
+	fake := runner.NewFake("testdata")
+	filters := &pb.Filters{
+		IpPairs:    []*pb.FilterPair{{First: "10.244.1.5", Second: "10.244.2.7"}},
+		Protocols:  []string{"6"},
+		Ethertypes: []string{"0x0800"},
+		Vlans:      []uint32{4},
+		Encap:      true,
+		VxlanPort:  4790,
+	}
+	if err := AddFilters(fake, filters); err != nil {
+		t.Fatalf("AddFilters failed: %v", err)
+	}
+
+	expected := []string{
+		"pktmon filter add wcnspect-6-1 -i 10.244.1.5 10.244.2.7 -d 0x0800 -v 4 -t 6 -e 4790",
+	}
+	if actual := fake.Calls(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, actual)
+	}
+}
+
+func TestAddFiltersSets(t *testing.T) {
+	fake := runner.NewFake("testdata")
+	filters := &pb.Filters{
+		Sets: []*pb.Filters{
+			{Ips: []string{"10.0.0.5"}, Protocols: []string{"TCP"}, Ports: []string{"443"}},
+			{Protocols: []string{"UDP"}, Ports: []string{"53"}},
+			{Protocols: []string{"tcp"}, Ports: []string{"80"}},
+		},
+	}
+	if err := AddFilters(fake, filters); err != nil {
+		t.Fatalf("AddFilters failed: %v", err)
+	}
+
+	expected := []string{
+		"pktmon filter add wcnspect-TCP-1 -i 10.0.0.5 -p 443 -t TCP",
+		"pktmon filter add wcnspect-UDP-1 -p 53 -t UDP",
+		"pktmon filter add wcnspect-TCP-2 -p 80 -t tcp",
+	}
+	if actual := fake.Calls(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, actual)
+	}
+}
+
+func TestAddFiltersUniqueNames(t *testing.T) {
+	ports := []string{}
+	for i := 1; i <= 11; i++ {
+		ports = append(ports, fmt.Sprint(8000+i))
+	}
+
+	// Protocol 6's eleventh filter and protocol 61's first would both be named wcnspect611 without separators
+	fake := runner.NewFake("testdata")
+	filters := &pb.Filters{
+		Sets: []*pb.Filters{
+			{Protocols: []string{"61"}},
+			{Protocols: []string{"6"}, Ports: ports},
+			{Ports: []string{"53"}},
+		},
+	}
+	if err := AddFilters(fake, filters); err != nil {
+		t.Fatalf("AddFilters failed: %v", err)
+	}
+
+	names := map[string]bool{}
+	for _, call := range fake.Calls() {
+		name := strings.Fields(call)[3]
+		if names[name] {
+			t.Fatalf("filter name %s was used twice in: %v", name, fake.Calls())
+		}
+		names[name] = true
+	}
+
+	for _, expected := range []string{"wcnspect-61-1", "wcnspect-6-11", "wcnspect-1"} {
+		if !names[expected] {
+			t.Fatalf("expected a filter named %s, got: %v", expected, fake.Calls())
+		}
+	}
+}
+
+func TestCountFilters(t *testing.T) {
+	cases := []struct {
+		desc     string
+		filters  *pb.Filters
+		expected int
+	}{
+		{"TestNoFilters", &pb.Filters{}, 0},
+		{"TestHeartbeat", &pb.Filters{Heartbeat: true}, 1},
+		{
+			"TestCombinations",
+			&pb.Filters{
+				Ips:       []string{"10.0.0.10", "10.240.0.4"},
+				IpPairs:   []*pb.FilterPair{{First: "10.244.1.5", Second: "10.244.2.7"}},
+				Ports:     []string{"80", "443"},
+				Protocols: []string{"TCP", "UDP"},
+			},
+			12,
+		},
+		{
+			"TestSets",
+			&pb.Filters{Sets: []*pb.Filters{{Ips: []string{"10.0.0.5", "10.0.0.6"}}, {Protocols: []string{"UDP"}, Ports: []string{"53"}}}},
+			3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if actual := CountFilters(tc.filters); actual != tc.expected {
+				t.Fatalf("expected: %d, got: %d", tc.expected, actual)
+			}
+
+			// Counts match what's added
+			fake := runner.NewFake("testdata")
+			if err := AddFilters(fake, tc.filters); err != nil {
+				t.Fatalf("AddFilters failed: %v", err)
+			}
+
+			if actual := len(fake.Calls()); actual != tc.expected {
+				t.Fatalf("expected %d filters to be added, got: %d", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAddFiltersTooMany(t *testing.T) {
+	fake := runner.NewFake("testdata")
+	filters := &pb.Filters{Protocols: []string{"TCP", "UDP"}}
+	for i := 0; i < MaxFilters/2+1; i++ {
+		filters.Ports = append(filters.Ports, fmt.Sprint(8000+i))
+	}
+
+	if err := AddFilters(fake, filters); err == nil {
+		t.Fatal("expected an error for too many filters")
+	}
+
+	// Nothing is added when the filters are refused
+	if actual := fake.Calls(); len(actual) != 0 {
+		t.Fatalf("expected no filters to be added, got: %v", actual)
+	}
+}
+
 func TestModifyCaptureCmd(t *testing.T) {
 	cases := []struct {
 		desc     string
@@ -83,7 +268,7 @@ func TestModifyCaptureCmd(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			actual, err := ModifyCaptureCmd(tc.mods)
+			actual, err := ModifyCaptureCmd(runner.NewFake("testdata"), tc.mods)
 
 			if err != nil {
 				t.Log("error should be nil -", err)
@@ -96,3 +281,63 @@ func TestModifyCaptureCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestFileCaptureCmd(t *testing.T) {
+	cases := []struct {
+		desc     string
+		mods     *pb.Modifiers
+		expected string
+		fails    bool
+	}{
+		{"TestNoModifiers", &pb.Modifiers{}, "pktmon start -c --pkt-size 0 --type all -f C:\\capture.etl", false},
+		{
+			"TestOnlyDropPackets",
+			&pb.Modifiers{
+				PacketType: pb.PacketType(pb.PacketType_value["drop"]),
+			},
+			"pktmon start -c --pkt-size 0 --type drop -f C:\\capture.etl",
+			false,
+		},
+		{"TestCountersOnly", &pb.Modifiers{CountersOnly: true}, "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actual, err := FileCaptureCmd(runner.NewFake("testdata"), tc.mods, "C:\\capture.etl")
+
+			if (err != nil) != tc.fails {
+				t.Fatalf("expected failure: %t got error: %v", tc.fails, err)
+			}
+
+			if actual != tc.expected {
+				t.Fatalf("expected: '%s' got: '%s' for mods: %v", tc.expected, actual, tc.mods)
+			}
+		})
+	}
+}
+
+func TestParseFilterNames(t *testing.T) {
+	cases := []struct {
+		desc     string
+		out      string
+		expected []string
+	}{
+		{
+			"TestFilters",
+			" # Name          EtherType Protocol  IP Address      Port\r\n" +
+				" - ----          --------- --------  ----------      ----\r\n" +
+				" 1 wcnspect-TCP-1          TCP       10.240.0.35       80\r\n" +
+				" 2 diag          IPv4                10.240.0.4\r\n",
+			[]string{"wcnspect-TCP-1", "diag"},
+		},
+		{"TestNoFilters", "There are no packet filters.\r\n", []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if actual := ParseFilterNames(tc.out); !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected: %v, got: %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var fixtureNameRe = regexp.MustCompile(`[^a-z0-9.\-]+`)
+
+/* Returns the file name that the output of cmd is recorded under in a fixture directory.
+For example, 'hnsdiag list endpoints -dl' is stored as 'hnsdiag_list_endpoints_-dl.txt'.
+*/
+func FixtureName(cmd string) string {
+	name := fixtureNameRe.ReplaceAllString(strings.ToLower(cmd), "_")
+	return strings.Trim(name, "_") + ".txt"
+}
+
+// Fake replays tool output recorded in Dir, so that server logic can be exercised away from a Windows node.
+type Fake struct {
+	Dir    string
+	Errors map[string]error // Commands that should fail, along with the error they fail with
+	Exits  map[string]error // Commands started with Start that exit once their output is streamed, along with their exit error
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func NewFake(dir string) *Fake {
+	return &Fake{Dir: dir, Errors: map[string]error{}, Exits: map[string]error{}}
+}
+
+// Calls returns every command the fake was asked to run, in order.
+func (f *Fake) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string{}, f.calls...)
+}
+
+// Run only fails if cmd is registered in Errors, since its output is discarded anyway.
+func (f *Fake) Run(cmd string) error {
+	f.call(cmd)
+	return f.Errors[cmd]
+}
+
+func (f *Fake) Output(cmd string) ([]byte, error) {
+	f.call(cmd)
+	return f.fixture(cmd)
+}
+
+func (f *Fake) CombinedOutput(cmd string) ([]byte, error) {
+	f.call(cmd)
+	return f.fixture(cmd)
+}
+
+// Start streams the recorded output of cmd, then holds the stream open until ctx is done or the process is killed,
+// unless cmd is registered in Exits.
+func (f *Fake) Start(ctx context.Context, cmd string) (Process, io.ReadCloser, error) {
+	f.call(cmd)
+	out, err := f.fixture(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := &fakeProcess{killed: make(chan struct{}), exited: make(chan struct{})}
+	exitErr, exits := f.Exits[cmd]
+	pr, pw := io.Pipe()
+	go func() {
+		defer close(p.exited)
+		pw.Write(out)
+
+		if exits {
+			p.err = exitErr
+			pw.Close()
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-p.killed:
+		}
+
+		pw.Close()
+	}()
+
+	return p, pr, nil
+}
+
+func (f *Fake) call(cmd string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, cmd)
+}
+
+func (f *Fake) fixture(cmd string) ([]byte, error) {
+	if err, ok := f.Errors[cmd]; ok {
+		return nil, err
+	}
+
+	out, err := os.ReadFile(filepath.Join(f.Dir, FixtureName(cmd)))
+	if err != nil {
+		return nil, fmt.Errorf("no fixture recorded for '%s': %v", cmd, err)
+	}
+
+	return out, nil
+}
+
+type fakeProcess struct {
+	once   sync.Once
+	killed chan struct{}
+	exited chan struct{}
+	err    error
+}
+
+func (p *fakeProcess) Kill() error {
+	p.once.Do(func() { close(p.killed) })
+	return nil
+}
+
+func (p *fakeProcess) Wait() error {
+	<-p.exited
+	return p.err
+}
+
+// Recorder wraps a Runner and saves the output of every command it runs into Dir, producing fixtures for Fake.
+type Recorder struct {
+	Runner Runner
+	Dir    string
+}
+
+func (r *Recorder) Run(cmd string) error {
+	return r.Runner.Run(cmd)
+}
+
+func (r *Recorder) Output(cmd string) ([]byte, error) {
+	out, err := r.Runner.Output(cmd)
+	return out, r.save(cmd, out, err)
+}
+
+func (r *Recorder) CombinedOutput(cmd string) ([]byte, error) {
+	out, err := r.Runner.CombinedOutput(cmd)
+	return out, r.save(cmd, out, err)
+}
+
+func (r *Recorder) Start(ctx context.Context, cmd string) (Process, io.ReadCloser, error) {
+	p, stdout, err := r.Runner.Start(ctx, cmd)
+	if err != nil {
+		return p, stdout, err
+	}
+
+	file, err := os.Create(filepath.Join(r.Dir, FixtureName(cmd)))
+	if err != nil {
+		p.Kill()
+		p.Wait()
+		return nil, nil, err
+	}
+
+	return p, &teeReadCloser{Reader: io.TeeReader(stdout, file), stdout: stdout, file: file}, nil
+}
+
+func (r *Recorder) save(cmd string, out []byte, err error) error {
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(r.Dir, FixtureName(cmd)), out, 0644)
+}
+
+type teeReadCloser struct {
+	io.Reader
+	stdout io.Closer
+	file   io.Closer
+}
+
+func (t *teeReadCloser) Close() error {
+	t.file.Close()
+	return t.stdout.Close()
+}
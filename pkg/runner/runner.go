@@ -0,0 +1,78 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package runner
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"sync"
+)
+
+// Runner executes the command line tools (pktmon, vfpctrl, hnsdiag, ipconfig) that wcnspect relies on.
+// Every command is passed as a single string, exactly as it would be typed into a Windows shell.
+type Runner interface {
+	// Run executes cmd and waits for it to complete.
+	Run(cmd string) error
+	// Output executes cmd and returns its standard output.
+	Output(cmd string) ([]byte, error)
+	// CombinedOutput executes cmd and returns its standard output and standard error.
+	CombinedOutput(cmd string) ([]byte, error)
+	// Start executes cmd without waiting for it to complete and returns a handle to the process along with its standard output.
+	// The process is killed once ctx is done.
+	Start(ctx context.Context, cmd string) (Process, io.ReadCloser, error)
+}
+
+// Process is a handle to a command launched by Runner.Start.
+type Process interface {
+	Kill() error
+	// Wait waits for the process to exit and releases its resources, returning an error if it exited with a non-zero
+	// status. It must only be called once its standard output has been read to the end, or the process killed.
+	Wait() error
+}
+
+// Shell runs commands through the Windows command interpreter.
+type Shell struct{}
+
+func (Shell) Run(cmd string) error {
+	return exec.Command("cmd", "/c", cmd).Run()
+}
+
+func (Shell) Output(cmd string) ([]byte, error) {
+	return exec.Command("cmd", "/c", cmd).Output()
+}
+
+func (Shell) CombinedOutput(cmd string) ([]byte, error) {
+	return exec.Command("cmd", "/c", cmd).CombinedOutput()
+}
+
+func (Shell) Start(ctx context.Context, cmd string) (Process, io.ReadCloser, error) {
+	c := exec.CommandContext(ctx, "cmd", "/c", cmd)
+	stdout, err := c.StdoutPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := c.Start(); err != nil {
+		return nil, nil, err
+	}
+
+	return &shellProcess{cmd: c}, stdout, nil
+}
+
+// shellProcess is a command started by Shell, which is only waited for once.
+type shellProcess struct {
+	cmd  *exec.Cmd
+	once sync.Once
+	err  error
+}
+
+func (p *shellProcess) Kill() error {
+	return p.cmd.Process.Kill()
+}
+
+func (p *shellProcess) Wait() error {
+	p.once.Do(func() { p.err = p.cmd.Wait() })
+	return p.err
+}
@@ -0,0 +1,254 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Prefix of the files captures are logged to, which is followed by the capture's session ID
+const artifactPrefix = "wcnspect-"
+
+// Smallest ETL file pktmon logs to, which it sizes in MB
+const minCaptureBytes = 1 << 20
+
+// ArtifactStore keeps the files captures are logged to on the node in a single directory, deleting the oldest ones
+// once they're too old or take up too much space.
+type ArtifactStore struct {
+	dir      string
+	maxBytes int64         // Total size of the artifacts, 0 for no limit
+	maxAge   time.Duration // 0 for no limit
+
+	pruneMu sync.Mutex // Held while artifacts are deleted, so that they aren't deleted twice
+
+	mu     sync.Mutex
+	active map[string]bool   // Names of the artifacts captures are still writing
+	errors map[string]string // Why artifacts were kept as ETL files instead of the format they were requested in
+}
+
+func NewArtifactStore(dir string, maxBytes int64, maxAge time.Duration) (*ArtifactStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create artifact directory: %v", err)
+	}
+
+	a := &ArtifactStore{dir: dir, maxBytes: maxBytes, maxAge: maxAge, active: map[string]bool{}, errors: map[string]string{}}
+	a.Prune()
+
+	return a, nil
+}
+
+// Reserves the name of the artifact a session logs to, returning the path of the ETL file pktmon writes to
+// and the name the artifact is listed as once complete.
+func (a *ArtifactStore) create(sessionID string, format pb.ArtifactFormat) (string, string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	base := fmt.Sprintf("%s%s-%s", artifactPrefix, sessionID, time.Now().UTC().Format("20060102T150405Z"))
+	name := base + "." + format.String()
+	a.active[base+".etl"] = true
+	a.active[name] = true
+
+	return filepath.Join(a.dir, base+".etl"), name
+}
+
+// Marks the artifact as complete, deleting the ETL file it was converted from. If converting it failed with err, the
+// ETL file is kept as the artifact instead, listed along with err, and its name is returned.
+func (a *ArtifactStore) complete(etlPath string, name string, err error) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	etlName := filepath.Base(etlPath)
+	delete(a.active, etlName)
+	delete(a.active, name)
+
+	if etlName == name {
+		return name
+	}
+
+	if err != nil {
+		os.Remove(filepath.Join(a.dir, name))
+		a.errors[etlName] = err.Error()
+		return etlName
+	}
+
+	os.Remove(etlPath)
+	return name
+}
+
+// Deletes the files of an artifact whose capture failed to start
+func (a *ArtifactStore) discard(etlPath string, name string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	os.Remove(etlPath)
+	os.Remove(filepath.Join(a.dir, name))
+	delete(a.active, filepath.Base(etlPath))
+	delete(a.active, name)
+}
+
+func (a *ArtifactStore) List() ([]*pb.Artifact, error) {
+	entries, err := os.ReadDir(a.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %v", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ret := []*pb.Artifact{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || !strings.HasPrefix(name, artifactPrefix) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		sessionID := strings.SplitN(strings.TrimPrefix(name, artifactPrefix), "-", 2)[0]
+		ret = append(ret, &pb.Artifact{
+			Name:      name,
+			SessionId: sessionID,
+			Size:      uint64(info.Size()),
+			Modified:  timestamppb.New(info.ModTime()),
+			Complete:  !a.active[name],
+			Error:     a.errors[name],
+		})
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].GetModified().AsTime().Before(ret[j].GetModified().AsTime()) })
+
+	return ret, nil
+}
+
+// Opens a complete artifact for reading
+func (a *ArtifactStore) Open(name string) (*os.File, error) {
+	if filepath.Base(name) != name || !strings.HasPrefix(name, artifactPrefix) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid artifact name '%s'", name)
+	}
+
+	a.mu.Lock()
+	active := a.active[name]
+	a.mu.Unlock()
+
+	if active {
+		return nil, status.Errorf(codes.FailedPrecondition, "artifact %s is still being written", name)
+	}
+
+	file, err := os.Open(filepath.Join(a.dir, name))
+	if os.IsNotExist(err) {
+		return nil, status.Errorf(codes.NotFound, "artifact %s not found", name)
+	}
+
+	return file, err
+}
+
+// Prune deletes the complete artifacts older than the maximum age, then the oldest ones until the artifacts fit in
+// the maximum size.
+func (a *ArtifactStore) Prune() {
+	a.prune(0)
+}
+
+// Run prunes the artifacts every interval until ctx is done, so that they're deleted once they're too old even when
+// no captures are started.
+func (a *ArtifactStore) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.Prune()
+		}
+	}
+}
+
+// Makes room for a capture logging to an artifact of the given format, returning how large its ETL file may grow, or
+// 0 for no limit. The oldest artifacts are deleted until at least half of the maximum size is free, and the capture
+// gets what's free. A pcapng artifact's ETL file only gets half of that, since both files exist while it's converted.
+func (a *ArtifactStore) reserve(format pb.ArtifactFormat) (int64, error) {
+	if a.maxBytes <= 0 {
+		a.Prune()
+		return 0, nil
+	}
+
+	free := a.maxBytes - a.prune(a.maxBytes/2)
+	if format == pb.ArtifactFormat_pcapng {
+		free /= 2
+	}
+
+	if free < minCaptureBytes {
+		return 0, status.Errorf(codes.ResourceExhausted, "not enough room left for a capture's %s artifact under the maximum of %d bytes",
+			format, a.maxBytes)
+	}
+
+	return free, nil
+}
+
+// Deletes the complete artifacts older than the maximum age, then the oldest ones until free bytes are left under the
+// maximum size, returning how many bytes the artifacts left take up.
+func (a *ArtifactStore) prune(free int64) int64 {
+	a.pruneMu.Lock()
+	defer a.pruneMu.Unlock()
+
+	artifacts, err := a.List()
+	if err != nil {
+		log.Print(err)
+		return 0
+	}
+
+	var total int64
+	for _, artifact := range artifacts {
+		total += int64(artifact.GetSize())
+	}
+
+	// Artifacts are listed oldest first
+	for _, artifact := range artifacts {
+		if !artifact.GetComplete() {
+			continue
+		}
+
+		expired := a.maxAge > 0 && time.Since(artifact.GetModified().AsTime()) > a.maxAge
+		if !expired && (a.maxBytes <= 0 || total+free <= a.maxBytes) {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(a.dir, artifact.GetName())); err != nil {
+			log.Printf("Failed to delete artifact %s: %v", artifact.GetName(), err)
+			continue
+		}
+
+		log.Printf("Deleted artifact %s.", artifact.GetName())
+		a.forget(artifact.GetName())
+		total -= int64(artifact.GetSize())
+	}
+
+	return total
+}
+
+// Forgets the error an artifact was kept with, once it's deleted
+func (a *ArtifactStore) forget(name string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.errors, name)
+}
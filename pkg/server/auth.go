@@ -0,0 +1,329 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/microsoft/wcnspect/pkg/comprise"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// Permissions that can be granted to callers
+const (
+	PermissionRead    = "read"    // HNS objects, counters, VFP rules, capture sessions and artifacts
+	PermissionCapture = "capture" // Starting and stopping captures, downloading the files they were logged to, and read
+	PermissionAll     = "*"       // Every RPC
+)
+
+// Permission required by each RPC, by method name. RPCs missing here require PermissionAll.
+var rpcPermissions = map[string]string{
+	"StartCapture":         PermissionCapture,
+	"CapturePcap":          PermissionCapture,
+	"StopCapture":          PermissionCapture,
+	"ListCaptures":         PermissionRead,
+	"GetServerStatus":      PermissionRead,
+	"ListArtifacts":        PermissionRead,
+	"DownloadArtifact":     PermissionCapture,
+	"GetCounters":          PermissionRead,
+	"GetVFPCounters":       PermissionRead,
+	"GetVFPRules":          PermissionRead,
+	"GetHCNLogs":           PermissionRead,
+	"ServerReflectionInfo": PermissionRead,
+}
+
+// Services anyone may call without a token, so that probes can check the server's health
+var publicServices = []string{healthpb.Health_ServiceDesc.ServiceName}
+
+// AuthConfig lists who may call the server and what each caller is allowed to do.
+type AuthConfig struct {
+	Tokens          []TokenGrant          `json:"tokens"`
+	ServiceAccounts []ServiceAccountGrant `json:"serviceAccounts"`
+}
+
+// TokenGrant grants permissions to callers presenting a static bearer token.
+type TokenGrant struct {
+	Name        string   `json:"name"`
+	Token       string   `json:"token"`
+	Permissions []string `json:"permissions"`
+}
+
+// ServiceAccountGrant grants permissions to callers presenting a token of a Kubernetes ServiceAccount.
+type ServiceAccountGrant struct {
+	Namespace   string   `json:"namespace"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// ErrUnauthenticated is returned by TokenReviewers for tokens they reviewed and rejected.
+var ErrUnauthenticated = errors.New("token was not authenticated")
+
+// TokenReviewer validates tokens issued by someone else, returning the name of the token's owner.
+type TokenReviewer interface {
+	Review(ctx context.Context, token string) (string, error)
+}
+
+// KubeTokenReviewer validates ServiceAccount tokens with the Kubernetes TokenReview API.
+type KubeTokenReviewer struct {
+	Client kubernetes.Interface
+}
+
+// TokenReviewTTL is how long the outcome of reviewing a token is reused, so that a revoked token is only accepted
+// for a short while.
+const TokenReviewTTL = 30 * time.Second
+
+// CachingTokenReviewer remembers what another reviewer said about each token for a while, instead of sending a review
+// on every RPC. Tokens are kept by their hash.
+type CachingTokenReviewer struct {
+	reviewer TokenReviewer
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	reviews map[[sha256.Size]byte]tokenReview
+}
+
+type tokenReview struct {
+	username string
+	err      error
+	expires  time.Time
+}
+
+// Authorizer checks the bearer token sent with each request against an AuthConfig.
+type Authorizer struct {
+	tokens   []TokenGrant
+	accounts map[string][]string // ServiceAccount username -> permissions
+	reviewer TokenReviewer
+}
+
+func LoadAuthConfig(path string) (*AuthConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read auth config: %v", err)
+	}
+
+	config := &AuthConfig{}
+	if err := yaml.UnmarshalStrict(b, config); err != nil {
+		return nil, fmt.Errorf("failed to parse auth config %s: %v", path, err)
+	}
+
+	return config, nil
+}
+
+func (r KubeTokenReviewer) Review(ctx context.Context, token string) (string, error) {
+	review := &authv1.TokenReview{Spec: authv1.TokenReviewSpec{Token: token}}
+	res, err := r.Client.AuthenticationV1().TokenReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("token review failed: %v", err)
+	}
+
+	if !res.Status.Authenticated {
+		return "", fmt.Errorf("%w: %s", ErrUnauthenticated, res.Status.Error)
+	}
+
+	return res.Status.User.Username, nil
+}
+
+func NewCachingTokenReviewer(reviewer TokenReviewer, ttl time.Duration) *CachingTokenReviewer {
+	return &CachingTokenReviewer{reviewer: reviewer, ttl: ttl, now: time.Now, reviews: map[[sha256.Size]byte]tokenReview{}}
+}
+
+// Review reuses the outcome of a recent review of the token. Reviews that failed before the token could be checked,
+// e.g. because the API server couldn't be reached, aren't remembered.
+func (r *CachingTokenReviewer) Review(ctx context.Context, token string) (string, error) {
+	key := sha256.Sum256([]byte(token))
+
+	r.mu.Lock()
+	review, ok := r.reviews[key]
+	r.mu.Unlock()
+
+	if ok && r.now().Before(review.expires) {
+		return review.username, review.err
+	}
+
+	username, err := r.reviewer.Review(ctx, token)
+	if err != nil && !errors.Is(err, ErrUnauthenticated) {
+		return username, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Drop expired reviews so that tokens which are no longer used don't pile up
+	now := r.now()
+	for k, review := range r.reviews {
+		if !now.Before(review.expires) {
+			delete(r.reviews, k)
+		}
+	}
+	r.reviews[key] = tokenReview{username: username, err: err, expires: now.Add(r.ttl)}
+
+	return username, err
+}
+
+// NewAuthorizer validates config. A reviewer is only needed if config grants permissions to ServiceAccounts.
+func NewAuthorizer(config *AuthConfig, reviewer TokenReviewer) (*Authorizer, error) {
+	a := &Authorizer{accounts: map[string][]string{}, reviewer: reviewer}
+
+	for _, grant := range config.Tokens {
+		if grant.Name == "" || grant.Token == "" {
+			return nil, errors.New("every token needs a name and a token")
+		}
+
+		if err := validatePermissions(grant.Permissions); err != nil {
+			return nil, fmt.Errorf("invalid permissions for token %s: %v", grant.Name, err)
+		}
+
+		a.tokens = append(a.tokens, grant)
+	}
+
+	for _, grant := range config.ServiceAccounts {
+		if grant.Namespace == "" || grant.Name == "" {
+			return nil, errors.New("every service account needs a namespace and a name")
+		}
+
+		if err := validatePermissions(grant.Permissions); err != nil {
+			return nil, fmt.Errorf("invalid permissions for service account %s/%s: %v", grant.Namespace, grant.Name, err)
+		}
+
+		a.accounts[fmt.Sprintf("system:serviceaccount:%s:%s", grant.Namespace, grant.Name)] = grant.Permissions
+	}
+
+	if len(a.accounts) > 0 && reviewer == nil {
+		return nil, errors.New("a token reviewer is required to authorize service accounts")
+	}
+
+	return a, nil
+}
+
+func (a *Authorizer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authorizer) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// Checks that the caller's bearer token grants the permission needed by the RPC
+func (a *Authorizer) authorize(ctx context.Context, fullMethod string) error {
+	// Full method names are /package.Service/Method
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	if comprise.Contains(publicServices, strings.TrimPrefix(strings.TrimSuffix(fullMethod, "/"+method), "/")) {
+		return nil
+	}
+
+	token := bearerToken(ctx)
+	if token == "" {
+		return status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	name, permissions, err := a.authenticate(ctx, token)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if !allowed(permissions, method) {
+		return status.Errorf(codes.PermissionDenied, "%s is not allowed to call %s", name, method)
+	}
+
+	return nil
+}
+
+// Returns the name and permissions of the token's owner
+func (a *Authorizer) authenticate(ctx context.Context, token string) (string, []string, error) {
+	for _, grant := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(grant.Token), []byte(token)) == 1 {
+			return grant.Name, grant.Permissions, nil
+		}
+	}
+
+	if len(a.accounts) == 0 {
+		return "", nil, errors.New("invalid bearer token")
+	}
+
+	username, err := a.reviewer.Review(ctx, token)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Authenticated ServiceAccounts which weren't granted anything are denied by allowed
+	return username, a.accounts[username], nil
+}
+
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, value := range md.Get("authorization") {
+		if len(value) > len("bearer ") && strings.EqualFold(value[:len("bearer ")], "bearer ") {
+			return strings.TrimSpace(value[len("bearer "):])
+		}
+	}
+
+	return ""
+}
+
+func allowed(permissions []string, method string) bool {
+	required, ok := rpcPermissions[method]
+	for _, p := range permissions {
+		if p == PermissionAll || p == method || (ok && p == required) {
+			return true
+		}
+
+		// Captures can't be followed up on without listing sessions and files
+		if ok && p == PermissionCapture && required == PermissionRead {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Permissions are either one of the groups above or the name of a single RPC
+func validatePermissions(permissions []string) error {
+	for _, p := range permissions {
+		if _, ok := rpcPermissions[p]; ok {
+			continue
+		}
+
+		switch p {
+		case PermissionRead, PermissionCapture, PermissionAll:
+		default:
+			return fmt.Errorf("unknown permission '%s'", p)
+		}
+	}
+
+	return nil
+}
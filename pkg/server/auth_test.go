@@ -0,0 +1,235 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Accepts the token of a single ServiceAccount
+type fakeReviewer struct {
+	token    string
+	username string
+}
+
+func (r fakeReviewer) Review(ctx context.Context, token string) (string, error) {
+	if token != r.token {
+		return "", ErrUnauthenticated
+	}
+
+	return r.username, nil
+}
+
+const authConfig = `
+tokens:
+- name: oncall
+  token: oncall-token
+  permissions: [read, capture]
+- name: dashboard
+  token: dashboard-token
+  permissions: [GetCounters]
+- name: ci
+  token: ci-token
+  permissions: [capture]
+serviceAccounts:
+- namespace: monitoring
+  name: collector
+  permissions: [read]
+`
+
+func TestAuthorizer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.yaml")
+	if err := os.WriteFile(path, []byte(authConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadAuthConfig(path)
+	if err != nil {
+		t.Fatalf("LoadAuthConfig failed: %v", err)
+	}
+
+	authorizer, err := NewAuthorizer(config, fakeReviewer{token: "sa-token", username: "system:serviceaccount:monitoring:collector"})
+	if err != nil {
+		t.Fatalf("NewAuthorizer failed: %v", err)
+	}
+
+	authLis := bufconn.Listen(bufSize)
+	s := grpc.NewServer(grpc.UnaryInterceptor(authorizer.UnaryInterceptor()), grpc.StreamInterceptor(authorizer.StreamInterceptor()))
+	pb.RegisterCaptureServiceServer(s, NewCaptureServer(fake, nil))
+	pb.RegisterHCNServiceServer(s, NewHcnServer(HnsdiagBackend{Runner: fake}))
+	healthpb.RegisterHealthServer(s, NewHealthChecker(fake).Server())
+	defer s.Stop()
+
+	go func() {
+		if err := s.Serve(authLis); err != nil {
+			log.Printf("Server exited with error: %v", err)
+		}
+	}()
+
+	dialer := func(context.Context, string) (net.Conn, error) {
+		return authLis.Dial()
+	}
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	captures, hcn := pb.NewCaptureServiceClient(conn), pb.NewHCNServiceClient(conn)
+
+	cases := []struct {
+		desc     string
+		token    string
+		capture  bool // Whether to call StartCapture instead of GetCounters
+		expected codes.Code
+	}{
+		{"TestMissingToken", "", false, codes.Unauthenticated},
+		{"TestInvalidToken", "guess", false, codes.Unauthenticated},
+		{"TestRead", "oncall-token", false, codes.OK},
+		{"TestCapture", "oncall-token", true, codes.OK},
+		{"TestCaptureIncludesRead", "ci-token", false, codes.OK},
+		{"TestCaptureOnly", "ci-token", true, codes.OK},
+		{"TestSingleRPC", "dashboard-token", false, codes.OK},
+		{"TestSingleRPCDenied", "dashboard-token", true, codes.PermissionDenied},
+		{"TestServiceAccountRead", "sa-token", false, codes.OK},
+		{"TestServiceAccountCaptureDenied", "sa-token", true, codes.PermissionDenied},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.token != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+tc.token)
+			}
+
+			var err error
+			if tc.capture {
+				var stream pb.CaptureService_StartCaptureClient
+				if stream, err = captures.StartCapture(ctx, &pb.CaptureRequest{Duration: 1, Filter: &pb.Filters{}, Modifier: &pb.Modifiers{}}); err == nil {
+					// Streams only fail once they're read from
+					for err == nil {
+						_, err = stream.Recv()
+					}
+
+					if err == io.EOF {
+						err = nil
+					}
+				}
+			} else {
+				_, err = captures.GetCounters(ctx, &pb.CountersRequest{})
+			}
+
+			if status.Code(err) != tc.expected {
+				t.Fatalf("expected: %v, got: %v", tc.expected, err)
+			}
+		})
+	}
+
+	// RPCs are only allowed through the permissions they're listed under
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer dashboard-token")
+	if _, err := hcn.GetHCNLogs(ctx, &pb.HCNRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected: %v, got: %v", codes.PermissionDenied, err)
+	}
+
+	// Probes don't need a token
+	if _, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("expected health checks to be allowed without a token, got: %v", err)
+	}
+
+	// Service accounts can only be authorized by a token reviewer
+	if _, err := NewAuthorizer(config, nil); err == nil {
+		t.Fatalf("expected an error for service accounts without a token reviewer")
+	}
+
+	if _, err := NewAuthorizer(&AuthConfig{Tokens: []TokenGrant{{Name: "ci", Token: "ci-token", Permissions: []string{"admin"}}}}, nil); err == nil {
+		t.Fatalf("expected an error for an unknown permission")
+	}
+}
+
+// Counts the reviews it's asked for, failing them while the API server is down
+type countingReviewer struct {
+	fakeReviewer
+	reviews int
+	down    bool
+}
+
+func (r *countingReviewer) Review(ctx context.Context, token string) (string, error) {
+	r.reviews++
+	if r.down {
+		return "", errors.New("token review failed: connection refused")
+	}
+
+	return r.fakeReviewer.Review(ctx, token)
+}
+
+func TestCachingTokenReviewer(t *testing.T) {
+	clock := time.Date(2022, 6, 20, 20, 35, 58, 0, time.UTC)
+	reviewer := &countingReviewer{fakeReviewer: fakeReviewer{token: "sa-token", username: "system:serviceaccount:monitoring:collector"}}
+	cache := NewCachingTokenReviewer(reviewer, 30*time.Second)
+	cache.now = func() time.Time { return clock }
+
+	review := func(token string, expectedReviews int) error {
+		t.Helper()
+
+		username, err := cache.Review(context.Background(), token)
+		if err == nil && username != reviewer.username {
+			t.Fatalf("expected: %s, got: %s", reviewer.username, username)
+		}
+
+		if reviewer.reviews != expectedReviews {
+			t.Fatalf("expected %d reviews, got: %d", expectedReviews, reviewer.reviews)
+		}
+
+		return err
+	}
+
+	// Both accepted and rejected tokens are only reviewed once within the TTL
+	if err := review("sa-token", 1); err != nil {
+		t.Fatalf("expected the token to be accepted, got: %v", err)
+	}
+	if err := review("sa-token", 1); err != nil {
+		t.Fatalf("expected the token to be accepted, got: %v", err)
+	}
+	if err := review("guess", 2); !errors.Is(err, ErrUnauthenticated) {
+		t.Fatalf("expected the token to be rejected, got: %v", err)
+	}
+	if err := review("guess", 2); !errors.Is(err, ErrUnauthenticated) {
+		t.Fatalf("expected the token to be rejected, got: %v", err)
+	}
+
+	// Reviews that couldn't be done are retried
+	clock = clock.Add(30 * time.Second)
+	reviewer.down = true
+	if err := review("sa-token", 3); err == nil {
+		t.Fatal("expected the review to fail")
+	}
+
+	reviewer.down = false
+	if err := review("sa-token", 4); err != nil {
+		t.Fatalf("expected the token to be accepted, got: %v", err)
+	}
+
+	// Expired reviews are dropped
+	if len(cache.reviews) != 1 {
+		t.Fatalf("expected only the last review to be kept, got: %d", len(cache.reviews))
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/microsoft/wcnspect/pkg/runner"
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Commands run to check that each tool the server relies on is available, and that the HNS and vmswitch services
+// answering them are up
+var toolChecks = map[string]string{
+	"pktmon":  "pktmon list",
+	"vfpctrl": "vfpctrl /list-vmswitch-port",
+	"hnsdiag": "hnsdiag list networks",
+}
+
+// HealthChecker serves the standard gRPC health service, reporting the server and each of its services as
+// NOT_SERVING while any of the tools it relies on can't be run.
+type HealthChecker struct {
+	runner runner.Runner
+	server *health.Server
+}
+
+func NewHealthChecker(r runner.Runner) *HealthChecker {
+	return &HealthChecker{runner: r, server: health.NewServer()}
+}
+
+// Server returns the health service to register on the gRPC server.
+func (h *HealthChecker) Server() healthpb.HealthServer {
+	return h.server
+}
+
+// Check runs every tool, updates the status of the server and returns which tools failed.
+func (h *HealthChecker) Check() error {
+	failed := []string{}
+	for tool, cmd := range toolChecks {
+		if err := h.runner.Run(cmd); err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%v)", tool, err))
+		}
+	}
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if len(failed) > 0 {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	// The empty service name stands for the server as a whole
+	for _, service := range []string{"", pb.CaptureService_ServiceDesc.ServiceName, pb.HCNService_ServiceDesc.ServiceName} {
+		h.server.SetServingStatus(service, status)
+	}
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("tools unavailable: %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// Run checks the tools every interval until ctx is done, logging whenever the server's health changes.
+func (h *HealthChecker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logged := ""
+	for {
+		state := "Server is serving."
+		if err := h.Check(); err != nil {
+			state = fmt.Sprintf("Server is not serving: %v", err)
+		}
+
+		if state != logged {
+			log.Print(state)
+			logged = state
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown reports every service as NOT_SERVING from now on, so that clients stop sending requests.
+func (h *HealthChecker) Shutdown() {
+	h.server.Shutdown()
+}
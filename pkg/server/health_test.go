@@ -0,0 +1,58 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/wcnspect/pkg/runner"
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthChecker(t *testing.T) {
+	r := runner.NewFake(fixtureDir)
+	h := NewHealthChecker(r)
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		res, err := h.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check failed for '%s': %v", service, err)
+		}
+
+		return res.GetStatus()
+	}
+
+	if err := h.Check(); err != nil {
+		t.Fatalf("expected every tool to be available, got: %v", err)
+	}
+
+	for _, service := range []string{"", pb.CaptureService_ServiceDesc.ServiceName, pb.HCNService_ServiceDesc.ServiceName} {
+		if actual := check(service); actual != healthpb.HealthCheckResponse_SERVING {
+			t.Fatalf("expected '%s' to be serving, got: %v", service, actual)
+		}
+	}
+
+	r.Errors["vfpctrl /list-vmswitch-port"] = errors.New("exit status 1")
+	if err := h.Check(); err == nil || !strings.Contains(err.Error(), "vfpctrl") {
+		t.Fatalf("expected vfpctrl to be reported as unavailable, got: %v", err)
+	}
+
+	if actual := check(""); actual != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected the server not to be serving, got: %v", actual)
+	}
+
+	// Once shut down, the server stays not serving whatever the tools report
+	delete(r.Errors, "vfpctrl /list-vmswitch-port")
+	h.Shutdown()
+	h.Check()
+
+	if actual := check(pb.CaptureService_ServiceDesc.ServiceName); actual != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected the server not to be serving once shut down, got: %v", actual)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/microsoft/wcnspect/pkg/netutil"
+	"github.com/microsoft/wcnspect/pkg/runner"
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+// Names of the HNS backends the server can be started with
+var HNSBackends = []string{"hcn", "hnsdiag"}
+
+// HNSBackend retrieves the HNS objects HcnServer reports on.
+type HNSBackend interface {
+	GetHNSObjects(hcntype pb.HCNType, verbose bool) (*pb.HCNResponse, error)
+}
+
+// HCNBackend queries HNS through the HCN API, returning typed objects.
+type HCNBackend struct{}
+
+func (HCNBackend) GetHNSObjects(hcntype pb.HCNType, verbose bool) (*pb.HCNResponse, error) {
+	objs, raw, err := netutil.ListHCNObjects(hcntype, verbose)
+
+	return &pb.HCNResponse{HcnResult: raw, Objects: objs}, err
+}
+
+// HnsdiagBackend runs hnsdiag, returning its output as is.
+type HnsdiagBackend struct {
+	Runner runner.Runner
+}
+
+func (b HnsdiagBackend) GetHNSObjects(hcntype pb.HCNType, verbose bool) (*pb.HCNResponse, error) {
+	logs, err := netutil.GetLogs(b.Runner, hcntype.String(), verbose)
+
+	return &pb.HCNResponse{HcnResult: logs}, err
+}
+
+// Returns the HNS backend with the given name, which runs any tools through r.
+func NewHNSBackend(name string, r runner.Runner) (HNSBackend, error) {
+	switch name {
+	case "hcn":
+		return HCNBackend{}, nil
+	case "hnsdiag":
+		return HnsdiagBackend{Runner: r}, nil
+	}
+
+	return nil, fmt.Errorf("unknown HNS backend '%s', must be one of: %v", name, HNSBackends)
+}
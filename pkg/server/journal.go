@@ -0,0 +1,129 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package server
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/microsoft/wcnspect/pkg/pkt"
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Journal persists the capture sessions running on the node, so that the pktmon state they leave behind can be
+// cleaned up if the server dies mid-capture.
+type Journal struct {
+	path string
+	mu   sync.Mutex
+}
+
+func OpenJournal(path string) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %v", err)
+	}
+
+	return &Journal{path: path}, nil
+}
+
+// Returns the journaled sessions, none if nothing was ever journaled
+func (j *Journal) load() ([]*pb.CaptureSession, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	b, err := os.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %v", err)
+	}
+
+	// The journal has the same shape as a ListCaptures response
+	journal := &pb.ListCapturesResponse{}
+	if err := protojson.Unmarshal(b, journal); err != nil {
+		return nil, fmt.Errorf("failed to parse journal %s: %v", j.path, err)
+	}
+
+	return journal.GetSessions(), nil
+}
+
+// Replaces the journaled sessions, writing to a temporary file first so the journal is never left half written
+func (j *Journal) save(sessions []*pb.CaptureSession) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	b, err := protojson.Marshal(&pb.ListCapturesResponse{Sessions: sessions, Timestamp: timestamppb.Now()})
+	if err != nil {
+		return err
+	}
+
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return fmt.Errorf("failed to write journal: %v", err)
+	}
+
+	return os.Rename(tmp, j.path)
+}
+
+// Recover cleans up after the sessions journaled by the server's previous run, which only remain if it died before
+// they ended: pktmon is stopped if any were left, and wcnspect's filters are removed whether or not any were.
+// Sessions are journaled from then on, and GetServerStatus reports what was cleaned up.
+func (s *CaptureServer) Recover(journal *Journal) *pb.ServerCleanup {
+	cleanup := &pb.ServerCleanup{Time: timestamppb.Now()}
+	fail := func(err error) {
+		log.Print(err)
+		cleanup.Errors = append(cleanup.Errors, err.Error())
+	}
+
+	sessions, err := journal.load()
+	if err != nil {
+		fail(err)
+	}
+	cleanup.Sessions = sessions
+
+	// pktmon may be in use by someone else, so it's only stopped if one of our sessions was running
+	if len(sessions) > 0 {
+		for _, session := range sessions {
+			log.Printf("Capture session %s (started at %s) was running when the server stopped.\n",
+				session.GetSessionId(), session.GetStarted().AsTime())
+		}
+
+		if err := pkt.ResetCaptureProgram(s.runner); err != nil {
+			fail(err)
+		} else {
+			cleanup.PktmonStopped = true
+		}
+	}
+
+	// pktmon can only remove every filter at once, so that's only done if some of them are ours
+	filters, err := pkt.ListFilters(s.runner)
+	if err != nil {
+		fail(err)
+	}
+
+	if len(filters) > 0 {
+		if err := pkt.ResetFilters(s.runner); err != nil {
+			fail(err)
+		} else {
+			cleanup.Filters = filters
+			log.Printf("Removed packet monitor filters %v left behind.\n", filters)
+		}
+	}
+
+	if err := journal.save(nil); err != nil {
+		fail(err)
+	}
+
+	s.sessions.setJournal(journal)
+	s.cleanup = cleanup
+
+	return cleanup
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/microsoft/wcnspect/pkg/comprise"
+	"github.com/microsoft/wcnspect/pkg/runner"
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRecover(t *testing.T) {
+	journal, err := OpenJournal(filepath.Join(t.TempDir(), "state", "sessions.json"))
+	if err != nil {
+		t.Fatalf("OpenJournal failed: %v", err)
+	}
+
+	// A session the previous run didn't get to end
+	orphan := &pb.CaptureSession{SessionId: "1a2b3c4d", Started: timestamppb.Now(), Artifact: "wcnspect-1a2b3c4d-20220601T100000Z.etl"}
+	if err := journal.save([]*pb.CaptureSession{orphan}); err != nil {
+		t.Fatalf("failed to save journal: %v", err)
+	}
+
+	r := runner.NewFake(fixtureDir)
+	captures := NewCaptureServer(r, nil)
+	cleanup := captures.Recover(journal)
+
+	if len(cleanup.GetSessions()) != 1 || cleanup.GetSessions()[0].GetSessionId() != orphan.GetSessionId() {
+		t.Fatalf("expected session %s to be cleaned up, got: %v", orphan.GetSessionId(), cleanup.GetSessions())
+	}
+
+	// Filters added by others are listed, but not reported as ours
+	if expected := []string{"wcnspect-TCP-1", "wcnspect-TCP-2"}; !cleanup.GetPktmonStopped() || !reflect.DeepEqual(cleanup.GetFilters(), expected) {
+		t.Fatalf("expected pktmon to be stopped and filters %v removed, got: %v", expected, cleanup)
+	}
+
+	for _, cmd := range []string{"pktmon stop", "pktmon filter remove"} {
+		if !comprise.Contains(r.Calls(), cmd) {
+			t.Fatalf("expected '%s' to be run, got: %v", cmd, r.Calls())
+		}
+	}
+
+	// Sessions are journaled from then on
+	session, err := captures.sessions.begin(&pb.CaptureRequest{Duration: 5}, false)
+	if err != nil {
+		t.Fatalf("failed to begin session: %v", err)
+	}
+
+	if sessions, err := journal.load(); err != nil || len(sessions) != 1 || sessions[0].GetSessionId() != session.id {
+		t.Fatalf("expected session %s to be journaled, got: %v (%v)", session.id, sessions, err)
+	}
+
+	status, err := captures.GetServerStatus(context.Background(), &pb.Empty{})
+	if err != nil {
+		t.Fatalf("GetServerStatus failed: %v", err)
+	}
+
+	if status.GetCleanup() != cleanup || len(status.GetSessions()) != 1 {
+		t.Fatalf("expected the cleanup and running session to be reported, got: %v", status)
+	}
+
+	captures.sessions.end(session.id)
+	if sessions, err := journal.load(); err != nil || len(sessions) != 0 {
+		t.Fatalf("expected the journal to be empty, got: %v (%v)", sessions, err)
+	}
+}
+
+func TestRecoverCleanNode(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, runner.FixtureName("pktmon filter list")), []byte("There are no packet filters.\r\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	journal, err := OpenJournal(filepath.Join(dir, "sessions.json"))
+	if err != nil {
+		t.Fatalf("OpenJournal failed: %v", err)
+	}
+
+	// Without a journal or filters of ours, pktmon is left alone since someone else may be using it
+	r := runner.NewFake(dir)
+	cleanup := NewCaptureServer(r, nil).Recover(journal)
+
+	if len(cleanup.GetSessions()) != 0 || cleanup.GetPktmonStopped() || len(cleanup.GetFilters()) != 0 || len(cleanup.GetErrors()) != 0 {
+		t.Fatalf("expected nothing to be cleaned up, got: %v", cleanup)
+	}
+
+	if expected := []string{"pktmon filter list"}; !reflect.DeepEqual(r.Calls(), expected) {
+		t.Fatalf("expected only %v to be run, got: %v", expected, r.Calls())
+	}
+}
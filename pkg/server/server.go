@@ -6,83 +6,138 @@ package server
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"math"
-	"os/exec"
+	"os"
+	"path/filepath"
 	"time"
 
-	"github.com/microsoft/wcnspect/pkg/netutil"
+	"github.com/microsoft/wcnspect/common"
 	"github.com/microsoft/wcnspect/pkg/pkt"
+	"github.com/microsoft/wcnspect/pkg/runner"
 	"github.com/microsoft/wcnspect/pkg/vfputil"
 	pb "github.com/microsoft/wcnspect/rpc"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Size of the chunks pcapng files are streamed in
+const pcapChunkSize = 64 * 1024
+
 type CaptureServer struct {
 	pb.UnimplementedCaptureServiceServer
-	currMonitor      *exec.Cmd          // Tracks the running pktmon stream
-	pktContextCancel context.CancelFunc // Tracks the pktmon stream's context's cancel func
-	printCounters    bool
+	runner    runner.Runner   // Executes pktmon and vfpctrl commands
+	sessions  *sessionManager // Tracks the capture sessions using pktmon
+	artifacts *ArtifactStore  // Keeps the files captures are logged to on the node, if enabled
+	started   time.Time
+	cleanup   *pb.ServerCleanup // What Recover cleaned up after the server's previous run, if it was called
 }
 
 type HcnServer struct {
 	pb.UnimplementedHCNServiceServer
+	backend HNSBackend // Retrieves HNS objects, either through the HCN API or hnsdiag
+}
+
+// Constructor for CaptureServer. Captures can only be logged to files on the node if artifacts isn't nil.
+func NewCaptureServer(r runner.Runner, artifacts *ArtifactStore) *CaptureServer {
+	return &CaptureServer{runner: r, sessions: newSessionManager(), artifacts: artifacts, started: time.Now()}
+}
+
+// Constructor for HcnServer
+func NewHcnServer(b HNSBackend) *HcnServer {
+	return &HcnServer{backend: b}
 }
 
 func (s *CaptureServer) StartCapture(req *pb.CaptureRequest, stream pb.CaptureService_StartCaptureServer) error {
 	fmt.Printf("StartCapture function was invoked with %v\n", req)
 
+	if req.GetArtifact() != pb.ArtifactFormat_none {
+		return s.startFileCapture(req, stream)
+	}
+
 	// Retrieve and format request arguments
 	dur := req.GetDuration()
 	modifiers := req.GetModifier()
 	filters := req.GetFilter()
-	s.printCounters = modifiers.GetCountersOnly()
+	countersOnly := modifiers.GetCountersOnly()
 
 	// If duration is less than or equal to 0, we run for an "infinite" amount of time
 	if dur <= 0 {
 		dur = math.MaxInt32
 	}
 
+	// Claim pktmon for this session, so other users can't stomp on it
+	session, err := s.sessions.begin(req, false)
+	if err != nil {
+		return err
+	}
+	defer s.sessions.end(session.id)
+
+	if err := stream.SendHeader(metadata.Pairs(common.SessionIDHeader, session.id)); err != nil {
+		return err
+	}
+
 	// Ensure filters are reset and add new ones
-	if err := pkt.ResetCaptureProgram(); err != nil {
+	if err := pkt.ResetCaptureProgram(s.runner); err != nil {
 		return err
 	}
 
-	if err := pkt.ResetFilters(); err != nil {
+	if err := pkt.ResetFilters(s.runner); err != nil {
 		return err
 	}
 
-	if err := pkt.AddFilters(filters); err != nil {
+	if err := pkt.AddFilters(s.runner, filters); err != nil {
 		return err
 	}
 
 	// Revise pktmonStartCommand based on Modifiers
-	captureCmd, err := pkt.ModifyCaptureCmd(modifiers)
+	captureCmd, err := pkt.ModifyCaptureCmd(s.runner, modifiers)
 	if err != nil {
 		return err
 	}
 
-	// Create a timeout context and set as server's pktmon canceller
+	// Create a timeout context for the pktmon stream
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(dur)*time.Second)
-	s.pktContextCancel = cancel
+	defer cancel()
 
-	// Execute pktmon command and check for errors, if successful, set as server's currMonitor
-	cmd, stdout, err := pkt.StartStream(ctx, captureCmd)
+	// Execute pktmon command and check for errors, if successful, track it in the session
+	proc, stdout, err := pkt.StartStream(ctx, s.runner, captureCmd)
 	if err != nil {
 		return err
 	}
-	s.currMonitor = cmd
+	session.setMonitor(proc, cancel)
 
-	// Create a channel to receive pktmon stream from
+	// Create a channel to receive pktmon stream from, and a parser to assemble packet records from its lines
 	c := pkt.CreateStreamChannel(stdout)
+	parser := pkt.NewPacketParser()
 
 	// Goroutine with a timeout constraint and pulling on pktmon channel with scanning loop
 loop:
 	for {
 		select {
-		case out := <-c:
-			if s.printCounters {
+		case out, ok := <-c:
+			// pktmon stopped on its own, so the last packet won't be completed by another one starting
+			if !ok {
+				if packet := parser.Flush(); packet != nil {
+					res := &pb.CaptureResponse{
+						Timestamp: timestamppb.Now(),
+						Packet:    packet,
+						SessionId: session.id,
+					}
+
+					if err := stream.Send(res); err != nil {
+						return err
+					}
+				}
+
+				break loop
+			}
+
+			if countersOnly {
 				time.Sleep(time.Millisecond * 100)
 				continue
 			}
@@ -90,6 +145,8 @@ loop:
 			res := &pb.CaptureResponse{
 				Result:    out,
 				Timestamp: timestamppb.Now(),
+				Packet:    parser.Parse(out),
+				SessionId: session.id,
 			}
 
 			if err := stream.Send(res); err != nil {
@@ -103,9 +160,9 @@ loop:
 		}
 	}
 
-	// If timeout reached and printCounters, then send counter table
-	if s.printCounters {
-		counters, err := pkt.PullCounters()
+	// If timeout reached and counters weren't already pulled by StopCapture, then send counter table
+	if session.takeCounters() {
+		counters, err := pkt.PullCounters(s.runner)
 
 		if err != nil {
 			return err
@@ -114,39 +171,352 @@ loop:
 		res := &pb.CaptureResponse{
 			Result:    counters,
 			Timestamp: timestamppb.Now(),
+			SessionId: session.id,
+			Counters:  s.structuredCounters(counters),
 		}
 
 		stream.Send(res)
 		log.Printf("Sent: \n%v", res)
 	}
 
-	// Reset pktmon filters and CaptureServer's fields
+	// Reset pktmon filters, releasing pktmon once what's left of its output is read
+	stopped := ctx.Err() != nil
 	cancel()
-	resetCaptureContext(s)
 
-	if err := pkt.ResetFilters(); err != nil {
+	go func() {
+		for range c {
+		}
+	}()
+
+	exitErr := proc.Wait()
+
+	if err := pkt.ResetFilters(s.runner); err != nil {
 		return err
 	}
 
 	log.Printf("Packet monitor filters reset.")
 
+	// pktmon is killed when the capture is stopped, so only its exit status from stopping on its own is meaningful
+	if exitErr != nil && !stopped {
+		return fmt.Errorf("pktmon exited with an error: %v", exitErr)
+	}
+
 	return nil
 }
 
-func (s *CaptureServer) StopCapture(ctx context.Context, req *pb.Empty) (*pb.StopCaptureResponse, error) {
-	fmt.Println("StopCapture function was invoked.")
+func (s *CaptureServer) CapturePcap(req *pb.CaptureRequest, stream pb.CaptureService_CapturePcapServer) error {
+	fmt.Printf("CapturePcap function was invoked with %v\n", req)
+
+	// Retrieve and format request arguments
+	dur := req.GetDuration()
+	modifiers := req.GetModifier()
+	filters := req.GetFilter()
+
+	// If duration is less than or equal to 0, we run for an "infinite" amount of time
+	if dur <= 0 {
+		dur = math.MaxInt32
+	}
+
+	// Claim pktmon for this session, so other users can't stomp on it
+	session, err := s.sessions.begin(req, true)
+	if err != nil {
+		return err
+	}
+	defer s.sessions.end(session.id)
+
+	if err := stream.SendHeader(metadata.Pairs(common.SessionIDHeader, session.id)); err != nil {
+		return err
+	}
+
+	// Log packets to a temporary ETL file, which is converted to pcapng once the capture ends
+	name := fmt.Sprintf("wcnspect-%d", time.Now().UnixNano())
+	etlPath := filepath.Join(os.TempDir(), name+".etl")
+	pcapPath := filepath.Join(os.TempDir(), name+".pcapng")
+	defer os.Remove(etlPath)
+	defer os.Remove(pcapPath)
+
+	captureCmd, err := pkt.FileCaptureCmd(s.runner, modifiers, etlPath)
+	if err != nil {
+		return err
+	}
+
+	// Ensure filters are reset and add new ones
+	if err := pkt.ResetFilters(s.runner); err != nil {
+		return err
+	}
+
+	if err := pkt.AddFilters(s.runner, filters); err != nil {
+		return err
+	}
+
+	// Create a timeout context and let the session cancel it
+	ctx, cancel := context.WithTimeout(stream.Context(), time.Duration(dur)*time.Second)
+	defer cancel()
+	session.setMonitor(nil, cancel)
+
+	if err := pkt.StartFileCapture(s.runner, captureCmd); err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	log.Printf("Packet logging finished.")
+
+	// Stop pktmon so the ETL file is flushed, then reset pktmon filters
+	cancel()
+
+	if err := pkt.ResetCaptureProgram(s.runner); err != nil {
+		return err
+	}
+
+	if err := pkt.ResetFilters(s.runner); err != nil {
+		return err
+	}
+
+	log.Printf("Packet monitor filters reset.")
+
+	if err := pkt.ConvertToPcap(s.runner, etlPath, pcapPath); err != nil {
+		return err
+	}
+
+	file, err := os.Open(pcapPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Stream the pcapng file in chunks
+	buf := make([]byte, pcapChunkSize)
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			res := &pb.PcapResponse{
+				Result:    buf[:n],
+				Timestamp: timestamppb.Now(),
+				SessionId: session.id,
+			}
+
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Sent pcapng file %s.", pcapPath)
+
+	return nil
+}
+
+// Logs packets to an artifact on the node, so the capture outlives the client's connection. Returns once the capture
+// has started, and finishes the capture in the background once it times out or is stopped.
+func (s *CaptureServer) startFileCapture(req *pb.CaptureRequest, stream pb.CaptureService_StartCaptureServer) error {
+	if s.artifacts == nil {
+		return status.Error(codes.FailedPrecondition, "logging captures to files isn't enabled on this server")
+	}
+
+	// Retrieve and format request arguments
+	dur := req.GetDuration()
+	if dur <= 0 {
+		dur = math.MaxInt32
+	}
+
+	// Claim pktmon for this session, so other users can't stomp on it
+	session, err := s.sessions.begin(req, false)
+	if err != nil {
+		return err
+	}
+
+	etlPath, name := s.artifacts.create(session.id, req.GetArtifact())
+	s.sessions.setArtifact(session, name)
+
+	if err := s.beginFileCapture(req, session, etlPath, stream); err != nil {
+		s.artifacts.discard(etlPath, name)
+		s.sessions.end(session.id)
+		return err
+	}
+
+	// The capture isn't tied to the request's context, so it keeps running if the client goes away
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(dur)*time.Second)
+	session.setMonitor(nil, cancel)
+	go s.finishFileCapture(ctx, session, etlPath, req.GetArtifact())
+
+	res := &pb.CaptureResponse{
+		Result:    fmt.Sprintf("Logging packets to %s in session %s.", name, session.id),
+		Timestamp: timestamppb.Now(),
+		SessionId: session.id,
+		Artifact:  name,
+	}
+
+	log.Printf("Sending: \n%v", res)
+
+	return stream.Send(res)
+}
+
+func (s *CaptureServer) beginFileCapture(req *pb.CaptureRequest, session *captureSession, etlPath string, stream pb.CaptureService_StartCaptureServer) error {
+	if err := stream.SendHeader(metadata.Pairs(common.SessionIDHeader, session.id)); err != nil {
+		return err
+	}
+
+	captureCmd, err := pkt.FileCaptureCmd(s.runner, req.GetModifier(), etlPath)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Make room for the new artifact, and bound the ETL file to it, which pktmon sizes in MB
+	maxBytes, err := s.artifacts.reserve(req.GetArtifact())
+	if err != nil {
+		return err
+	}
+
+	if maxBytes > 0 {
+		captureCmd += fmt.Sprintf(" -s %d", maxBytes>>20)
+	}
+
+	// Ensure filters are reset and add new ones
+	if err := pkt.ResetFilters(s.runner); err != nil {
+		return err
+	}
+
+	err = pkt.AddFilters(s.runner, req.GetFilter())
+	if err == nil {
+		err = pkt.StartFileCapture(s.runner, captureCmd)
+	}
+
+	// Don't leave the filters behind if pktmon didn't start logging
+	if err != nil {
+		if resetErr := pkt.ResetFilters(s.runner); resetErr != nil {
+			log.Print(resetErr)
+		}
+	}
+
+	return err
+}
+
+// Waits for a capture logging to an artifact to end, then stops pktmon and completes the artifact
+func (s *CaptureServer) finishFileCapture(ctx context.Context, session *captureSession, etlPath string, format pb.ArtifactFormat) {
+	defer s.sessions.end(session.id)
+
+	<-ctx.Done()
+	log.Printf("Packet logging to %s finished.", session.artifact)
+
+	// Stop pktmon so the ETL file is flushed, then reset pktmon filters
+	if err := pkt.ResetCaptureProgram(s.runner); err != nil {
+		log.Print(err)
+	}
+
+	if err := pkt.ResetFilters(s.runner); err != nil {
+		log.Print(err)
+	}
+
+	var err error
+	if format == pb.ArtifactFormat_pcapng {
+		err = pkt.ConvertToPcap(s.runner, etlPath, filepath.Join(filepath.Dir(etlPath), session.artifact))
+	}
+
+	// The ETL file is kept if it couldn't be converted, so the capture isn't lost
+	if name := s.artifacts.complete(etlPath, session.artifact, err); name != session.artifact {
+		log.Printf("%v, keeping %s instead.", err, name)
+		s.sessions.setArtifact(session, name)
+	}
+}
+
+func (s *CaptureServer) GetServerStatus(ctx context.Context, req *pb.Empty) (*pb.ServerStatusResponse, error) {
+	fmt.Println("GetServerStatus function was invoked.")
+
+	res := &pb.ServerStatusResponse{
+		Started:   timestamppb.New(s.started),
+		Cleanup:   s.cleanup,
+		Timestamp: timestamppb.Now(),
+	}
+
+	for _, session := range s.sessions.list() {
+		res.Sessions = append(res.Sessions, session.toProto())
+	}
+
+	log.Printf("Sending: \n%v", res)
+
+	return res, nil
+}
+
+func (s *CaptureServer) ListArtifacts(ctx context.Context, req *pb.Empty) (*pb.ListArtifactsResponse, error) {
+	fmt.Println("ListArtifacts function was invoked.")
+
+	if s.artifacts == nil {
+		return nil, status.Error(codes.FailedPrecondition, "logging captures to files isn't enabled on this server")
+	}
+
+	artifacts, err := s.artifacts.List()
+	res := &pb.ListArtifactsResponse{
+		Artifacts: artifacts,
+		Timestamp: timestamppb.Now(),
+	}
+
+	log.Printf("Sending: \n%v", res)
+
+	return res, err
+}
+
+func (s *CaptureServer) DownloadArtifact(req *pb.DownloadArtifactRequest, stream pb.CaptureService_DownloadArtifactServer) error {
+	fmt.Printf("DownloadArtifact function was invoked for '%s'.\n", req.GetName())
+
+	if s.artifacts == nil {
+		return status.Error(codes.FailedPrecondition, "logging captures to files isn't enabled on this server")
+	}
+
+	file, err := s.artifacts.Open(req.GetName())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Stream the artifact in chunks
+	buf := make([]byte, pcapChunkSize)
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.ArtifactChunk{Data: buf[:n], Timestamp: timestamppb.Now()}); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Sent artifact %s.", req.GetName())
+
+	return nil
+}
+
+func (s *CaptureServer) StopCapture(ctx context.Context, req *pb.StopCaptureRequest) (*pb.StopCaptureResponse, error) {
+	id := req.GetSessionId()
+	fmt.Printf("StopCapture function was invoked for session '%s'.\n", id)
 	var msg string
+	var rows []*pb.ComponentCounters
 	var err error
 
-	if s.currMonitor != nil {
-		if s.printCounters {
-			msg, err = pkt.PullCounters()
-			s.printCounters = false
+	// An empty session ID stops whichever capture is running
+	if session, ok := s.sessions.get(id); ok {
+		if session.stop() {
+			msg, err = pkt.PullCounters(s.runner)
+			rows = s.structuredCounters(msg)
 		}
 
-		s.currMonitor.Process.Kill()
-		s.pktContextCancel()
-		log.Printf("Successfully killed packet capture stream.\n")
+		log.Printf("Successfully killed packet capture session %s.\n", session.id)
+	} else if id != "" {
+		return nil, status.Errorf(codes.NotFound, "capture session %s not found", id)
 	} else {
 		log.Printf("Packet capture stream not found.\n")
 	}
@@ -154,6 +524,7 @@ func (s *CaptureServer) StopCapture(ctx context.Context, req *pb.Empty) (*pb.Sto
 	res := &pb.StopCaptureResponse{
 		Result:    msg,
 		Timestamp: timestamppb.Now(),
+		Counters:  rows,
 	}
 
 	log.Printf("Sending StopCapture execution timestamp: \n%v", res)
@@ -161,14 +532,54 @@ func (s *CaptureServer) StopCapture(ctx context.Context, req *pb.Empty) (*pb.Sto
 	return res, err
 }
 
+// Shutdown refuses new captures and stops the running ones, waiting for them to finish until ctx is done. Then pktmon
+// is stopped and its filters removed, so the node isn't left capturing with wcnspect's filters installed.
+func (s *CaptureServer) Shutdown(ctx context.Context) error {
+	for _, session := range s.sessions.close() {
+		log.Printf("Stopping capture session %s.\n", session.id)
+		session.stop()
+	}
+
+	waitErr := s.sessions.wait(ctx)
+
+	if err := pkt.ResetCaptureProgram(s.runner); err != nil {
+		return err
+	}
+
+	if err := pkt.ResetFilters(s.runner); err != nil {
+		return err
+	}
+
+	log.Printf("Packet monitor stopped and filters reset.")
+
+	return waitErr
+}
+
+func (s *CaptureServer) ListCaptures(ctx context.Context, req *pb.Empty) (*pb.ListCapturesResponse, error) {
+	fmt.Println("ListCaptures function was invoked.")
+
+	res := &pb.ListCapturesResponse{
+		Timestamp: timestamppb.Now(),
+	}
+
+	for _, session := range s.sessions.list() {
+		res.Sessions = append(res.Sessions, session.toProto())
+	}
+
+	log.Printf("Sending: \n%v", res)
+
+	return res, nil
+}
+
 func (s *CaptureServer) GetCounters(ctx context.Context, req *pb.CountersRequest) (*pb.CountersResponse, error) {
 	fmt.Println("GetCounters function was invoked.")
 	includeHidden := req.GetIncludeHidden()
 
-	counters, err := pkt.PullStreamCounters(includeHidden)
+	counters, err := pkt.PullStreamCounters(s.runner, includeHidden)
 	res := &pb.CountersResponse{
 		Result:    counters,
 		Timestamp: timestamppb.Now(),
+		Counters:  s.structuredCounters(counters),
 	}
 
 	log.Printf("Sending: \n%v", res)
@@ -176,14 +587,19 @@ func (s *CaptureServer) GetCounters(ctx context.Context, req *pb.CountersRequest
 	return res, err
 }
 
-func (*CaptureServer) GetVFPCounters(ctx context.Context, req *pb.VFPCountersRequest) (*pb.VFPCountersResponse, error) {
+func (s *CaptureServer) GetVFPCounters(ctx context.Context, req *pb.VFPCountersRequest) (*pb.VFPCountersResponse, error) {
 	fmt.Println("GetVFPCounters function was invoked.")
 	pod, verbose := req.GetPod(), req.GetVerbose()
 
-	counters, err := vfputil.CollateCounters(pod, verbose)
+	counters, ports, err := vfputil.CollateCounters(s.runner, pod, verbose)
 	res := &pb.VFPCountersResponse{
-		Result:    counters,
 		Timestamp: timestamppb.Now(),
+		Ports:     ports,
+	}
+
+	// The raw vfpctrl tables are only sent if asked for
+	if req.GetIncludeRaw() {
+		res.Result = &counters
 	}
 
 	log.Printf("Sending: \n%v", res)
@@ -191,14 +607,20 @@ func (*CaptureServer) GetVFPCounters(ctx context.Context, req *pb.VFPCountersReq
 	return res, err
 }
 
-func (*HcnServer) GetHCNLogs(ctx context.Context, req *pb.HCNRequest) (*pb.HCNResponse, error) {
-	hcntype, verbose := pb.HCNType(req.GetHcntype()), req.GetVerbose()
+func (s *CaptureServer) GetVFPRules(ctx context.Context, req *pb.VFPRulesRequest) (*pb.VFPRulesResponse, error) {
+	fmt.Println("GetVFPRules function was invoked.")
+	pod, layers := req.GetPod(), req.GetLayers()
 
-	fmt.Printf("GetHCNLogs function was invoked for %s.\n", hcntype)
+	guid, err := vfputil.GetPodPortGUID(s.runner, pod)
+	if err != nil {
+		return nil, err
+	}
 
-	logs, err := netutil.GetLogs(hcntype.String(), verbose)
-	res := &pb.HCNResponse{
-		HcnResult: logs,
+	tree, err := vfputil.GetVFPRuleTree(s.runner, guid, layers)
+	res := &pb.VFPRulesResponse{
+		PortGuid:  guid,
+		Layers:    tree,
+		Timestamp: timestamppb.Now(),
 	}
 
 	log.Printf("Sending: \n%v", res)
@@ -206,8 +628,25 @@ func (*HcnServer) GetHCNLogs(ctx context.Context, req *pb.HCNRequest) (*pb.HCNRe
 	return res, err
 }
 
-func resetCaptureContext(s *CaptureServer) {
-	s.currMonitor = nil
-	s.pktContextCancel = nil
-	s.printCounters = false
+// Parses a pktmon counter table into per-component rows. Since the raw table is still returned,
+// failing to look up component MACs is logged rather than failing the request.
+func (s *CaptureServer) structuredCounters(out string) []*pb.ComponentCounters {
+	rows, err := pkt.PullStructuredCounters(s.runner, out)
+	if err != nil {
+		log.Printf("Failed to look up packet monitor component MACs: %v", err)
+	}
+
+	return rows
+}
+
+func (s *HcnServer) GetHCNLogs(ctx context.Context, req *pb.HCNRequest) (*pb.HCNResponse, error) {
+	hcntype, verbose := pb.HCNType(req.GetHcntype()), req.GetVerbose()
+
+	fmt.Printf("GetHCNLogs function was invoked for %s.\n", hcntype)
+
+	res, err := s.backend.GetHNSObjects(hcntype, verbose)
+
+	log.Printf("Sending: \n%v", res)
+
+	return res, err
 }
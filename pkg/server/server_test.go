@@ -4,28 +4,42 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io"
 	"log"
 	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/microsoft/wcnspect/common"
 	"github.com/microsoft/wcnspect/pkg/netutil"
+	"github.com/microsoft/wcnspect/pkg/runner"
 	pb "github.com/microsoft/wcnspect/rpc"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
 )
 
 const bufSize = 1024 * 1024
+const fixtureDir = "testdata"
 
 var lis *bufconn.Listener
+var fake = runner.NewFake(fixtureDir)
 
 func init() {
 	lis = bufconn.Listen(bufSize)
 
 	s := grpc.NewServer()
-	pb.RegisterCaptureServiceServer(s, &CaptureServer{})
-	pb.RegisterHCNServiceServer(s, &HcnServer{})
+	pb.RegisterCaptureServiceServer(s, NewCaptureServer(fake, nil))
+	pb.RegisterHCNServiceServer(s, NewHcnServer(HnsdiagBackend{Runner: fake}))
 
 	go func() {
 		if err := s.Serve(lis); err != nil {
@@ -38,6 +52,201 @@ func bufDialer(context.Context, string) (net.Conn, error) {
 	return lis.Dial()
 }
 
+func readFixture(t *testing.T, cmd string) string {
+	out, err := os.ReadFile(filepath.Join(fixtureDir, runner.FixtureName(cmd)))
+	if err != nil {
+		t.Fatalf("Failed to read fixture for '%s': %v", cmd, err)
+	}
+
+	return string(out)
+}
+
+func TestStartCapture(t *testing.T) {
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	cases := []struct {
+		desc     string
+		in       *pb.CaptureRequest
+		expected []string
+		packets  int
+		counters int
+	}{
+		{
+			"TestPacketStream",
+			&pb.CaptureRequest{Duration: 1},
+			strings.Split(strings.TrimSuffix(readFixture(t, "pktmon start -c -m real-time --type all"), "\r\n"), "\r\n"),
+			4,
+			0,
+		},
+		{
+			"TestCountersOnly",
+			&pb.CaptureRequest{Duration: 1, Modifier: &pb.Modifiers{CountersOnly: true}},
+			[]string{readFixture(t, "pktmon stop")},
+			0,
+			4,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := pb.NewCaptureServiceClient(conn)
+			stream, err := client.StartCapture(ctx, tc.in)
+
+			if err != nil {
+				t.Fatalf("StartCapture failed: %v", err)
+			}
+
+			actual := []string{}
+			packets, counters := 0, 0
+			for {
+				res, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+
+				if err != nil {
+					t.Fatalf("error while reading stream: %v", err)
+				}
+
+				actual = append(actual, res.GetResult())
+				if res.GetPacket() != nil {
+					packets++
+				}
+				counters += len(res.GetCounters())
+			}
+
+			if strings.Join(actual, "\n") != strings.Join(tc.expected, "\n") {
+				t.Fatalf("expected: \n%v\n got: \n%v\n", tc.expected, actual)
+			}
+
+			if packets != tc.packets {
+				t.Fatalf("expected %d parsed packets, got %d", tc.packets, packets)
+			}
+
+			if counters != tc.counters {
+				t.Fatalf("expected %d component counter rows, got %d", tc.counters, counters)
+			}
+		})
+	}
+}
+
+func TestStartCapturePktmonExits(t *testing.T) {
+	cases := []struct {
+		desc  string
+		err   error
+		fails bool
+	}{
+		{"TestCleanExit", nil, false},
+		{"TestFailedExit", errors.New("exit status 1"), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := runner.NewFake(fixtureDir)
+			r.Exits["pktmon start -c -m real-time --type all"] = tc.err
+
+			exitLis := bufconn.Listen(bufSize)
+			s := grpc.NewServer()
+			pb.RegisterCaptureServiceServer(s, NewCaptureServer(r, nil))
+			go s.Serve(exitLis)
+			defer s.Stop()
+
+			ctx := context.Background()
+			dialer := func(context.Context, string) (net.Conn, error) { return exitLis.Dial() }
+			conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+			if err != nil {
+				t.Fatalf("Failed to dial bufnet: %v", err)
+			}
+			defer conn.Close()
+
+			stream, err := pb.NewCaptureServiceClient(conn).StartCapture(ctx, &pb.CaptureRequest{Duration: 30})
+			if err != nil {
+				t.Fatalf("StartCapture failed: %v", err)
+			}
+
+			// The stream ends as soon as pktmon does, well before its 30 second duration
+			done := make(chan error)
+			go func() {
+				for {
+					if _, err := stream.Recv(); err != nil {
+						done <- err
+						return
+					}
+				}
+			}()
+
+			select {
+			case err := <-done:
+				if (err != io.EOF) != tc.fails {
+					t.Fatalf("expected failure: %t got error: %v", tc.fails, err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("stream didn't end when pktmon exited")
+			}
+		})
+	}
+}
+
+func TestStartCaptureFlushesLastPacket(t *testing.T) {
+	cmd := "pktmon start -c -m real-time --type all"
+	drop := "[01]0000.0000::2022-06-20 20:35:58.125877300 [Microsoft-Windows-PktMon] Drop: PktGroupId 1125899906842628, PktNumber 1, Appearance 0, Direction Rx , Type Ethernet , Component 14, Filter 0, DropReason Filtered VLAN , DropLocation 0xE0004A44 , OriginalSize 60, LoggedSize 60\r\n"
+
+	dir := t.TempDir()
+	for name, out := range map[string]string{cmd: drop, "pktmon stop": readFixture(t, "pktmon stop")} {
+		if err := os.WriteFile(filepath.Join(dir, runner.FixtureName(name)), []byte(out), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := runner.NewFake(dir)
+	r.Exits[cmd] = nil
+
+	flushLis := bufconn.Listen(bufSize)
+	s := grpc.NewServer()
+	pb.RegisterCaptureServiceServer(s, NewCaptureServer(r, nil))
+	go s.Serve(flushLis)
+	defer s.Stop()
+
+	ctx := context.Background()
+	dialer := func(context.Context, string) (net.Conn, error) { return flushLis.Dial() }
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	stream, err := pb.NewCaptureServiceClient(conn).StartCapture(ctx, &pb.CaptureRequest{Duration: 30})
+	if err != nil {
+		t.Fatalf("StartCapture failed: %v", err)
+	}
+
+	packets := []*pb.Packet{}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("error while reading stream: %v", err)
+		}
+
+		if res.GetPacket() != nil {
+			packets = append(packets, res.GetPacket())
+		}
+	}
+
+	if len(packets) != 1 || packets[0].GetDropReason() != "Filtered VLAN" {
+		t.Fatalf("expected the drop to be sent once pktmon's output ended, got: %v", packets)
+	}
+}
+
 func TestStopCapture(t *testing.T) {
 	ctx := context.Background()
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
@@ -48,7 +257,7 @@ func TestStopCapture(t *testing.T) {
 	defer conn.Close()
 
 	client := pb.NewCaptureServiceClient(conn)
-	res, err := client.StopCapture(ctx, &pb.Empty{})
+	res, err := client.StopCapture(ctx, &pb.StopCaptureRequest{})
 
 	if err != nil {
 		t.Fatalf("StopCapture failed: %v", err)
@@ -63,9 +272,313 @@ func TestStopCapture(t *testing.T) {
 	}
 }
 
+func TestCaptureSessions(t *testing.T) {
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewCaptureServiceClient(conn)
+	stream, err := client.StartCapture(ctx, &pb.CaptureRequest{Duration: 30})
+	if err != nil {
+		t.Fatalf("StartCapture failed: %v", err)
+	}
+
+	header, err := stream.Header()
+	if err != nil {
+		t.Fatalf("failed to read StartCapture header: %v", err)
+	}
+
+	ids := header.Get(common.SessionIDHeader)
+	if len(ids) != 1 || ids[0] == "" {
+		t.Fatalf("expected a session ID in the StartCapture header, got: %v", header)
+	}
+	id := ids[0]
+
+	// The running session is listed
+	list, err := client.ListCaptures(ctx, &pb.Empty{})
+	if err != nil {
+		t.Fatalf("ListCaptures failed: %v", err)
+	}
+
+	if len(list.GetSessions()) != 1 || list.GetSessions()[0].GetSessionId() != id {
+		t.Fatalf("expected session %s to be listed, got: %v", id, list.GetSessions())
+	}
+
+	// A second capture is refused instead of stomping on the first
+	second, err := client.StartCapture(ctx, &pb.CaptureRequest{Duration: 1})
+	if err != nil {
+		t.Fatalf("StartCapture failed: %v", err)
+	}
+
+	if _, err := second.Recv(); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected second capture to fail with FailedPrecondition, got: %v", err)
+	}
+
+	// Stopping an unknown session doesn't touch the running one
+	if _, err := client.StopCapture(ctx, &pb.StopCaptureRequest{SessionId: "unknown"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected stopping an unknown session to fail with NotFound, got: %v", err)
+	}
+
+	if _, err := client.StopCapture(ctx, &pb.StopCaptureRequest{SessionId: id}); err != nil {
+		t.Fatalf("StopCapture failed: %v", err)
+	}
+
+	// The stream ends well before its 30 second duration
+	done := make(chan error)
+	go func() {
+		for {
+			if _, err := stream.Recv(); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	select {
+	case err := <-done:
+		if err != io.EOF {
+			t.Fatalf("expected stream to end cleanly, got: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("capture session %s was not stopped", id)
+	}
+
+	// Once the stream has finished, the session is no longer listed
+	list, err = client.ListCaptures(ctx, &pb.Empty{})
+	if err != nil {
+		t.Fatalf("ListCaptures failed: %v", err)
+	}
+
+	if len(list.GetSessions()) != 0 {
+		t.Fatalf("expected no sessions to be listed, got: %v", list.GetSessions())
+	}
+}
+
+func TestGetCounters(t *testing.T) {
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewCaptureServiceClient(conn)
+	res, err := client.GetCounters(ctx, &pb.CountersRequest{})
+	if err != nil {
+		t.Fatalf("GetCounters failed: %v", err)
+	}
+
+	if res.GetResult() != readFixture(t, "pktmon counter") {
+		t.Fatalf("expected raw counters: \n%s\n got: \n%s\n", readFixture(t, "pktmon counter"), res.GetResult())
+	}
+
+	expected := &pb.ComponentCounters{
+		ComponentId: 14,
+		Name:        "Container NIC 3f2a1b0c",
+		Mac:         "00-15-5D-70-41-2C",
+		PacketsIn:   2105,
+		BytesIn:     1939494,
+		PacketsOut:  1616,
+		BytesOut:    183622,
+		Drops:       []*pb.DropCounter{{Reason: "Filtered VLAN", Location: "0xE0004A44", Packets: 3}},
+	}
+
+	rows := res.GetCounters()
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 component rows, got %d: %v", len(rows), rows)
+	}
+
+	if !proto.Equal(rows[3], expected) {
+		t.Fatalf("expected: \n%v\n got: \n%v\n", expected, rows[3])
+	}
+}
+
+func TestGetVFPCounters(t *testing.T) {
+	podGUID := "8A6F4C5E-2D13-4B07-9E1F-5C3A7B9D0E21"
+	hostGUID := "B1D3E8F0-6A2C-4E57-8D09-3F4B5C6A7D8E"
+	extGUID := "C4E7F1A2-9B3D-4C68-A0E1-7D2F3B4C5D6E"
+	counterCmd := func(guid string) string { return "vfpctrl /port " + guid + " /get-port-counter" }
+
+	cases := []struct {
+		desc     string
+		in       *pb.VFPCountersRequest
+		expected []string
+		ports    []string
+	}{
+		{
+			"TestPodPort",
+			&pb.VFPCountersRequest{Pod: "10.240.0.35"},
+			nil,
+			[]string{podGUID},
+		},
+		{
+			"TestPodPortRaw",
+			&pb.VFPCountersRequest{Pod: "10.240.0.35", IncludeRaw: true},
+			[]string{"Pod Port VFP Counters (ID: " + podGUID + ")", readFixture(t, counterCmd(podGUID))},
+			[]string{podGUID},
+		},
+		{
+			"TestPodPortVerbose",
+			&pb.VFPCountersRequest{Pod: "10.240.0.35", Verbose: true, IncludeRaw: true},
+			[]string{
+				"Pod Port VFP Counters (ID: " + podGUID + ")", readFixture(t, counterCmd(podGUID)),
+				"Host vNic VFP Counters (ID: " + hostGUID + ")", readFixture(t, counterCmd(hostGUID)),
+				"External Adapter VFP Counters (ID: " + extGUID + ")", readFixture(t, counterCmd(extGUID)),
+			},
+			[]string{podGUID, hostGUID, extGUID},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := context.Background()
+			conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+
+			if err != nil {
+				t.Fatalf("Failed to dial bufnet: %v", err)
+			}
+			defer conn.Close()
+
+			client := pb.NewCaptureServiceClient(conn)
+			res, err := client.GetVFPCounters(ctx, tc.in)
+
+			if err != nil {
+				t.Fatalf("GetVFPCounters failed: %v", err)
+			}
+
+			if tc.expected == nil && res.Result != nil {
+				t.Fatalf("expected no raw counters, got: \n%s\n", res.GetResult())
+			}
+
+			actual := res.GetResult()
+			for _, expected := range tc.expected {
+				if !strings.Contains(actual, expected) {
+					t.Fatalf("expected response to contain: \n%s\n got: \n%s\n", expected, actual)
+				}
+			}
+
+			ports := res.GetPorts()
+			if len(ports) != len(tc.ports) {
+				t.Fatalf("expected counters for %d ports, got %d", len(tc.ports), len(ports))
+			}
+
+			for i, port := range ports {
+				if port.GetPortGuid() != tc.ports[i] || port.GetPortType() != pb.VFPPortType(i) {
+					t.Fatalf("expected port %s of type %s, got %s of type %s", tc.ports[i], pb.VFPPortType(i), port.GetPortGuid(), port.GetPortType())
+				}
+
+				if len(port.GetDirections()) != 2 {
+					t.Fatalf("expected counters for 2 directions on port %s, got %d", port.GetPortGuid(), len(port.GetDirections()))
+				}
+			}
+		})
+	}
+}
+
+func TestGetVFPRules(t *testing.T) {
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewCaptureServiceClient(conn)
+
+	// Flattens the rule tree into layer/group/rule paths
+	paths := func(layers []*pb.VFPLayer) []string {
+		ret := []string{}
+		for _, layer := range layers {
+			for _, group := range layer.GetGroups() {
+				for _, rule := range group.GetRules() {
+					ret = append(ret, layer.GetName()+"/"+group.GetName()+"/"+rule.GetName())
+				}
+			}
+		}
+		return ret
+	}
+
+	cases := []struct {
+		desc     string
+		in       *pb.VFPRulesRequest
+		expected []string
+	}{
+		{
+			"TestAllLayers",
+			&pb.VFPRulesRequest{Pod: "10.240.0.35"},
+			[]string{
+				"SLB_NAT_LAYER/SLB_GROUP_NAT_IPv4_OUT/LB_NAT_RULE_10.0.12.7_80_TCP",
+				"ACL_ENDPOINT_LAYER/ACL_ENDPOINT_GROUP_IPv4_IN/Block_10.240.0.99_In",
+				"ACL_ENDPOINT_LAYER/ACL_ENDPOINT_GROUP_IPv4_IN/AllowAll_In",
+				"ACL_ENDPOINT_LAYER/ACL_ENDPOINT_GROUP_IPv4_OUT/AllowAll_Out",
+			},
+		},
+		{
+			"TestLayerFilter",
+			&pb.VFPRulesRequest{Pod: "10.240.0.35", Layers: []string{"slb_nat_layer"}},
+			[]string{"SLB_NAT_LAYER/SLB_GROUP_NAT_IPv4_OUT/LB_NAT_RULE_10.0.12.7_80_TCP"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := client.GetVFPRules(ctx, tc.in)
+			if err != nil {
+				t.Fatalf("GetVFPRules failed: %v", err)
+			}
+
+			actual := paths(res.GetLayers())
+			if strings.Join(actual, "\n") != strings.Join(tc.expected, "\n") {
+				t.Fatalf("expected: \n%v\n got: \n%v\n", tc.expected, actual)
+			}
+		})
+	}
+
+	// Check a rule's details are parsed
+	res, err := client.GetVFPRules(ctx, &pb.VFPRulesRequest{Pod: "10.240.0.35", Layers: []string{"SLB_NAT_LAYER"}})
+	if err != nil {
+		t.Fatalf("GetVFPRules failed: %v", err)
+	}
+
+	expected := &pb.VFPRule{
+		Name:     "LB_NAT_RULE_10.0.12.7_80_TCP",
+		Priority: 100,
+		Action:   "transposition",
+		Flags:    "1 terminating",
+		Conditions: []*pb.VFPCondition{
+			{Field: "Protocols", Value: "6"},
+			{Field: "Destination IP", Value: "10.0.12.7"},
+			{Field: "Destination ports", Value: "80"},
+		},
+		Properties: map[string]string{
+			"Flow TTL":                       "240",
+			"FlagsEx":                        "0",
+			"Transposition Destination IP":   "10.240.0.35",
+			"Transposition Destination port": "8080",
+		},
+	}
+
+	rule := res.GetLayers()[0].GetGroups()[0].GetRules()[0]
+	if !proto.Equal(rule, expected) {
+		t.Fatalf("expected: \n%v\n got: \n%v\n", expected, rule)
+	}
+
+	// Unknown layers are reported rather than silently returning nothing
+	_, err = client.GetVFPRules(ctx, &pb.VFPRulesRequest{Pod: "10.240.0.35", Layers: []string{"VNET_LAYER"}})
+	if err == nil || !strings.Contains(err.Error(), "ACL_ENDPOINT_LAYER") {
+		t.Fatalf("expected an error listing the available layers, got: %v", err)
+	}
+}
+
 func TestGetHCNLogs(t *testing.T) {
 	getLogString := func(option string, verbose bool) string {
-		res, _ := netutil.GetLogs(option, verbose)
+		res, _ := netutil.GetLogs(fake, option, verbose)
 		return string(res)
 	}
 
@@ -106,3 +619,499 @@ func TestGetHCNLogs(t *testing.T) {
 		})
 	}
 }
+
+// pcapRunner writes a pcapng file whenever pktmon is asked to convert an ETL file, since the fake can't
+type pcapRunner struct {
+	*runner.Fake
+	pcap       []byte
+	convertErr error // If set, converting fails once part of the pcapng file is written
+	startErr   error // If set, pktmon fails to start logging to a file
+}
+
+func (r *pcapRunner) Run(cmd string) error {
+	fields := strings.Fields(cmd)
+	switch {
+	case strings.HasPrefix(cmd, "pktmon etl2pcap"):
+		if r.convertErr != nil {
+			os.WriteFile(fields[len(fields)-1], r.pcap[:len(r.pcap)/2], 0644)
+			return r.convertErr
+		}
+		return os.WriteFile(fields[len(fields)-1], r.pcap, 0644)
+	case strings.HasPrefix(cmd, "pktmon start") && strings.Contains(cmd, " -f "):
+		if r.startErr != nil {
+			r.Fake.Run(cmd)
+			return r.startErr
+		}
+
+		for i, field := range fields[:len(fields)-1] {
+			if field == "-f" {
+				os.WriteFile(fields[i+1], []byte("etl"), 0644)
+			}
+		}
+	}
+
+	return r.Fake.Run(cmd)
+}
+
+// Serves captures over a bufconn listener for the duration of the test
+func dialCaptureServer(t *testing.T, captures *CaptureServer) pb.CaptureServiceClient {
+	l := bufconn.Listen(bufSize)
+	s := grpc.NewServer()
+	pb.RegisterCaptureServiceServer(s, captures)
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+
+	dialer := func(context.Context, string) (net.Conn, error) { return l.Dial() }
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewCaptureServiceClient(conn)
+}
+
+// Waits for the artifacts of every capture logged to files to be complete, returning them
+func waitForArtifacts(t *testing.T, client pb.CaptureServiceClient) []*pb.Artifact {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		list, err := client.ListArtifacts(context.Background(), &pb.Empty{})
+		if err != nil {
+			t.Fatalf("ListArtifacts failed: %v", err)
+		}
+
+		complete := len(list.GetArtifacts()) > 0
+		for _, artifact := range list.GetArtifacts() {
+			complete = complete && artifact.GetComplete()
+		}
+
+		if complete {
+			return list.GetArtifacts()
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("capture didn't complete, artifacts: %v", list.GetArtifacts())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestCapturePcap(t *testing.T) {
+	expected := bytes.Repeat([]byte("\x0a\x0d\x0d\x0a"), pcapChunkSize/2)
+	r := &pcapRunner{Fake: runner.NewFake(fixtureDir), pcap: expected}
+
+	pcapLis := bufconn.Listen(bufSize)
+	s := grpc.NewServer()
+	pb.RegisterCaptureServiceServer(s, NewCaptureServer(r, nil))
+	go s.Serve(pcapLis)
+	defer s.Stop()
+
+	ctx := context.Background()
+	dialer := func(context.Context, string) (net.Conn, error) { return pcapLis.Dial() }
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewCaptureServiceClient(conn)
+	stream, err := client.CapturePcap(ctx, &pb.CaptureRequest{Duration: 1})
+
+	if err != nil {
+		t.Fatalf("CapturePcap failed: %v", err)
+	}
+
+	var actual []byte
+	chunks := 0
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("error while reading stream: %v", err)
+		}
+
+		actual = append(actual, res.GetResult()...)
+		chunks++
+	}
+
+	if !bytes.Equal(actual, expected) {
+		t.Fatalf("expected %d bytes of pcapng, got %d", len(expected), len(actual))
+	}
+
+	if chunks != 2 {
+		t.Fatalf("expected pcapng to be streamed in 2 chunks, got %d", chunks)
+	}
+
+	calls := r.Calls()
+	if !strings.HasPrefix(calls[len(calls)-1], "pktmon filter remove") {
+		t.Fatalf("expected filters to be reset after the capture, got calls: %v", calls)
+	}
+}
+
+func TestFileCapture(t *testing.T) {
+	expected := bytes.Repeat([]byte("\x0a\x0d\x0d\x0a"), pcapChunkSize/2)
+	r := &pcapRunner{Fake: runner.NewFake(fixtureDir), pcap: expected}
+
+	artifacts, err := NewArtifactStore(t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatalf("NewArtifactStore failed: %v", err)
+	}
+
+	ctx := context.Background()
+	client := dialCaptureServer(t, NewCaptureServer(r, artifacts))
+	stream, err := client.StartCapture(ctx, &pb.CaptureRequest{Duration: 1, Artifact: pb.ArtifactFormat_pcapng})
+	if err != nil {
+		t.Fatalf("StartCapture failed: %v", err)
+	}
+
+	// The capture returns as soon as pktmon is logging
+	res, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("error while reading stream: %v", err)
+	}
+
+	name := res.GetArtifact()
+	if !strings.HasPrefix(name, artifactPrefix+res.GetSessionId()+"-") || !strings.HasSuffix(name, ".pcapng") {
+		t.Fatalf("unexpected artifact name '%s' for session %s", name, res.GetSessionId())
+	}
+
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("expected the stream to end once the capture started, got: %v", err)
+	}
+
+	download := func(name string) ([]byte, error) {
+		stream, err := client.DownloadArtifact(ctx, &pb.DownloadArtifactRequest{Name: name})
+		if err != nil {
+			return nil, err
+		}
+
+		var ret []byte
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				return ret, nil
+			}
+
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, chunk.GetData()...)
+		}
+	}
+
+	if _, err := download(name); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected: %v while the capture runs, got: %v", codes.FailedPrecondition, err)
+	}
+
+	// Wait for the capture to be converted to pcapng
+	list := waitForArtifacts(t, client)
+	if actual := list[0]; len(list) != 1 || actual.GetName() != name || actual.GetSessionId() != res.GetSessionId() || actual.GetSize() != uint64(len(expected)) || actual.GetError() != "" {
+		t.Fatalf("unexpected artifacts: %v", list)
+	}
+
+	actual, err := download(name)
+	if err != nil || !bytes.Equal(actual, expected) {
+		t.Fatalf("expected %d bytes of pcapng, got %d (%v)", len(expected), len(actual), err)
+	}
+
+	if _, err := download("../" + name); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected: %v for a path, got: %v", codes.InvalidArgument, err)
+	}
+
+	if _, err := download(artifactPrefix + "missing.etl"); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected: %v, got: %v", codes.NotFound, err)
+	}
+}
+
+func TestFileCaptureConversionFailure(t *testing.T) {
+	r := &pcapRunner{Fake: runner.NewFake(fixtureDir), pcap: []byte("pcapng"), convertErr: errors.New("etl2pcap failed")}
+
+	dir := t.TempDir()
+	artifacts, err := NewArtifactStore(dir, 0, 0)
+	if err != nil {
+		t.Fatalf("NewArtifactStore failed: %v", err)
+	}
+
+	captures := NewCaptureServer(r, artifacts)
+	client := dialCaptureServer(t, captures)
+	stream, err := client.StartCapture(context.Background(), &pb.CaptureRequest{Duration: 1, Artifact: pb.ArtifactFormat_pcapng})
+	if err != nil {
+		t.Fatalf("StartCapture failed: %v", err)
+	}
+
+	res, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("error while reading stream: %v", err)
+	}
+
+	// The ETL file is kept instead of the partial pcapng file, along with why
+	list := waitForArtifacts(t, client)
+	expected := strings.TrimSuffix(res.GetArtifact(), ".pcapng") + ".etl"
+	if actual := list[0]; len(list) != 1 || actual.GetName() != expected || !strings.Contains(actual.GetError(), "etl2pcap failed") {
+		t.Fatalf("expected only %s to be kept with the conversion's error, got: %v", expected, list)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, res.GetArtifact())); !os.IsNotExist(err) {
+		t.Fatalf("expected the partial pcapng file to be deleted, got: %v", err)
+	}
+}
+
+func TestFileCaptureStartFailure(t *testing.T) {
+	r := &pcapRunner{Fake: runner.NewFake(fixtureDir), startErr: errors.New("pktmon failed")}
+
+	artifacts, err := NewArtifactStore(t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatalf("NewArtifactStore failed: %v", err)
+	}
+
+	client := dialCaptureServer(t, NewCaptureServer(r, artifacts))
+	stream, err := client.StartCapture(context.Background(), &pb.CaptureRequest{Duration: 1, Artifact: pb.ArtifactFormat_etl})
+	if err != nil {
+		t.Fatalf("StartCapture failed: %v", err)
+	}
+
+	if _, err := stream.Recv(); err == nil || !strings.Contains(err.Error(), "pktmon failed") {
+		t.Fatalf("expected the capture to fail, got: %v", err)
+	}
+
+	calls := r.Calls()
+	if !strings.HasPrefix(calls[len(calls)-1], "pktmon filter remove") {
+		t.Fatalf("expected filters to be reset after pktmon failed to start, got calls: %v", calls)
+	}
+
+	list, err := client.ListArtifacts(context.Background(), &pb.Empty{})
+	if err != nil || len(list.GetArtifacts()) != 0 {
+		t.Fatalf("expected no artifacts, got: %v (%v)", list.GetArtifacts(), err)
+	}
+}
+
+func TestArtifactRetention(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, size int, age time.Duration) {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, make([]byte, size), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		modified := time.Now().Add(-age)
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(artifactPrefix+"expired-1.etl", 10, 3*time.Hour)
+	write(artifactPrefix+"old-1.pcapng", 100, 2*time.Hour)
+	write(artifactPrefix+"new-1.pcapng", 100, time.Hour)
+	write(artifactPrefix+"newest-1.etl", 100, 0)
+	write("kubelet.log", 1000, 4*time.Hour)
+
+	artifacts, err := NewArtifactStore(dir, 250, 150*time.Minute)
+	if err != nil {
+		t.Fatalf("NewArtifactStore failed: %v", err)
+	}
+
+	list, err := artifacts.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	actual := []string{}
+	for _, artifact := range list {
+		actual = append(actual, artifact.GetSessionId())
+	}
+
+	if expected := []string{"new", "newest"}; strings.Join(actual, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected artifacts: %v, got: %v", expected, actual)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "kubelet.log")); err != nil {
+		t.Fatalf("expected files other than artifacts to be kept: %v", err)
+	}
+}
+
+func TestArtifactReserve(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, size int, age time.Duration) {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, make([]byte, size), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		modified := time.Now().Add(-age)
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(artifactPrefix+"old-1.pcapng", 3<<20, 2*time.Hour)
+	write(artifactPrefix+"new-1.pcapng", 3<<20, time.Hour)
+
+	artifacts, err := NewArtifactStore(dir, 8<<20, 0)
+	if err != nil {
+		t.Fatalf("NewArtifactStore failed: %v", err)
+	}
+
+	// The old artifact is deleted so that half of the maximum is free, and the pcapng capture gets half of what's free
+	maxBytes, err := artifacts.reserve(pb.ArtifactFormat_pcapng)
+	if err != nil || maxBytes != 5<<19 {
+		t.Fatalf("expected %d bytes for the capture, got %d (%v)", 5<<19, maxBytes, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, artifactPrefix+"old-1.pcapng")); !os.IsNotExist(err) {
+		t.Fatalf("expected the oldest artifact to be deleted, got: %v", err)
+	}
+
+	if maxBytes, err := artifacts.reserve(pb.ArtifactFormat_etl); err != nil || maxBytes != 5<<20 {
+		t.Fatalf("expected %d bytes for the capture, got %d (%v)", 5<<20, maxBytes, err)
+	}
+
+	small, err := NewArtifactStore(t.TempDir(), 1<<20, 0)
+	if err != nil {
+		t.Fatalf("NewArtifactStore failed: %v", err)
+	}
+
+	if _, err := small.reserve(pb.ArtifactFormat_pcapng); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected: %v, got: %v", codes.ResourceExhausted, err)
+	}
+}
+
+func TestArtifactRun(t *testing.T) {
+	dir := t.TempDir()
+	artifacts, err := NewArtifactStore(dir, 0, time.Hour)
+	if err != nil {
+		t.Fatalf("NewArtifactStore failed: %v", err)
+	}
+
+	// Artifacts expiring after the store is created are deleted without a capture starting
+	path := filepath.Join(dir, artifactPrefix+"expired-1.etl")
+	if err := os.WriteFile(path, []byte("etl"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	modified := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go artifacts.Run(ctx, 10*time.Millisecond)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %s to be deleted", path)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	r := runner.NewFake(fixtureDir)
+	captures := NewCaptureServer(r, nil)
+
+	shutdownLis := bufconn.Listen(bufSize)
+	s := grpc.NewServer()
+	pb.RegisterCaptureServiceServer(s, captures)
+	go s.Serve(shutdownLis)
+	defer s.Stop()
+
+	ctx := context.Background()
+	dialer := func(context.Context, string) (net.Conn, error) { return shutdownLis.Dial() }
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewCaptureServiceClient(conn)
+	stream, err := client.StartCapture(ctx, &pb.CaptureRequest{Duration: 30})
+	if err != nil {
+		t.Fatalf("StartCapture failed: %v", err)
+	}
+
+	if _, err := stream.Header(); err != nil {
+		t.Fatalf("failed to read StartCapture header: %v", err)
+	}
+
+	done := make(chan error)
+	go func() {
+		for {
+			if _, err := stream.Recv(); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := captures.Shutdown(shutdownCtx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	// The running capture has ended by the time Shutdown returns
+	select {
+	case err := <-done:
+		if err != io.EOF {
+			t.Fatalf("expected stream to end cleanly, got: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("capture wasn't stopped by Shutdown")
+	}
+
+	// pktmon is left stopped, without filters
+	calls := r.Calls()
+	if expected := []string{"pktmon stop", "pktmon filter remove"}; strings.Join(calls[len(calls)-2:], ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected Shutdown to end with %v, got: %v", expected, calls)
+	}
+
+	// New captures are refused
+	second, err := client.StartCapture(ctx, &pb.CaptureRequest{Duration: 1})
+	if err != nil {
+		t.Fatalf("StartCapture failed: %v", err)
+	}
+
+	if _, err := second.Recv(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected: %v once shut down, got: %v", codes.Unavailable, err)
+	}
+}
+
+func TestNewHNSBackend(t *testing.T) {
+	cases := []struct {
+		desc     string
+		name     string
+		expected HNSBackend
+	}{
+		{"TestHCN", "hcn", HCNBackend{}},
+		{"TestHnsdiag", "hnsdiag", HnsdiagBackend{Runner: fake}},
+		{"TestUnknown", "hnscmd", nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actual, err := NewHNSBackend(tc.name, fake)
+
+			if tc.expected == nil {
+				if err == nil {
+					t.Fatalf("expected an error for backend '%s'", tc.name)
+				}
+				return
+			}
+
+			if err != nil || actual != tc.expected {
+				t.Fatalf("expected: %#v, got: %#v (error: %v)", tc.expected, actual, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/microsoft/wcnspect/pkg/runner"
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type captureSession struct {
+	id       string
+	started  time.Time
+	request  *pb.CaptureRequest
+	pcap     bool
+	artifact string // Name of the artifact the capture is logged to, if any
+
+	mu            sync.Mutex
+	monitor       runner.Process     // Tracks the running pktmon stream, if any
+	cancel        context.CancelFunc // Tracks the pktmon stream's context's cancel func
+	printCounters bool
+	stopped       bool
+}
+
+// sessionManager tracks the capture sessions running on the node.
+// Since there is only a single pktmon instance per node, it only allows one session at a time.
+type sessionManager struct {
+	mu       sync.Mutex
+	sessions map[string]*captureSession
+	closed   bool     // Set once the server shuts down, refusing new sessions
+	journal  *Journal // Persists the running sessions, if set
+}
+
+func newSessionManager() *sessionManager {
+	return &sessionManager{sessions: map[string]*captureSession{}}
+}
+
+// Registers a new session, failing with FailedPrecondition if pktmon is already in use by another session.
+func (m *sessionManager) begin(req *pb.CaptureRequest, pcap bool) (*captureSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, status.Error(codes.Unavailable, "server is shutting down")
+	}
+
+	for _, active := range m.sessions {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"pktmon is busy with capture session %s (started at %s); stop it or wait for it to finish",
+			active.id, active.started.Format(time.RFC3339))
+	}
+
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &captureSession{
+		id:            id,
+		started:       time.Now(),
+		request:       req,
+		pcap:          pcap,
+		printCounters: req.GetModifier().GetCountersOnly() && !pcap,
+	}
+	m.sessions[id] = session
+	m.persist()
+
+	return session, nil
+}
+
+func (m *sessionManager) end(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, id)
+	m.persist()
+}
+
+// Records the artifact a session logs to, so that it's journaled along with the session
+func (m *sessionManager) setArtifact(session *captureSession, artifact string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session.artifact = artifact
+	m.persist()
+}
+
+func (m *sessionManager) setJournal(journal *Journal) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.journal = journal
+	m.persist()
+}
+
+// Journals the running sessions. Must be called with m.mu held, after any change to the sessions.
+func (m *sessionManager) persist() {
+	if m.journal == nil {
+		return
+	}
+
+	sessions := []*pb.CaptureSession{}
+	for _, session := range m.sessions {
+		sessions = append(sessions, session.toProto())
+	}
+
+	// Failing to journal only matters if the server dies mid-capture, so captures carry on
+	if err := m.journal.save(sessions); err != nil {
+		log.Printf("Failed to journal capture sessions: %v", err)
+	}
+}
+
+// Returns the session with the given ID. An empty ID returns the running session, if there is one.
+func (m *sessionManager) get(id string) (*captureSession, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if id != "" {
+		session, ok := m.sessions[id]
+		return session, ok
+	}
+
+	for _, session := range m.sessions {
+		return session, true
+	}
+
+	return nil, false
+}
+
+func (m *sessionManager) list() []*captureSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ret := []*captureSession{}
+	for _, session := range m.sessions {
+		ret = append(ret, session)
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i].started.Before(ret[j].started) })
+
+	return ret
+}
+
+// Refuses new sessions from now on, returning the sessions still running
+func (m *sessionManager) close() []*captureSession {
+	m.mu.Lock()
+	m.closed = true
+	m.mu.Unlock()
+
+	return m.list()
+}
+
+// Waits for every session to end, or for ctx to be done
+func (m *sessionManager) wait(ctx context.Context) error {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for len(m.list()) > 0 {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("capture sessions didn't finish: %v", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+
+	return nil
+}
+
+func (s *captureSession) setMonitor(monitor runner.Process, cancel context.CancelFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.monitor = monitor
+	s.cancel = cancel
+
+	// The session may have been stopped before pktmon was started
+	if s.stopped {
+		s.kill()
+	}
+}
+
+// Returns whether the session's counters still need to be printed, and marks them as printed
+func (s *captureSession) takeCounters() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	printCounters := s.printCounters
+	s.printCounters = false
+
+	return printCounters
+}
+
+// Stops the session's capture, returning whether its counters need to be printed
+func (s *captureSession) stop() bool {
+	printCounters := s.takeCounters()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopped = true
+	s.kill()
+
+	return printCounters
+}
+
+func (s *captureSession) kill() {
+	// The stream's context is cancelled first, so that pktmon being killed isn't taken for it failing
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	// Captures logging to a file don't have a running pktmon stream to kill
+	if s.monitor != nil {
+		s.monitor.Kill()
+	}
+}
+
+func (s *captureSession) toProto() *pb.CaptureSession {
+	return &pb.CaptureSession{
+		SessionId: s.id,
+		Started:   timestamppb.New(s.started),
+		Request:   s.request,
+		Pcap:      s.pcap,
+		Artifact:  s.artifact,
+	}
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,204 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// KeyPair is a certificate and key loaded from disk, which are reloaded whenever either file changes.
+type KeyPair struct {
+	certFile string
+	keyFile  string
+
+	mu       sync.Mutex
+	cert     *tls.Certificate
+	modTimes [2]time.Time
+}
+
+// CertPool is a pool of CA certificates loaded from a PEM file, which is reloaded whenever the file changes.
+type CertPool struct {
+	file string
+
+	mu      sync.Mutex
+	pool    *x509.CertPool
+	modTime time.Time
+}
+
+func NewKeyPair(certFile string, keyFile string) (*KeyPair, error) {
+	k := &KeyPair{certFile: certFile, keyFile: keyFile}
+	if _, err := k.Certificate(); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// Returns the current certificate, reloading it if the files were modified since they were last read.
+// If reloading fails, for instance because only one of the files has been replaced so far, the previous certificate is kept.
+func (k *KeyPair) Certificate() (*tls.Certificate, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	modTimes, err := modTimes(k.certFile, k.keyFile)
+	if err == nil && k.cert != nil && modTimes == k.modTimes {
+		return k.cert, nil
+	}
+
+	if err == nil {
+		var cert tls.Certificate
+		if cert, err = tls.LoadX509KeyPair(k.certFile, k.keyFile); err == nil {
+			k.cert, k.modTimes = &cert, modTimes
+			return k.cert, nil
+		}
+	}
+
+	if k.cert == nil {
+		return nil, fmt.Errorf("failed to load certificate %s and key %s: %v", k.certFile, k.keyFile, err)
+	}
+
+	log.Printf("Failed to reload certificate %s and key %s, keeping the previous one: %v\n", k.certFile, k.keyFile, err)
+	return k.cert, nil
+}
+
+func NewCertPool(file string) (*CertPool, error) {
+	p := &CertPool{file: file}
+	if _, err := p.Pool(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Returns the current pool, reloading it if the file was modified since it was last read.
+func (p *CertPool) Pool() (*x509.CertPool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	modTimes, err := modTimes(p.file)
+	if err == nil && p.pool != nil && modTimes[0] == p.modTime {
+		return p.pool, nil
+	}
+
+	if err == nil {
+		var pem []byte
+		if pem, err = os.ReadFile(p.file); err == nil {
+			pool := x509.NewCertPool()
+			if pool.AppendCertsFromPEM(pem) {
+				p.pool, p.modTime = pool, modTimes[0]
+				return p.pool, nil
+			}
+
+			err = errors.New("no certificates found")
+		}
+	}
+
+	if p.pool == nil {
+		return nil, fmt.Errorf("failed to load CA certificates from %s: %v", p.file, err)
+	}
+
+	log.Printf("Failed to reload CA certificates from %s, keeping the previous ones: %v\n", p.file, err)
+	return p.pool, nil
+}
+
+// Returns the TLS config for wcnspectserv, serving the certificate in certFile and keyFile.
+// If clientCAFile is set, clients must present a certificate signed by one of its CAs (mutual TLS).
+// Every file is reloaded when it changes, so certificates can be rotated without restarting the server.
+func ServerConfig(certFile string, keyFile string, clientCAFile string) (*tls.Config, error) {
+	keyPair, err := NewKeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var clientCAs *CertPool
+	if clientCAFile != "" {
+		if clientCAs, err = NewCertPool(clientCAFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Build the config for each handshake, so it picks up any reloaded files
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, err := keyPair.Certificate()
+			if err != nil {
+				return nil, err
+			}
+
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+			}
+
+			if clientCAs != nil {
+				if config.ClientCAs, err = clientCAs.Pool(); err != nil {
+					return nil, err
+				}
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+
+			return config, nil
+		},
+	}, nil
+}
+
+// Returns the TLS config for the wcnspect client.
+// The server's certificate is verified against the CAs in caFile, or the system's CAs if caFile is empty.
+// If serverName is set, it's expected in the server's certificate instead of the node's IP.
+// If certFile and keyFile are set, the certificate is presented to servers requiring mutual TLS.
+func ClientConfig(caFile string, certFile string, keyFile string, serverName string) (*tls.Config, error) {
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("a client certificate and key must be passed together")
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+
+	if caFile != "" {
+		rootCAs, err := NewCertPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if config.RootCAs, err = rootCAs.Pool(); err != nil {
+			return nil, err
+		}
+	}
+
+	if certFile != "" {
+		keyPair, err := NewKeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return keyPair.Certificate()
+		}
+	}
+
+	return config, nil
+}
+
+func modTimes(files ...string) (ret [2]time.Time, err error) {
+	for i, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return ret, err
+		}
+
+		ret[i] = info.ModTime()
+	}
+
+	return ret, nil
+}
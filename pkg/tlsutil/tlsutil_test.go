@@ -0,0 +1,212 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// Issues a certificate for name, self-signed if parent is nil
+func issue(t *testing.T, name string, serial int64, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer := &testCert{cert: template, key: key}
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+	} else {
+		signer = parent
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer.cert, &key.PublicKey, signer.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCert{cert: cert, key: key}
+}
+
+// Writes the certificate and key as PEM files, returning their paths
+func (c *testCert) write(t *testing.T, dir string, name string) (string, string) {
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+// Runs a TLS handshake between both configs, returning the serial number of the server's certificate
+func handshake(t *testing.T, server *tls.Config, client *tls.Config) (int64, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	errs := make(chan error, 1)
+	go func() {
+		raw, err := listener.Accept()
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		conn := tls.Server(raw, server)
+		errs <- conn.Handshake()
+		conn.Close()
+	}()
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), client)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	// Client certificates are verified after the client has finished its side of the handshake
+	if err := <-errs; err != nil {
+		return 0, err
+	}
+
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64(), nil
+}
+
+func TestTLS(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := issue(t, "wcnspect-ca", 1, nil)
+	caFile, _ := ca.write(t, dir, "ca")
+
+	serverCert, serverKey := issue(t, "wcnspectserv", 2, ca).write(t, dir, "server")
+	clientCert, clientKey := issue(t, "wcnspect", 3, ca).write(t, dir, "client")
+
+	cases := []struct {
+		desc      string
+		clientCA  string
+		cert      string
+		key       string
+		expectErr bool
+	}{
+		{"TestTLS", "", "", "", false},
+		{"TestMutualTLS", caFile, clientCert, clientKey, false},
+		{"TestMutualTLSWithoutClientCert", caFile, "", "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			server, err := ServerConfig(serverCert, serverKey, tc.clientCA)
+			if err != nil {
+				t.Fatalf("ServerConfig failed: %v", err)
+			}
+
+			client, err := ClientConfig(caFile, tc.cert, tc.key, "wcnspectserv")
+			if err != nil {
+				t.Fatalf("ClientConfig failed: %v", err)
+			}
+
+			serial, err := handshake(t, server, client)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected the handshake to fail")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("handshake failed: %v", err)
+			}
+
+			if serial != 2 {
+				t.Fatalf("expected server certificate 2, got: %d", serial)
+			}
+		})
+	}
+
+	if _, err := ClientConfig(caFile, clientCert, "", ""); err == nil {
+		t.Fatalf("expected an error for a client certificate without a key")
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := issue(t, "wcnspect-ca", 1, nil)
+	caFile, _ := ca.write(t, dir, "ca")
+	certFile, keyFile := issue(t, "wcnspectserv", 2, ca).write(t, dir, "server")
+
+	server, err := ServerConfig(certFile, keyFile, "")
+	if err != nil {
+		t.Fatalf("ServerConfig failed: %v", err)
+	}
+
+	client, err := ClientConfig(caFile, "", "", "wcnspectserv")
+	if err != nil {
+		t.Fatalf("ClientConfig failed: %v", err)
+	}
+
+	// Replace the certificate, making sure the modification time changes
+	issue(t, "wcnspectserv", 4, ca).write(t, dir, "server")
+	later := time.Now().Add(time.Minute)
+	for _, file := range []string{certFile, keyFile} {
+		if err := os.Chtimes(file, later, later); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if serial, err := handshake(t, server, client); err != nil || serial != 4 {
+		t.Fatalf("expected the reloaded certificate 4, got: %d (%v)", serial, err)
+	}
+
+	// A half-written certificate keeps the previous one
+	if err := os.WriteFile(keyFile, []byte("not a key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if serial, err := handshake(t, server, client); err != nil || serial != 4 {
+		t.Fatalf("expected the previous certificate 4, got: %d (%v)", serial, err)
+	}
+}
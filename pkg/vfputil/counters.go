@@ -0,0 +1,82 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package vfputil
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+// ParseVFPCounters parses the output of 'vfpctrl /get-port-counter' into counters for each direction.
+func ParseVFPCounters(out string) []*pb.VFPDirectionCounters {
+	ret := []*pb.VFPDirectionCounters{}
+	var current *pb.VFPDirectionCounters
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Direction - OUT
+		if dir, found := cutPrefix(line, "Direction - "); found {
+			current = &pb.VFPDirectionCounters{Direction: strings.TrimSpace(dir)}
+			ret = append(ret, current)
+			continue
+		}
+
+		// Packets : 1864
+		key, value, found := strings.Cut(line, ":")
+		if current == nil || !found {
+			continue
+		}
+
+		key = strings.Join(strings.Fields(key), " ")
+		n, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "Packets":
+			current.Packets = n
+		case "Bytes":
+			current.Bytes = n
+		case "Dropped Packets":
+			current.DroppedPackets = n
+		case "Flows Created":
+			current.FlowsCreated = n
+		case "Flows Deleted":
+			current.FlowsDeleted = n
+		case "Active Flows":
+			current.ActiveFlows = n
+		default:
+			// Dropped <Reason> Packets
+			if reason, found := cutPrefix(key, "Dropped "); found && strings.HasSuffix(reason, " Packets") {
+				current.Drops = append(current.Drops, &pb.DropCounter{
+					Reason:  strings.TrimSuffix(reason, " Packets"),
+					Packets: n,
+				})
+			}
+		}
+	}
+
+	for _, dir := range ret {
+		if dir.Packets > dir.DroppedPackets {
+			dir.ForwardedPackets = dir.Packets - dir.DroppedPackets
+		}
+	}
+
+	return ret
+}
+
+// Equivalent to strings.CutPrefix, which isn't available in go1.18
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+
+	return s[len(prefix):], true
+}
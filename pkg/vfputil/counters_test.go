@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package vfputil
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/microsoft/wcnspect/rpc"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseVFPCounters(t *testing.T) {
+	out := strings.Join([]string{
+		"ITEM LIST",
+		"===========",
+		"",
+		"  Direction - OUT",
+		"    Packets                                  : 1864",
+		"    Bytes                                    : 213856",
+		"    Dropped Packets                          : 3",
+		"    Dropped ACL Packets                          : 2",
+		"    Dropped MAC Spoofing Packets                 : 0",
+		"    Flows Created                            : 42",
+		"    Flows Deleted                            : 38",
+		"    Active Flows                             : 4",
+		"",
+		"  Direction - IN",
+		"    Packets                                  : 2107",
+		"    Dropped Packets                          : 5",
+		"    Dropped ACL Packets                          : 5",
+		"",
+		"Command get-port-counter succeeded!",
+	}, "\r\n")
+
+	expected := []*pb.VFPDirectionCounters{
+		{
+			Direction:        "OUT",
+			Packets:          1864,
+			Bytes:            213856,
+			DroppedPackets:   3,
+			ForwardedPackets: 1861,
+			Drops: []*pb.DropCounter{
+				{Reason: "ACL", Packets: 2},
+				{Reason: "MAC Spoofing", Packets: 0},
+			},
+			FlowsCreated: 42,
+			FlowsDeleted: 38,
+			ActiveFlows:  4,
+		},
+		{
+			Direction:        "IN",
+			Packets:          2107,
+			DroppedPackets:   5,
+			ForwardedPackets: 2102,
+			Drops:            []*pb.DropCounter{{Reason: "ACL", Packets: 5}},
+		},
+	}
+
+	actual := ParseVFPCounters(out)
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d directions, got %d: %v", len(expected), len(actual), actual)
+	}
+
+	for i := range actual {
+		if !proto.Equal(actual[i], expected[i]) {
+			t.Fatalf("expected: \n%v\n got: \n%v\n", expected[i], actual[i])
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package vfputil
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/microsoft/wcnspect/pkg/runner"
+	pb "github.com/microsoft/wcnspect/rpc"
+)
+
+// LAYER : ACL_ENDPOINT_LAYER
+var vfpItemRe = regexp.MustCompile(`^\s*(LAYER|GROUP|RULE)\s*:\s*(.+?)\s*$`)
+
+// vfpItem is a layer, group or rule listed by vfpctrl
+type vfpItem struct {
+	name       string
+	properties map[string]string
+	sections   map[string][]*pb.VFPCondition // Indented blocks such as Conditions
+	order      []string                      // Section names in the order they were listed
+}
+
+// GetVFPRuleTree lists the layers of a VFP port along with their groups and rules.
+// If layers is non-empty, only the layers with those names are listed.
+func GetVFPRuleTree(r runner.Runner, portGUID string, layers []string) ([]*pb.VFPLayer, error) {
+	all, err := ListVFPLayers(r, portGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []*pb.VFPLayer{}
+	for _, layer := range all {
+		if len(layers) > 0 && !containsFold(layers, layer.GetName()) {
+			continue
+		}
+
+		groups, err := ListVFPGroups(r, portGUID, layer.GetName())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, group := range groups {
+			if group.Rules, err = ListVFPRules(r, portGUID, layer.GetName(), group.GetName()); err != nil {
+				return nil, err
+			}
+		}
+
+		layer.Groups = groups
+		ret = append(ret, layer)
+	}
+
+	// Asking for a layer the port doesn't have is most likely a typo
+	for _, name := range layers {
+		found := false
+		for _, layer := range ret {
+			found = found || strings.EqualFold(layer.GetName(), name)
+		}
+
+		if !found {
+			names := []string{}
+			for _, layer := range all {
+				names = append(names, layer.GetName())
+			}
+
+			return nil, fmt.Errorf("layer %s not found on port %s, available layers: %s", name, portGUID, strings.Join(names, ", "))
+		}
+	}
+
+	return ret, nil
+}
+
+func ListVFPLayers(r runner.Runner, portGUID string) ([]*pb.VFPLayer, error) {
+	out, err := r.Output(fmt.Sprintf("vfpctrl /port %s /list-layer", portGUID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VFP layers of port %s: %v", portGUID, err)
+	}
+
+	ret := []*pb.VFPLayer{}
+	for _, item := range parseVFPItems(string(out), "LAYER") {
+		ret = append(ret, &pb.VFPLayer{
+			Name:     item.name,
+			Priority: parsePriority(item.properties["Priority"]),
+			Flags:    item.properties["Flags"],
+		})
+	}
+
+	return ret, nil
+}
+
+func ListVFPGroups(r runner.Runner, portGUID string, layer string) ([]*pb.VFPGroup, error) {
+	out, err := r.Output(fmt.Sprintf("vfpctrl /port %s /layer %s /list-group", portGUID, layer))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VFP groups of layer %s: %v", layer, err)
+	}
+
+	ret := []*pb.VFPGroup{}
+	for _, item := range parseVFPItems(string(out), "GROUP") {
+		ret = append(ret, &pb.VFPGroup{
+			Name:       item.name,
+			Priority:   parsePriority(item.properties["Priority"]),
+			Direction:  item.properties["Direction"],
+			Type:       item.properties["Type"],
+			Conditions: item.sections["Conditions"],
+		})
+	}
+
+	return ret, nil
+}
+
+func ListVFPRules(r runner.Runner, portGUID string, layer string, group string) ([]*pb.VFPRule, error) {
+	out, err := r.Output(fmt.Sprintf("vfpctrl /port %s /layer %s /group %s /list-rule", portGUID, layer, group))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VFP rules of group %s in layer %s: %v", group, layer, err)
+	}
+
+	ret := []*pb.VFPRule{}
+	for _, item := range parseVFPItems(string(out), "RULE") {
+		rule := &pb.VFPRule{
+			Name:       item.name,
+			Priority:   parsePriority(item.properties["Priority"]),
+			Action:     item.properties["Type"],
+			Flags:      item.properties["Flags"],
+			Conditions: item.sections["Conditions"],
+			Properties: map[string]string{},
+		}
+
+		// Keep everything else, such as the flow TTL and any NAT transpositions
+		for key, value := range item.properties {
+			switch key {
+			case "Friendly name", "Priority", "Type", "Flags":
+			default:
+				rule.Properties[key] = value
+			}
+		}
+
+		for _, section := range item.order {
+			if section == "Conditions" {
+				continue
+			}
+
+			for _, c := range item.sections[section] {
+				rule.Properties[section+" "+c.GetField()] = c.GetValue()
+			}
+		}
+
+		ret = append(ret, rule)
+	}
+
+	return ret, nil
+}
+
+// Parses the items of the given kind listed by vfpctrl. Each item starts with a "KIND : name" line,
+// followed by "Key : value" properties and sections whose entries are indented under a "Section:" line.
+func parseVFPItems(out string, kind string) []*vfpItem {
+	ret := []*vfpItem{}
+	var current *vfpItem
+	section, sectionIndent := "", 0
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		raw := strings.TrimRight(scanner.Text(), "\r")
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			section = ""
+			continue
+		}
+
+		if m := vfpItemRe.FindStringSubmatch(raw); m != nil && m[1] == kind {
+			current = &vfpItem{name: m[2], properties: map[string]string{}, sections: map[string][]*pb.VFPCondition{}}
+			ret = append(ret, current)
+			section = ""
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		indent := len(raw) - len(strings.TrimLeft(raw, " \t"))
+		if section != "" && indent > sectionIndent {
+			if field, value, found := strings.Cut(line, ":"); found {
+				current.sections[section] = append(current.sections[section], &pb.VFPCondition{
+					Field: strings.TrimSpace(field),
+					Value: strings.TrimSpace(value),
+				})
+			}
+
+			continue
+		}
+
+		section = ""
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if value == "" {
+			section, sectionIndent = key, indent
+			current.order = append(current.order, key)
+			continue
+		}
+
+		current.properties[key] = value
+	}
+
+	return ret
+}
+
+func parsePriority(s string) uint32 {
+	n, _ := strconv.ParseUint(s, 10, 32)
+	return uint32(n)
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+
+	return false
+}
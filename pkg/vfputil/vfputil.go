@@ -5,11 +5,12 @@ package vfputil
 
 import (
 	"fmt"
-	"os/exec"
 	"regexp"
 	"strings"
 
 	"github.com/microsoft/wcnspect/pkg/netutil"
+	"github.com/microsoft/wcnspect/pkg/runner"
+	pb "github.com/microsoft/wcnspect/rpc"
 )
 
 type VFPPort struct {
@@ -18,24 +19,29 @@ type VFPPort struct {
 	Type       string
 }
 
-func CollateCounters(pod string, verbose bool) (string, error) {
+// Pulls the VFP counters of the pod's port, and if verbose, the host vNIC and external adapter ports.
+// Returns the raw tables collated under titles, along with the parsed counters of each port.
+func CollateCounters(r runner.Runner, pod string, verbose bool) (string, []*pb.VFPPortCounters, error) {
 	var collated strings.Builder
+	ports := []*pb.VFPPortCounters{}
 	delim := "\n=========================================================================\n"
 
-	pguid, err := GetPodPortGUID(pod)
+	pguid, err := GetPodPortGUID(r, pod)
 	if err != nil {
-		return collated.String(), err
+		return collated.String(), ports, err
 	}
 
 	guids := []string{pguid}
+	types := []pb.VFPPortType{pb.VFPPortType_pod_port}
 	titles := []string{fmt.Sprintf("Pod Port VFP Counters (ID: %s)", guids[0])}
 	if verbose {
-		hguid, eguid, err := GetHostAndExternalPortGUIDs()
+		hguid, eguid, err := GetHostAndExternalPortGUIDs(r)
 		if err != nil {
-			return collated.String(), err
+			return collated.String(), ports, err
 		}
 
 		guids = append(guids, hguid, eguid)
+		types = append(types, pb.VFPPortType_host_vnic, pb.VFPPortType_external_adapter)
 		titles = append(titles,
 			fmt.Sprintf("Host vNic VFP Counters (ID: %s)", hguid),
 			fmt.Sprintf("External Adapter VFP Counters (ID: %s)", eguid),
@@ -44,11 +50,17 @@ func CollateCounters(pod string, verbose bool) (string, error) {
 
 	collated.WriteString("\n")
 	for i, guid := range guids {
-		counters, err := PullVFPCounters(guid)
+		counters, err := PullVFPCounters(r, guid)
 		if err != nil {
-			return collated.String(), err
+			return collated.String(), ports, err
 		}
 
+		ports = append(ports, &pb.VFPPortCounters{
+			PortGuid:   guid,
+			PortType:   types[i],
+			Directions: ParseVFPCounters(counters),
+		})
+
 		collated.WriteString(titles[i])
 		collated.WriteString(delim)
 		collated.WriteString(counters)
@@ -58,11 +70,11 @@ func CollateCounters(pod string, verbose bool) (string, error) {
 		}
 	}
 
-	return collated.String(), nil
+	return collated.String(), ports, nil
 }
 
-func GetPodPortGUID(podIP string) (string, error) {
-	objs, err := netutil.ParseHNSDiag("endpoints")
+func GetPodPortGUID(r runner.Runner, podIP string) (string, error) {
+	objs, err := netutil.ParseHNSDiag(r, "endpoints")
 	if err != nil {
 		return "", err
 	}
@@ -89,10 +101,10 @@ func GetPodPortGUID(podIP string) (string, error) {
 /* Retrieves host port guid and external port guid on the VM running the function.
 Returns host port guid and external port guid. Returns an error if there was an issue with retrieval.
 */
-func GetHostAndExternalPortGUIDs() (string, string, error) {
+func GetHostAndExternalPortGUIDs(r runner.Runner) (string, string, error) {
 	var hguid, eguid string
 
-	objs, err := netutil.ParseHNSDiag("networks")
+	objs, err := netutil.ParseHNSDiag(r, "networks")
 	if err != nil {
 		return hguid, eguid, err
 	}
@@ -102,12 +114,12 @@ func GetHostAndExternalPortGUIDs() (string, string, error) {
 	}
 
 	ip := objs[0].ManagementIP
-	mac, err := PortIPtoMAC(ip)
+	mac, err := PortIPtoMAC(r, ip)
 	if err != nil {
 		return hguid, eguid, err
 	}
 
-	vfpPorts, err := ParseVFPPorts()
+	vfpPorts, err := ParseVFPPorts(r)
 	if err != nil {
 		return hguid, eguid, err
 	}
@@ -128,14 +140,14 @@ func GetHostAndExternalPortGUIDs() (string, string, error) {
 	return hguid, eguid, nil
 }
 
-func ListVFPPorts() ([]byte, error) {
-	return exec.Command("cmd", "/c", "vfpctrl /list-vmswitch-port").CombinedOutput()
+func ListVFPPorts(r runner.Runner) ([]byte, error) {
+	return r.CombinedOutput("vfpctrl /list-vmswitch-port")
 }
 
-func ParseVFPPorts() ([]VFPPort, error) {
+func ParseVFPPorts(r runner.Runner) ([]VFPPort, error) {
 	ret := []VFPPort{}
 
-	out, err := ListVFPPorts()
+	out, err := ListVFPPorts(r)
 	if err != nil {
 		return ret, err
 	}
@@ -168,8 +180,8 @@ func ParseVFPPorts() ([]VFPPort, error) {
 	return ret, nil
 }
 
-func PortIPtoMAC(ip string) (string, error) {
-	out, err := netutil.ListIPConfig()
+func PortIPtoMAC(r runner.Runner, ip string) (string, error) {
+	out, err := netutil.ListIPConfig(r)
 	if err != nil {
 		return "", err
 	}
@@ -203,11 +215,10 @@ func PortIPtoMAC(ip string) (string, error) {
 	return "", fmt.Errorf("unable to find corresponding MAC address for IP: %s", ip)
 }
 
-func PullVFPCounters(portGUID string) (string, error) {
+func PullVFPCounters(r runner.Runner, portGUID string) (string, error) {
 	vfpCmd := fmt.Sprintf("vfpctrl /port %s /get-port-counter", portGUID)
 
-	cmd := exec.Command("cmd", "/c", vfpCmd)
-	out, err := cmd.Output()
+	out, err := r.Output(vfpCmd)
 
 	return string(out), err
 }
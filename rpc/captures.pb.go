@@ -70,22 +70,234 @@ func (PacketType) EnumDescriptor() ([]byte, []int) {
 	return file_captures_proto_rawDescGZIP(), []int{0}
 }
 
+type ArtifactFormat int32
+
+const (
+	ArtifactFormat_none   ArtifactFormat = 0
+	ArtifactFormat_etl    ArtifactFormat = 1
+	ArtifactFormat_pcapng ArtifactFormat = 2
+)
+
+// Enum value maps for ArtifactFormat.
+var (
+	ArtifactFormat_name = map[int32]string{
+		0: "none",
+		1: "etl",
+		2: "pcapng",
+	}
+	ArtifactFormat_value = map[string]int32{
+		"none":   0,
+		"etl":    1,
+		"pcapng": 2,
+	}
+)
+
+func (x ArtifactFormat) Enum() *ArtifactFormat {
+	p := new(ArtifactFormat)
+	*p = x
+	return p
+}
+
+func (x ArtifactFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ArtifactFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_captures_proto_enumTypes[1].Descriptor()
+}
+
+func (ArtifactFormat) Type() protoreflect.EnumType {
+	return &file_captures_proto_enumTypes[1]
+}
+
+func (x ArtifactFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ArtifactFormat.Descriptor instead.
+func (ArtifactFormat) EnumDescriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{1}
+}
+
+type Direction int32
+
+const (
+	Direction_unknown Direction = 0
+	Direction_rx      Direction = 1
+	Direction_tx      Direction = 2
+)
+
+// Enum value maps for Direction.
+var (
+	Direction_name = map[int32]string{
+		0: "unknown",
+		1: "rx",
+		2: "tx",
+	}
+	Direction_value = map[string]int32{
+		"unknown": 0,
+		"rx":      1,
+		"tx":      2,
+	}
+)
+
+func (x Direction) Enum() *Direction {
+	p := new(Direction)
+	*p = x
+	return p
+}
+
+func (x Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_captures_proto_enumTypes[2].Descriptor()
+}
+
+func (Direction) Type() protoreflect.EnumType {
+	return &file_captures_proto_enumTypes[2]
+}
+
+func (x Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Direction.Descriptor instead.
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{2}
+}
+
+type VFPPortType int32
+
+const (
+	VFPPortType_pod_port         VFPPortType = 0
+	VFPPortType_host_vnic        VFPPortType = 1
+	VFPPortType_external_adapter VFPPortType = 2
+)
+
+// Enum value maps for VFPPortType.
+var (
+	VFPPortType_name = map[int32]string{
+		0: "pod_port",
+		1: "host_vnic",
+		2: "external_adapter",
+	}
+	VFPPortType_value = map[string]int32{
+		"pod_port":         0,
+		"host_vnic":        1,
+		"external_adapter": 2,
+	}
+)
+
+func (x VFPPortType) Enum() *VFPPortType {
+	p := new(VFPPortType)
+	*p = x
+	return p
+}
+
+func (x VFPPortType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VFPPortType) Descriptor() protoreflect.EnumDescriptor {
+	return file_captures_proto_enumTypes[3].Descriptor()
+}
+
+func (VFPPortType) Type() protoreflect.EnumType {
+	return &file_captures_proto_enumTypes[3]
+}
+
+func (x VFPPortType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VFPPortType.Descriptor instead.
+func (VFPPortType) EnumDescriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{3}
+}
+
 // models
+// Matches traffic between two values only, rather than traffic to or from either
+type FilterPair struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	First  string `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second string `protobuf:"bytes,2,opt,name=second,proto3" json:"second,omitempty"`
+}
+
+func (x *FilterPair) Reset() {
+	*x = FilterPair{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterPair) ProtoMessage() {}
+
+func (x *FilterPair) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterPair.ProtoReflect.Descriptor instead.
+func (*FilterPair) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FilterPair) GetFirst() string {
+	if x != nil {
+		return x.First
+	}
+	return ""
+}
+
+func (x *FilterPair) GetSecond() string {
+	if x != nil {
+		return x.Second
+	}
+	return ""
+}
+
 type Filters struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ips       []string `protobuf:"bytes,2,rep,name=ips,proto3" json:"ips,omitempty"`
-	Protocols []string `protobuf:"bytes,3,rep,name=protocols,proto3" json:"protocols,omitempty"`
-	Ports     []string `protobuf:"bytes,4,rep,name=ports,proto3" json:"ports,omitempty"`
-	Macs      []string `protobuf:"bytes,5,rep,name=macs,proto3" json:"macs,omitempty"`
+	Ips        []string      `protobuf:"bytes,2,rep,name=ips,proto3" json:"ips,omitempty"`
+	Protocols  []string      `protobuf:"bytes,3,rep,name=protocols,proto3" json:"protocols,omitempty"`
+	Ports      []string      `protobuf:"bytes,4,rep,name=ports,proto3" json:"ports,omitempty"`
+	Macs       []string      `protobuf:"bytes,5,rep,name=macs,proto3" json:"macs,omitempty"`
+	IpPairs    []*FilterPair `protobuf:"bytes,6,rep,name=ip_pairs,json=ipPairs,proto3" json:"ip_pairs,omitempty"`
+	PortPairs  []*FilterPair `protobuf:"bytes,7,rep,name=port_pairs,json=portPairs,proto3" json:"port_pairs,omitempty"`
+	MacPairs   []*FilterPair `protobuf:"bytes,8,rep,name=mac_pairs,json=macPairs,proto3" json:"mac_pairs,omitempty"`
+	Ethertypes []string      `protobuf:"bytes,9,rep,name=ethertypes,proto3" json:"ethertypes,omitempty"`
+	Vlans      []uint32      `protobuf:"varint,10,rep,packed,name=vlans,proto3" json:"vlans,omitempty"`
+	Heartbeat  bool          `protobuf:"varint,11,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	Encap      bool          `protobuf:"varint,12,opt,name=encap,proto3" json:"encap,omitempty"`                          // Match the other filters against both the inner and outer headers of encapsulated packets
+	VxlanPort  uint32        `protobuf:"varint,13,opt,name=vxlan_port,json=vxlanPort,proto3" json:"vxlan_port,omitempty"` // VXLAN port matched with encap, 4789 if not set
+	Sets       []*Filters    `protobuf:"bytes,14,rep,name=sets,proto3" json:"sets,omitempty"`                             // Alternative filters, each added on its own, as parsed from a filter expression
 }
 
 func (x *Filters) Reset() {
 	*x = Filters{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_captures_proto_msgTypes[0]
+		mi := &file_captures_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -98,7 +310,7 @@ func (x *Filters) String() string {
 func (*Filters) ProtoMessage() {}
 
 func (x *Filters) ProtoReflect() protoreflect.Message {
-	mi := &file_captures_proto_msgTypes[0]
+	mi := &file_captures_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -111,7 +323,7 @@ func (x *Filters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Filters.ProtoReflect.Descriptor instead.
 func (*Filters) Descriptor() ([]byte, []int) {
-	return file_captures_proto_rawDescGZIP(), []int{0}
+	return file_captures_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Filters) GetIps() []string {
@@ -142,6 +354,69 @@ func (x *Filters) GetMacs() []string {
 	return nil
 }
 
+func (x *Filters) GetIpPairs() []*FilterPair {
+	if x != nil {
+		return x.IpPairs
+	}
+	return nil
+}
+
+func (x *Filters) GetPortPairs() []*FilterPair {
+	if x != nil {
+		return x.PortPairs
+	}
+	return nil
+}
+
+func (x *Filters) GetMacPairs() []*FilterPair {
+	if x != nil {
+		return x.MacPairs
+	}
+	return nil
+}
+
+func (x *Filters) GetEthertypes() []string {
+	if x != nil {
+		return x.Ethertypes
+	}
+	return nil
+}
+
+func (x *Filters) GetVlans() []uint32 {
+	if x != nil {
+		return x.Vlans
+	}
+	return nil
+}
+
+func (x *Filters) GetHeartbeat() bool {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return false
+}
+
+func (x *Filters) GetEncap() bool {
+	if x != nil {
+		return x.Encap
+	}
+	return false
+}
+
+func (x *Filters) GetVxlanPort() uint32 {
+	if x != nil {
+		return x.VxlanPort
+	}
+	return 0
+}
+
+func (x *Filters) GetSets() []*Filters {
+	if x != nil {
+		return x.Sets
+	}
+	return nil
+}
+
 type Modifiers struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -155,7 +430,7 @@ type Modifiers struct {
 func (x *Modifiers) Reset() {
 	*x = Modifiers{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_captures_proto_msgTypes[1]
+		mi := &file_captures_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -168,7 +443,7 @@ func (x *Modifiers) String() string {
 func (*Modifiers) ProtoMessage() {}
 
 func (x *Modifiers) ProtoReflect() protoreflect.Message {
-	mi := &file_captures_proto_msgTypes[1]
+	mi := &file_captures_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -181,7 +456,7 @@ func (x *Modifiers) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Modifiers.ProtoReflect.Descriptor instead.
 func (*Modifiers) Descriptor() ([]byte, []int) {
-	return file_captures_proto_rawDescGZIP(), []int{1}
+	return file_captures_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Modifiers) GetPods() []string {
@@ -205,29 +480,45 @@ func (x *Modifiers) GetCountersOnly() bool {
 	return false
 }
 
-type Empty struct {
+type Packet struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-}
 
-func (x *Empty) Reset() {
-	*x = Empty{}
+	Timestamp    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ComponentId  uint32                 `protobuf:"varint,2,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	Direction    Direction              `protobuf:"varint,3,opt,name=direction,proto3,enum=wcnspect.captures.Direction" json:"direction,omitempty"`
+	SrcMac       string                 `protobuf:"bytes,4,opt,name=src_mac,json=srcMac,proto3" json:"src_mac,omitempty"`
+	DstMac       string                 `protobuf:"bytes,5,opt,name=dst_mac,json=dstMac,proto3" json:"dst_mac,omitempty"`
+	Ethertype    string                 `protobuf:"bytes,6,opt,name=ethertype,proto3" json:"ethertype,omitempty"`
+	SrcIp        string                 `protobuf:"bytes,7,opt,name=src_ip,json=srcIp,proto3" json:"src_ip,omitempty"`
+	DstIp        string                 `protobuf:"bytes,8,opt,name=dst_ip,json=dstIp,proto3" json:"dst_ip,omitempty"`
+	SrcPort      uint32                 `protobuf:"varint,9,opt,name=src_port,json=srcPort,proto3" json:"src_port,omitempty"`
+	DstPort      uint32                 `protobuf:"varint,10,opt,name=dst_port,json=dstPort,proto3" json:"dst_port,omitempty"`
+	Protocol     string                 `protobuf:"bytes,11,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	TcpFlags     []string               `protobuf:"bytes,12,rep,name=tcp_flags,json=tcpFlags,proto3" json:"tcp_flags,omitempty"`
+	Length       uint32                 `protobuf:"varint,13,opt,name=length,proto3" json:"length,omitempty"`
+	DropReason   string                 `protobuf:"bytes,14,opt,name=drop_reason,json=dropReason,proto3" json:"drop_reason,omitempty"`
+	DropLocation string                 `protobuf:"bytes,15,opt,name=drop_location,json=dropLocation,proto3" json:"drop_location,omitempty"`
+}
+
+func (x *Packet) Reset() {
+	*x = Packet{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_captures_proto_msgTypes[2]
+		mi := &file_captures_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Empty) String() string {
+func (x *Packet) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Empty) ProtoMessage() {}
+func (*Packet) ProtoMessage() {}
 
-func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_captures_proto_msgTypes[2]
+func (x *Packet) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -238,93 +529,128 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
-func (*Empty) Descriptor() ([]byte, []int) {
-	return file_captures_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use Packet.ProtoReflect.Descriptor instead.
+func (*Packet) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{3}
 }
 
-// requests
-type CaptureRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Duration  int32                  `protobuf:"varint,1,opt,name=duration,proto3" json:"duration,omitempty"`
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Modifier  *Modifiers             `protobuf:"bytes,3,opt,name=modifier,proto3" json:"modifier,omitempty"`
-	Filter    *Filters               `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+func (x *Packet) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
 }
 
-func (x *CaptureRequest) Reset() {
-	*x = CaptureRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_captures_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Packet) GetComponentId() uint32 {
+	if x != nil {
+		return x.ComponentId
 	}
+	return 0
 }
 
-func (x *CaptureRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Packet) GetDirection() Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return Direction_unknown
 }
 
-func (*CaptureRequest) ProtoMessage() {}
+func (x *Packet) GetSrcMac() string {
+	if x != nil {
+		return x.SrcMac
+	}
+	return ""
+}
 
-func (x *CaptureRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_captures_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Packet) GetDstMac() string {
+	if x != nil {
+		return x.DstMac
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use CaptureRequest.ProtoReflect.Descriptor instead.
-func (*CaptureRequest) Descriptor() ([]byte, []int) {
-	return file_captures_proto_rawDescGZIP(), []int{3}
+func (x *Packet) GetEthertype() string {
+	if x != nil {
+		return x.Ethertype
+	}
+	return ""
 }
 
-func (x *CaptureRequest) GetDuration() int32 {
+func (x *Packet) GetSrcIp() string {
 	if x != nil {
-		return x.Duration
+		return x.SrcIp
 	}
-	return 0
+	return ""
 }
 
-func (x *CaptureRequest) GetTimestamp() *timestamppb.Timestamp {
+func (x *Packet) GetDstIp() string {
 	if x != nil {
-		return x.Timestamp
+		return x.DstIp
 	}
-	return nil
+	return ""
 }
 
-func (x *CaptureRequest) GetModifier() *Modifiers {
+func (x *Packet) GetSrcPort() uint32 {
 	if x != nil {
-		return x.Modifier
+		return x.SrcPort
 	}
-	return nil
+	return 0
 }
 
-func (x *CaptureRequest) GetFilter() *Filters {
+func (x *Packet) GetDstPort() uint32 {
 	if x != nil {
-		return x.Filter
+		return x.DstPort
 	}
-	return nil
+	return 0
 }
 
-type CountersRequest struct {
+func (x *Packet) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *Packet) GetTcpFlags() []string {
+	if x != nil {
+		return x.TcpFlags
+	}
+	return nil
+}
+
+func (x *Packet) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *Packet) GetDropReason() string {
+	if x != nil {
+		return x.DropReason
+	}
+	return ""
+}
+
+func (x *Packet) GetDropLocation() string {
+	if x != nil {
+		return x.DropLocation
+	}
+	return ""
+}
+
+type DropCounter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IncludeHidden bool `protobuf:"varint,1,opt,name=include_hidden,json=includeHidden,proto3" json:"include_hidden,omitempty"`
+	Reason   string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Location string `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Packets  uint64 `protobuf:"varint,3,opt,name=packets,proto3" json:"packets,omitempty"`
 }
 
-func (x *CountersRequest) Reset() {
-	*x = CountersRequest{}
+func (x *DropCounter) Reset() {
+	*x = DropCounter{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_captures_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -332,13 +658,13 @@ func (x *CountersRequest) Reset() {
 	}
 }
 
-func (x *CountersRequest) String() string {
+func (x *DropCounter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CountersRequest) ProtoMessage() {}
+func (*DropCounter) ProtoMessage() {}
 
-func (x *CountersRequest) ProtoReflect() protoreflect.Message {
+func (x *DropCounter) ProtoReflect() protoreflect.Message {
 	mi := &file_captures_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -350,29 +676,49 @@ func (x *CountersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CountersRequest.ProtoReflect.Descriptor instead.
-func (*CountersRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DropCounter.ProtoReflect.Descriptor instead.
+func (*DropCounter) Descriptor() ([]byte, []int) {
 	return file_captures_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CountersRequest) GetIncludeHidden() bool {
+func (x *DropCounter) GetReason() string {
 	if x != nil {
-		return x.IncludeHidden
+		return x.Reason
 	}
-	return false
+	return ""
 }
 
-type VFPCountersRequest struct {
+func (x *DropCounter) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *DropCounter) GetPackets() uint64 {
+	if x != nil {
+		return x.Packets
+	}
+	return 0
+}
+
+type ComponentCounters struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pod     string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
-	Verbose bool   `protobuf:"varint,2,opt,name=verbose,proto3" json:"verbose,omitempty"`
+	ComponentId uint32         `protobuf:"varint,1,opt,name=component_id,json=componentId,proto3" json:"component_id,omitempty"`
+	Name        string         `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Mac         string         `protobuf:"bytes,3,opt,name=mac,proto3" json:"mac,omitempty"`
+	PacketsIn   uint64         `protobuf:"varint,4,opt,name=packets_in,json=packetsIn,proto3" json:"packets_in,omitempty"`
+	BytesIn     uint64         `protobuf:"varint,5,opt,name=bytes_in,json=bytesIn,proto3" json:"bytes_in,omitempty"`
+	PacketsOut  uint64         `protobuf:"varint,6,opt,name=packets_out,json=packetsOut,proto3" json:"packets_out,omitempty"`
+	BytesOut    uint64         `protobuf:"varint,7,opt,name=bytes_out,json=bytesOut,proto3" json:"bytes_out,omitempty"`
+	Drops       []*DropCounter `protobuf:"bytes,8,rep,name=drops,proto3" json:"drops,omitempty"`
 }
 
-func (x *VFPCountersRequest) Reset() {
-	*x = VFPCountersRequest{}
+func (x *ComponentCounters) Reset() {
+	*x = ComponentCounters{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_captures_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -380,13 +726,13 @@ func (x *VFPCountersRequest) Reset() {
 	}
 }
 
-func (x *VFPCountersRequest) String() string {
+func (x *ComponentCounters) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VFPCountersRequest) ProtoMessage() {}
+func (*ComponentCounters) ProtoMessage() {}
 
-func (x *VFPCountersRequest) ProtoReflect() protoreflect.Message {
+func (x *ComponentCounters) ProtoReflect() protoreflect.Message {
 	mi := &file_captures_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -398,37 +744,85 @@ func (x *VFPCountersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VFPCountersRequest.ProtoReflect.Descriptor instead.
-func (*VFPCountersRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ComponentCounters.ProtoReflect.Descriptor instead.
+func (*ComponentCounters) Descriptor() ([]byte, []int) {
 	return file_captures_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *VFPCountersRequest) GetPod() string {
+func (x *ComponentCounters) GetComponentId() uint32 {
 	if x != nil {
-		return x.Pod
+		return x.ComponentId
+	}
+	return 0
+}
+
+func (x *ComponentCounters) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-func (x *VFPCountersRequest) GetVerbose() bool {
+func (x *ComponentCounters) GetMac() string {
 	if x != nil {
-		return x.Verbose
+		return x.Mac
 	}
-	return false
+	return ""
 }
 
-// responses
-type CaptureResponse struct {
+func (x *ComponentCounters) GetPacketsIn() uint64 {
+	if x != nil {
+		return x.PacketsIn
+	}
+	return 0
+}
+
+func (x *ComponentCounters) GetBytesIn() uint64 {
+	if x != nil {
+		return x.BytesIn
+	}
+	return 0
+}
+
+func (x *ComponentCounters) GetPacketsOut() uint64 {
+	if x != nil {
+		return x.PacketsOut
+	}
+	return 0
+}
+
+func (x *ComponentCounters) GetBytesOut() uint64 {
+	if x != nil {
+		return x.BytesOut
+	}
+	return 0
+}
+
+func (x *ComponentCounters) GetDrops() []*DropCounter {
+	if x != nil {
+		return x.Drops
+	}
+	return nil
+}
+
+type VFPDirectionCounters struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Result    string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Direction        string         `protobuf:"bytes,1,opt,name=direction,proto3" json:"direction,omitempty"`
+	Packets          uint64         `protobuf:"varint,2,opt,name=packets,proto3" json:"packets,omitempty"`
+	Bytes            uint64         `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	DroppedPackets   uint64         `protobuf:"varint,4,opt,name=dropped_packets,json=droppedPackets,proto3" json:"dropped_packets,omitempty"`
+	ForwardedPackets uint64         `protobuf:"varint,5,opt,name=forwarded_packets,json=forwardedPackets,proto3" json:"forwarded_packets,omitempty"`
+	Drops            []*DropCounter `protobuf:"bytes,6,rep,name=drops,proto3" json:"drops,omitempty"`
+	FlowsCreated     uint64         `protobuf:"varint,7,opt,name=flows_created,json=flowsCreated,proto3" json:"flows_created,omitempty"`
+	FlowsDeleted     uint64         `protobuf:"varint,8,opt,name=flows_deleted,json=flowsDeleted,proto3" json:"flows_deleted,omitempty"`
+	ActiveFlows      uint64         `protobuf:"varint,9,opt,name=active_flows,json=activeFlows,proto3" json:"active_flows,omitempty"`
 }
 
-func (x *CaptureResponse) Reset() {
-	*x = CaptureResponse{}
+func (x *VFPDirectionCounters) Reset() {
+	*x = VFPDirectionCounters{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_captures_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -436,13 +830,13 @@ func (x *CaptureResponse) Reset() {
 	}
 }
 
-func (x *CaptureResponse) String() string {
+func (x *VFPDirectionCounters) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CaptureResponse) ProtoMessage() {}
+func (*VFPDirectionCounters) ProtoMessage() {}
 
-func (x *CaptureResponse) ProtoReflect() protoreflect.Message {
+func (x *VFPDirectionCounters) ProtoReflect() protoreflect.Message {
 	mi := &file_captures_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -454,36 +848,86 @@ func (x *CaptureResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CaptureResponse.ProtoReflect.Descriptor instead.
-func (*CaptureResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VFPDirectionCounters.ProtoReflect.Descriptor instead.
+func (*VFPDirectionCounters) Descriptor() ([]byte, []int) {
 	return file_captures_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CaptureResponse) GetResult() string {
+func (x *VFPDirectionCounters) GetDirection() string {
 	if x != nil {
-		return x.Result
+		return x.Direction
 	}
 	return ""
 }
 
-func (x *CaptureResponse) GetTimestamp() *timestamppb.Timestamp {
+func (x *VFPDirectionCounters) GetPackets() uint64 {
 	if x != nil {
-		return x.Timestamp
+		return x.Packets
+	}
+	return 0
+}
+
+func (x *VFPDirectionCounters) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *VFPDirectionCounters) GetDroppedPackets() uint64 {
+	if x != nil {
+		return x.DroppedPackets
+	}
+	return 0
+}
+
+func (x *VFPDirectionCounters) GetForwardedPackets() uint64 {
+	if x != nil {
+		return x.ForwardedPackets
+	}
+	return 0
+}
+
+func (x *VFPDirectionCounters) GetDrops() []*DropCounter {
+	if x != nil {
+		return x.Drops
 	}
 	return nil
 }
 
-type StopCaptureResponse struct {
+func (x *VFPDirectionCounters) GetFlowsCreated() uint64 {
+	if x != nil {
+		return x.FlowsCreated
+	}
+	return 0
+}
+
+func (x *VFPDirectionCounters) GetFlowsDeleted() uint64 {
+	if x != nil {
+		return x.FlowsDeleted
+	}
+	return 0
+}
+
+func (x *VFPDirectionCounters) GetActiveFlows() uint64 {
+	if x != nil {
+		return x.ActiveFlows
+	}
+	return 0
+}
+
+type VFPPortCounters struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Result    string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	PortGuid   string                  `protobuf:"bytes,1,opt,name=port_guid,json=portGuid,proto3" json:"port_guid,omitempty"`
+	PortType   VFPPortType             `protobuf:"varint,2,opt,name=port_type,json=portType,proto3,enum=wcnspect.captures.VFPPortType" json:"port_type,omitempty"`
+	Directions []*VFPDirectionCounters `protobuf:"bytes,3,rep,name=directions,proto3" json:"directions,omitempty"`
 }
 
-func (x *StopCaptureResponse) Reset() {
-	*x = StopCaptureResponse{}
+func (x *VFPPortCounters) Reset() {
+	*x = VFPPortCounters{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_captures_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -491,13 +935,13 @@ func (x *StopCaptureResponse) Reset() {
 	}
 }
 
-func (x *StopCaptureResponse) String() string {
+func (x *VFPPortCounters) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopCaptureResponse) ProtoMessage() {}
+func (*VFPPortCounters) ProtoMessage() {}
 
-func (x *StopCaptureResponse) ProtoReflect() protoreflect.Message {
+func (x *VFPPortCounters) ProtoReflect() protoreflect.Message {
 	mi := &file_captures_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -509,36 +953,43 @@ func (x *StopCaptureResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopCaptureResponse.ProtoReflect.Descriptor instead.
-func (*StopCaptureResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VFPPortCounters.ProtoReflect.Descriptor instead.
+func (*VFPPortCounters) Descriptor() ([]byte, []int) {
 	return file_captures_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *StopCaptureResponse) GetResult() string {
+func (x *VFPPortCounters) GetPortGuid() string {
 	if x != nil {
-		return x.Result
+		return x.PortGuid
 	}
 	return ""
 }
 
-func (x *StopCaptureResponse) GetTimestamp() *timestamppb.Timestamp {
+func (x *VFPPortCounters) GetPortType() VFPPortType {
 	if x != nil {
-		return x.Timestamp
+		return x.PortType
+	}
+	return VFPPortType_pod_port
+}
+
+func (x *VFPPortCounters) GetDirections() []*VFPDirectionCounters {
+	if x != nil {
+		return x.Directions
 	}
 	return nil
 }
 
-type CountersResponse struct {
+type VFPCondition struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Result    string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *CountersResponse) Reset() {
-	*x = CountersResponse{}
+func (x *VFPCondition) Reset() {
+	*x = VFPCondition{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_captures_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -546,13 +997,13 @@ func (x *CountersResponse) Reset() {
 	}
 }
 
-func (x *CountersResponse) String() string {
+func (x *VFPCondition) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CountersResponse) ProtoMessage() {}
+func (*VFPCondition) ProtoMessage() {}
 
-func (x *CountersResponse) ProtoReflect() protoreflect.Message {
+func (x *VFPCondition) ProtoReflect() protoreflect.Message {
 	mi := &file_captures_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -564,36 +1015,40 @@ func (x *CountersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CountersResponse.ProtoReflect.Descriptor instead.
-func (*CountersResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VFPCondition.ProtoReflect.Descriptor instead.
+func (*VFPCondition) Descriptor() ([]byte, []int) {
 	return file_captures_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CountersResponse) GetResult() string {
+func (x *VFPCondition) GetField() string {
 	if x != nil {
-		return x.Result
+		return x.Field
 	}
 	return ""
 }
 
-func (x *CountersResponse) GetTimestamp() *timestamppb.Timestamp {
+func (x *VFPCondition) GetValue() string {
 	if x != nil {
-		return x.Timestamp
+		return x.Value
 	}
-	return nil
+	return ""
 }
 
-type VFPCountersResponse struct {
+type VFPRule struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Result    string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Name       string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Priority   uint32            `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	Action     string            `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Flags      string            `protobuf:"bytes,4,opt,name=flags,proto3" json:"flags,omitempty"`
+	Conditions []*VFPCondition   `protobuf:"bytes,5,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	Properties map[string]string `protobuf:"bytes,6,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *VFPCountersResponse) Reset() {
-	*x = VFPCountersResponse{}
+func (x *VFPRule) Reset() {
+	*x = VFPRule{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_captures_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -601,13 +1056,13 @@ func (x *VFPCountersResponse) Reset() {
 	}
 }
 
-func (x *VFPCountersResponse) String() string {
+func (x *VFPRule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VFPCountersResponse) ProtoMessage() {}
+func (*VFPRule) ProtoMessage() {}
 
-func (x *VFPCountersResponse) ProtoReflect() protoreflect.Message {
+func (x *VFPRule) ProtoReflect() protoreflect.Message {
 	mi := &file_captures_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -619,186 +1074,2051 @@ func (x *VFPCountersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VFPCountersResponse.ProtoReflect.Descriptor instead.
-func (*VFPCountersResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VFPRule.ProtoReflect.Descriptor instead.
+func (*VFPRule) Descriptor() ([]byte, []int) {
 	return file_captures_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *VFPCountersResponse) GetResult() string {
+func (x *VFPRule) GetName() string {
 	if x != nil {
-		return x.Result
+		return x.Name
 	}
 	return ""
 }
 
-func (x *VFPCountersResponse) GetTimestamp() *timestamppb.Timestamp {
+func (x *VFPRule) GetPriority() uint32 {
 	if x != nil {
-		return x.Timestamp
+		return x.Priority
 	}
-	return nil
+	return 0
 }
 
-var File_captures_proto protoreflect.FileDescriptor
+func (x *VFPRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
 
-var file_captures_proto_rawDesc = []byte{
-	0x0a, 0x0e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x11, 0x77, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75,
-	0x72, 0x65, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x63, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12,
-	0x10, 0x0a, 0x03, 0x69, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x70,
-	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x12,
-	0x14, 0x0a, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
-	0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61, 0x63, 0x73, 0x18, 0x05, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x61, 0x63, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x09, 0x4d, 0x6f,
-	0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x12, 0x3e, 0x0a, 0x0b, 0x70,
-	0x61, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x1d, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74,
-	0x75, 0x72, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x0a, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x4f, 0x6e, 0x6c, 0x79,
-	0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0xd4, 0x01, 0x0a, 0x0e, 0x43, 0x61,
-	0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
-	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
-	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x12, 0x38, 0x0a, 0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e,
-	0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65,
-	0x72, 0x73, 0x52, 0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x06,
-	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77,
-	0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73,
-	0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x22, 0x38, 0x0a, 0x0f, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x68,
-	0x69, 0x64, 0x64, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x6e, 0x63,
-	0x6c, 0x75, 0x64, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x22, 0x40, 0x0a, 0x12, 0x56, 0x46,
-	0x50, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70,
-	0x6f, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x07, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x22, 0x63, 0x0a, 0x0f,
-	0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x22, 0x67, 0x0a, 0x13, 0x53, 0x74, 0x6f, 0x70, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x64, 0x0a, 0x10, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x22, 0x67, 0x0a, 0x13, 0x56, 0x46, 0x50, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
-	0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
-	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2a, 0x29, 0x0a, 0x0a, 0x50, 0x61, 0x63,
-	0x6b, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x10, 0x00,
-	0x12, 0x08, 0x0a, 0x04, 0x66, 0x6c, 0x6f, 0x77, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x64, 0x72,
-	0x6f, 0x70, 0x10, 0x02, 0x32, 0xfb, 0x02, 0x0a, 0x0e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x59, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74,
-	0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x12, 0x21, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x70, 0x65,
-	0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x70, 0x74,
-	0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x77, 0x69, 0x6e,
-	0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x43,
-	0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x30, 0x01, 0x12, 0x51, 0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x70, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72,
-	0x65, 0x12, 0x18, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70,
-	0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x26, 0x2e, 0x77, 0x69,
-	0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e,
-	0x53, 0x74, 0x6f, 0x70, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x65, 0x72, 0x73, 0x12, 0x22, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e,
-	0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x70,
-	0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x61, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x56, 0x46, 0x50, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72,
-	0x73, 0x12, 0x25, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70,
-	0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x56, 0x46, 0x50, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x70,
-	0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x56, 0x46, 0x50,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x6f, 0x66, 0x74, 0x2f, 0x77, 0x69, 0x6e, 0x73, 0x70,
-	0x65, 0x63, 0x74, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *VFPRule) GetFlags() string {
+	if x != nil {
+		return x.Flags
+	}
+	return ""
 }
 
-var (
-	file_captures_proto_rawDescOnce sync.Once
-	file_captures_proto_rawDescData = file_captures_proto_rawDesc
-)
+func (x *VFPRule) GetConditions() []*VFPCondition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
 
-func file_captures_proto_rawDescGZIP() []byte {
-	file_captures_proto_rawDescOnce.Do(func() {
-		file_captures_proto_rawDescData = protoimpl.X.CompressGZIP(file_captures_proto_rawDescData)
-	})
-	return file_captures_proto_rawDescData
+func (x *VFPRule) GetProperties() map[string]string {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
 }
 
-var file_captures_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_captures_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
-var file_captures_proto_goTypes = []interface{}{
-	(PacketType)(0),               // 0: wcnspect.captures.PacketType
-	(*Filters)(nil),               // 1: wcnspect.captures.Filters
-	(*Modifiers)(nil),             // 2: wcnspect.captures.Modifiers
-	(*Empty)(nil),                 // 3: wcnspect.captures.Empty
-	(*CaptureRequest)(nil),        // 4: wcnspect.captures.CaptureRequest
-	(*CountersRequest)(nil),       // 5: wcnspect.captures.CountersRequest
-	(*VFPCountersRequest)(nil),    // 6: wcnspect.captures.VFPCountersRequest
-	(*CaptureResponse)(nil),       // 7: wcnspect.captures.CaptureResponse
-	(*StopCaptureResponse)(nil),   // 8: wcnspect.captures.StopCaptureResponse
-	(*CountersResponse)(nil),      // 9: wcnspect.captures.CountersResponse
-	(*VFPCountersResponse)(nil),   // 10: wcnspect.captures.VFPCountersResponse
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+type VFPGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Priority   uint32          `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	Direction  string          `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`
+	Type       string          `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Conditions []*VFPCondition `protobuf:"bytes,5,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	Rules      []*VFPRule      `protobuf:"bytes,6,rep,name=rules,proto3" json:"rules,omitempty"`
 }
-var file_captures_proto_depIdxs = []int32{
-	0,  // 0: wcnspect.captures.Modifiers.packet_type:type_name -> wcnspect.captures.PacketType
-	11, // 1: wcnspect.captures.CaptureRequest.timestamp:type_name -> google.protobuf.Timestamp
-	2,  // 2: wcnspect.captures.CaptureRequest.modifier:type_name -> wcnspect.captures.Modifiers
-	1,  // 3: wcnspect.captures.CaptureRequest.filter:type_name -> wcnspect.captures.Filters
-	11, // 4: wcnspect.captures.CaptureResponse.timestamp:type_name -> google.protobuf.Timestamp
-	11, // 5: wcnspect.captures.StopCaptureResponse.timestamp:type_name -> google.protobuf.Timestamp
-	11, // 6: wcnspect.captures.CountersResponse.timestamp:type_name -> google.protobuf.Timestamp
-	11, // 7: wcnspect.captures.VFPCountersResponse.timestamp:type_name -> google.protobuf.Timestamp
-	4,  // 8: wcnspect.captures.CaptureService.StartCapture:input_type -> wcnspect.captures.CaptureRequest
-	3,  // 9: wcnspect.captures.CaptureService.StopCapture:input_type -> wcnspect.captures.Empty
-	5,  // 10: wcnspect.captures.CaptureService.GetCounters:input_type -> wcnspect.captures.CountersRequest
-	6,  // 11: wcnspect.captures.CaptureService.GetVFPCounters:input_type -> wcnspect.captures.VFPCountersRequest
-	7,  // 12: wcnspect.captures.CaptureService.StartCapture:output_type -> wcnspect.captures.CaptureResponse
-	8,  // 13: wcnspect.captures.CaptureService.StopCapture:output_type -> wcnspect.captures.StopCaptureResponse
-	9,  // 14: wcnspect.captures.CaptureService.GetCounters:output_type -> wcnspect.captures.CountersResponse
-	10, // 15: wcnspect.captures.CaptureService.GetVFPCounters:output_type -> wcnspect.captures.VFPCountersResponse
-	12, // [12:16] is the sub-list for method output_type
-	8,  // [8:12] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+
+func (x *VFPGroup) Reset() {
+	*x = VFPGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func init() { file_captures_proto_init() }
-func file_captures_proto_init() {
-	if File_captures_proto != nil {
-		return
+func (x *VFPGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VFPGroup) ProtoMessage() {}
+
+func (x *VFPGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_captures_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Filters); i {
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VFPGroup.ProtoReflect.Descriptor instead.
+func (*VFPGroup) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VFPGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VFPGroup) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *VFPGroup) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *VFPGroup) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *VFPGroup) GetConditions() []*VFPCondition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *VFPGroup) GetRules() []*VFPRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type VFPLayer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Priority uint32      `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	Flags    string      `protobuf:"bytes,3,opt,name=flags,proto3" json:"flags,omitempty"`
+	Groups   []*VFPGroup `protobuf:"bytes,4,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *VFPLayer) Reset() {
+	*x = VFPLayer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VFPLayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VFPLayer) ProtoMessage() {}
+
+func (x *VFPLayer) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VFPLayer.ProtoReflect.Descriptor instead.
+func (*VFPLayer) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VFPLayer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VFPLayer) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *VFPLayer) GetFlags() string {
+	if x != nil {
+		return x.Flags
+	}
+	return ""
+}
+
+func (x *VFPLayer) GetGroups() []*VFPGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{12}
+}
+
+type CaptureSession struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Started   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started,proto3" json:"started,omitempty"`
+	Request   *CaptureRequest        `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	Pcap      bool                   `protobuf:"varint,4,opt,name=pcap,proto3" json:"pcap,omitempty"`
+	Artifact  string                 `protobuf:"bytes,5,opt,name=artifact,proto3" json:"artifact,omitempty"`
+}
+
+func (x *CaptureSession) Reset() {
+	*x = CaptureSession{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CaptureSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureSession) ProtoMessage() {}
+
+func (x *CaptureSession) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureSession.ProtoReflect.Descriptor instead.
+func (*CaptureSession) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CaptureSession) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *CaptureSession) GetStarted() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Started
+	}
+	return nil
+}
+
+func (x *CaptureSession) GetRequest() *CaptureRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *CaptureSession) GetPcap() bool {
+	if x != nil {
+		return x.Pcap
+	}
+	return false
+}
+
+func (x *CaptureSession) GetArtifact() string {
+	if x != nil {
+		return x.Artifact
+	}
+	return ""
+}
+
+// pktmon state left behind by the server's previous run, cleaned up when it started
+type ServerCleanup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Sessions      []*CaptureSession      `protobuf:"bytes,2,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	PktmonStopped bool                   `protobuf:"varint,3,opt,name=pktmon_stopped,json=pktmonStopped,proto3" json:"pktmon_stopped,omitempty"`
+	Filters       []string               `protobuf:"bytes,4,rep,name=filters,proto3" json:"filters,omitempty"`
+	Errors        []string               `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *ServerCleanup) Reset() {
+	*x = ServerCleanup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerCleanup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerCleanup) ProtoMessage() {}
+
+func (x *ServerCleanup) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerCleanup.ProtoReflect.Descriptor instead.
+func (*ServerCleanup) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ServerCleanup) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ServerCleanup) GetSessions() []*CaptureSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *ServerCleanup) GetPktmonStopped() bool {
+	if x != nil {
+		return x.PktmonStopped
+	}
+	return false
+}
+
+func (x *ServerCleanup) GetFilters() []string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *ServerCleanup) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type Artifact struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SessionId string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Size      uint64                 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Modified  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=modified,proto3" json:"modified,omitempty"`
+	Complete  bool                   `protobuf:"varint,5,opt,name=complete,proto3" json:"complete,omitempty"`
+	Error     string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *Artifact) Reset() {
+	*x = Artifact{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Artifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artifact) ProtoMessage() {}
+
+func (x *Artifact) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Artifact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Artifact) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Artifact) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Artifact) GetModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Modified
+	}
+	return nil
+}
+
+func (x *Artifact) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+func (x *Artifact) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// requests
+type CaptureRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Duration  int32                  `protobuf:"varint,1,opt,name=duration,proto3" json:"duration,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Modifier  *Modifiers             `protobuf:"bytes,3,opt,name=modifier,proto3" json:"modifier,omitempty"`
+	Filter    *Filters               `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	Artifact  ArtifactFormat         `protobuf:"varint,5,opt,name=artifact,proto3,enum=wcnspect.captures.ArtifactFormat" json:"artifact,omitempty"`
+}
+
+func (x *CaptureRequest) Reset() {
+	*x = CaptureRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CaptureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureRequest) ProtoMessage() {}
+
+func (x *CaptureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureRequest.ProtoReflect.Descriptor instead.
+func (*CaptureRequest) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CaptureRequest) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *CaptureRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *CaptureRequest) GetModifier() *Modifiers {
+	if x != nil {
+		return x.Modifier
+	}
+	return nil
+}
+
+func (x *CaptureRequest) GetFilter() *Filters {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *CaptureRequest) GetArtifact() ArtifactFormat {
+	if x != nil {
+		return x.Artifact
+	}
+	return ArtifactFormat_none
+}
+
+type StopCaptureRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *StopCaptureRequest) Reset() {
+	*x = StopCaptureRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopCaptureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopCaptureRequest) ProtoMessage() {}
+
+func (x *StopCaptureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopCaptureRequest.ProtoReflect.Descriptor instead.
+func (*StopCaptureRequest) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StopCaptureRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type DownloadArtifactRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *DownloadArtifactRequest) Reset() {
+	*x = DownloadArtifactRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DownloadArtifactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadArtifactRequest) ProtoMessage() {}
+
+func (x *DownloadArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadArtifactRequest.ProtoReflect.Descriptor instead.
+func (*DownloadArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DownloadArtifactRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CountersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IncludeHidden bool `protobuf:"varint,1,opt,name=include_hidden,json=includeHidden,proto3" json:"include_hidden,omitempty"`
+}
+
+func (x *CountersRequest) Reset() {
+	*x = CountersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountersRequest) ProtoMessage() {}
+
+func (x *CountersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountersRequest.ProtoReflect.Descriptor instead.
+func (*CountersRequest) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CountersRequest) GetIncludeHidden() bool {
+	if x != nil {
+		return x.IncludeHidden
+	}
+	return false
+}
+
+type VFPCountersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod        string `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	Verbose    bool   `protobuf:"varint,2,opt,name=verbose,proto3" json:"verbose,omitempty"`
+	IncludeRaw bool   `protobuf:"varint,3,opt,name=include_raw,json=includeRaw,proto3" json:"include_raw,omitempty"`
+}
+
+func (x *VFPCountersRequest) Reset() {
+	*x = VFPCountersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VFPCountersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VFPCountersRequest) ProtoMessage() {}
+
+func (x *VFPCountersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VFPCountersRequest.ProtoReflect.Descriptor instead.
+func (*VFPCountersRequest) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *VFPCountersRequest) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *VFPCountersRequest) GetVerbose() bool {
+	if x != nil {
+		return x.Verbose
+	}
+	return false
+}
+
+func (x *VFPCountersRequest) GetIncludeRaw() bool {
+	if x != nil {
+		return x.IncludeRaw
+	}
+	return false
+}
+
+type VFPRulesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pod    string   `protobuf:"bytes,1,opt,name=pod,proto3" json:"pod,omitempty"`
+	Layers []string `protobuf:"bytes,2,rep,name=layers,proto3" json:"layers,omitempty"`
+}
+
+func (x *VFPRulesRequest) Reset() {
+	*x = VFPRulesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VFPRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VFPRulesRequest) ProtoMessage() {}
+
+func (x *VFPRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VFPRulesRequest.ProtoReflect.Descriptor instead.
+func (*VFPRulesRequest) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *VFPRulesRequest) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *VFPRulesRequest) GetLayers() []string {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
+}
+
+// responses
+type CaptureResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result    string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Packet    *Packet                `protobuf:"bytes,3,opt,name=packet,proto3" json:"packet,omitempty"`
+	SessionId string                 `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Counters  []*ComponentCounters   `protobuf:"bytes,5,rep,name=counters,proto3" json:"counters,omitempty"`
+	Artifact  string                 `protobuf:"bytes,6,opt,name=artifact,proto3" json:"artifact,omitempty"`
+}
+
+func (x *CaptureResponse) Reset() {
+	*x = CaptureResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CaptureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureResponse) ProtoMessage() {}
+
+func (x *CaptureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureResponse.ProtoReflect.Descriptor instead.
+func (*CaptureResponse) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CaptureResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *CaptureResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *CaptureResponse) GetPacket() *Packet {
+	if x != nil {
+		return x.Packet
+	}
+	return nil
+}
+
+func (x *CaptureResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *CaptureResponse) GetCounters() []*ComponentCounters {
+	if x != nil {
+		return x.Counters
+	}
+	return nil
+}
+
+func (x *CaptureResponse) GetArtifact() string {
+	if x != nil {
+		return x.Artifact
+	}
+	return ""
+}
+
+type PcapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result    []byte                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	SessionId string                 `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *PcapResponse) Reset() {
+	*x = PcapResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PcapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PcapResponse) ProtoMessage() {}
+
+func (x *PcapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PcapResponse.ProtoReflect.Descriptor instead.
+func (*PcapResponse) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PcapResponse) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *PcapResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *PcapResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type ListCapturesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sessions  []*CaptureSession      `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *ListCapturesResponse) Reset() {
+	*x = ListCapturesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCapturesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCapturesResponse) ProtoMessage() {}
+
+func (x *ListCapturesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCapturesResponse.ProtoReflect.Descriptor instead.
+func (*ListCapturesResponse) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListCapturesResponse) GetSessions() []*CaptureSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *ListCapturesResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type ServerStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Started   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=started,proto3" json:"started,omitempty"`
+	Sessions  []*CaptureSession      `protobuf:"bytes,2,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	Cleanup   *ServerCleanup         `protobuf:"bytes,3,opt,name=cleanup,proto3" json:"cleanup,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *ServerStatusResponse) Reset() {
+	*x = ServerStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerStatusResponse) ProtoMessage() {}
+
+func (x *ServerStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerStatusResponse.ProtoReflect.Descriptor instead.
+func (*ServerStatusResponse) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ServerStatusResponse) GetStarted() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Started
+	}
+	return nil
+}
+
+func (x *ServerStatusResponse) GetSessions() []*CaptureSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *ServerStatusResponse) GetCleanup() *ServerCleanup {
+	if x != nil {
+		return x.Cleanup
+	}
+	return nil
+}
+
+func (x *ServerStatusResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type ListArtifactsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Artifacts []*Artifact            `protobuf:"bytes,1,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *ListArtifactsResponse) Reset() {
+	*x = ListArtifactsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListArtifactsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArtifactsResponse) ProtoMessage() {}
+
+func (x *ListArtifactsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArtifactsResponse.ProtoReflect.Descriptor instead.
+func (*ListArtifactsResponse) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListArtifactsResponse) GetArtifacts() []*Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *ListArtifactsResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type ArtifactChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data      []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *ArtifactChunk) Reset() {
+	*x = ArtifactChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArtifactChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArtifactChunk) ProtoMessage() {}
+
+func (x *ArtifactChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArtifactChunk.ProtoReflect.Descriptor instead.
+func (*ArtifactChunk) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ArtifactChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ArtifactChunk) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type StopCaptureResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result    string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Counters  []*ComponentCounters   `protobuf:"bytes,3,rep,name=counters,proto3" json:"counters,omitempty"`
+}
+
+func (x *StopCaptureResponse) Reset() {
+	*x = StopCaptureResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopCaptureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopCaptureResponse) ProtoMessage() {}
+
+func (x *StopCaptureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopCaptureResponse.ProtoReflect.Descriptor instead.
+func (*StopCaptureResponse) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *StopCaptureResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *StopCaptureResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *StopCaptureResponse) GetCounters() []*ComponentCounters {
+	if x != nil {
+		return x.Counters
+	}
+	return nil
+}
+
+type CountersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result    string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Counters  []*ComponentCounters   `protobuf:"bytes,3,rep,name=counters,proto3" json:"counters,omitempty"`
+}
+
+func (x *CountersResponse) Reset() {
+	*x = CountersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountersResponse) ProtoMessage() {}
+
+func (x *CountersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountersResponse.ProtoReflect.Descriptor instead.
+func (*CountersResponse) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CountersResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *CountersResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *CountersResponse) GetCounters() []*ComponentCounters {
+	if x != nil {
+		return x.Counters
+	}
+	return nil
+}
+
+type VFPCountersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result    *string                `protobuf:"bytes,1,opt,name=result,proto3,oneof" json:"result,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Ports     []*VFPPortCounters     `protobuf:"bytes,3,rep,name=ports,proto3" json:"ports,omitempty"`
+}
+
+func (x *VFPCountersResponse) Reset() {
+	*x = VFPCountersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VFPCountersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VFPCountersResponse) ProtoMessage() {}
+
+func (x *VFPCountersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VFPCountersResponse.ProtoReflect.Descriptor instead.
+func (*VFPCountersResponse) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *VFPCountersResponse) GetResult() string {
+	if x != nil && x.Result != nil {
+		return *x.Result
+	}
+	return ""
+}
+
+func (x *VFPCountersResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *VFPCountersResponse) GetPorts() []*VFPPortCounters {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+type VFPRulesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PortGuid  string                 `protobuf:"bytes,1,opt,name=port_guid,json=portGuid,proto3" json:"port_guid,omitempty"`
+	Layers    []*VFPLayer            `protobuf:"bytes,2,rep,name=layers,proto3" json:"layers,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *VFPRulesResponse) Reset() {
+	*x = VFPRulesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_captures_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VFPRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VFPRulesResponse) ProtoMessage() {}
+
+func (x *VFPRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_captures_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VFPRulesResponse.ProtoReflect.Descriptor instead.
+func (*VFPRulesResponse) Descriptor() ([]byte, []int) {
+	return file_captures_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *VFPRulesResponse) GetPortGuid() string {
+	if x != nil {
+		return x.PortGuid
+	}
+	return ""
+}
+
+func (x *VFPRulesResponse) GetLayers() []*VFPLayer {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
+}
+
+func (x *VFPRulesResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+var File_captures_proto protoreflect.FileDescriptor
+
+var file_captures_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x11, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3a, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x50, 0x61,
+	0x69, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x22, 0xd0, 0x03, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x10, 0x0a, 0x03,
+	0x69, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x70, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x6f, 0x72,
+	0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61, 0x63, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x04, 0x6d, 0x61, 0x63, 0x73, 0x12, 0x38, 0x0a, 0x08, 0x69, 0x70, 0x5f, 0x70, 0x61, 0x69,
+	0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x50, 0x61, 0x69, 0x72, 0x52, 0x07, 0x69, 0x70, 0x50, 0x61, 0x69, 0x72, 0x73,
+	0x12, 0x3c, 0x0a, 0x0a, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e,
+	0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x50,
+	0x61, 0x69, 0x72, 0x52, 0x09, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x69, 0x72, 0x73, 0x12, 0x3a,
+	0x0a, 0x09, 0x6d, 0x61, 0x63, 0x5f, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x50, 0x61, 0x69, 0x72,
+	0x52, 0x08, 0x6d, 0x61, 0x63, 0x50, 0x61, 0x69, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x74,
+	0x68, 0x65, 0x72, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a,
+	0x65, 0x74, 0x68, 0x65, 0x72, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x6c,
+	0x61, 0x6e, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x05, 0x76, 0x6c, 0x61, 0x6e, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x6e, 0x63, 0x61, 0x70, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65,
+	0x6e, 0x63, 0x61, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x78, 0x6c, 0x61, 0x6e, 0x5f, 0x70, 0x6f,
+	0x72, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x76, 0x78, 0x6c, 0x61, 0x6e, 0x50,
+	0x6f, 0x72, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x73, 0x65, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x52, 0x04, 0x73,
+	0x65, 0x74, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x09, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x6f, 0x64, 0x73, 0x12, 0x3e, 0x0a, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x77, 0x63, 0x6e,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x50,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72,
+	0x73, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0xec, 0x03, 0x0a, 0x06, 0x50,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x12, 0x3a, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74,
+	0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17,
+	0x0a, 0x07, 0x73, 0x72, 0x63, 0x5f, 0x6d, 0x61, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x72, 0x63, 0x4d, 0x61, 0x63, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x73, 0x74, 0x5f, 0x6d,
+	0x61, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x73, 0x74, 0x4d, 0x61, 0x63,
+	0x12, 0x1c, 0x0a, 0x09, 0x65, 0x74, 0x68, 0x65, 0x72, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x74, 0x68, 0x65, 0x72, 0x74, 0x79, 0x70, 0x65, 0x12, 0x15,
+	0x0a, 0x06, 0x73, 0x72, 0x63, 0x5f, 0x69, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x73, 0x72, 0x63, 0x49, 0x70, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x73, 0x74, 0x5f, 0x69, 0x70, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x73, 0x74, 0x49, 0x70, 0x12, 0x19, 0x0a, 0x08,
+	0x73, 0x72, 0x63, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07,
+	0x73, 0x72, 0x63, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x73, 0x74, 0x5f, 0x70,
+	0x6f, 0x72, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x64, 0x73, 0x74, 0x50, 0x6f,
+	0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x1b,
+	0x0a, 0x09, 0x74, 0x63, 0x70, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x08, 0x74, 0x63, 0x70, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c,
+	0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x72, 0x6f, 0x70, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x72, 0x6f,
+	0x70, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x5b, 0x0a, 0x0b, 0x44, 0x72, 0x6f,
+	0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0x8a, 0x02, 0x0a, 0x11, 0x43, 0x6f, 0x6d, 0x70, 0x6f,
+	0x6e, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x0c,
+	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6d, 0x61, 0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x5f, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x49, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x69, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x62, 0x79, 0x74, 0x65, 0x73, 0x49, 0x6e, 0x12,
+	0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x4f, 0x75, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x62, 0x79, 0x74, 0x65, 0x73, 0x4f, 0x75, 0x74, 0x12, 0x34, 0x0a,
+	0x05, 0x64, 0x72, 0x6f, 0x70, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x77,
+	0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x2e, 0x44, 0x72, 0x6f, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x52, 0x05, 0x64, 0x72,
+	0x6f, 0x70, 0x73, 0x22, 0xdd, 0x02, 0x0a, 0x14, 0x56, 0x46, 0x50, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x72,
+	0x6f, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0e, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64,
+	0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10,
+	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x12, 0x34, 0x0a, 0x05, 0x64, 0x72, 0x6f, 0x70, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x2e, 0x44, 0x72, 0x6f, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x52,
+	0x05, 0x64, 0x72, 0x6f, 0x70, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x6c, 0x6f, 0x77, 0x73, 0x5f,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x66,
+	0x6c, 0x6f, 0x77, 0x73, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x66,
+	0x6c, 0x6f, 0x77, 0x73, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x66, 0x6c, 0x6f, 0x77, 0x73, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x66, 0x6c, 0x6f, 0x77, 0x73,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x46, 0x6c,
+	0x6f, 0x77, 0x73, 0x22, 0xb4, 0x01, 0x0a, 0x0f, 0x56, 0x46, 0x50, 0x50, 0x6f, 0x72, 0x74, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6f, 0x72, 0x74, 0x5f,
+	0x67, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x72, 0x74,
+	0x47, 0x75, 0x69, 0x64, 0x12, 0x3b, 0x0a, 0x09, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65,
+	0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x56, 0x46, 0x50, 0x50,
+	0x6f, 0x72, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x47, 0x0a, 0x0a, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74,
+	0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x56, 0x46, 0x50, 0x44, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x0a,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x3a, 0x0a, 0x0c, 0x56, 0x46,
+	0x50, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xb3, 0x02, 0x0a, 0x07, 0x56, 0x46, 0x50, 0x52, 0x75,
+	0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c,
+	0x61, 0x67, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73,
+	0x12, 0x3f, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e,
+	0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x56, 0x46, 0x50, 0x43, 0x6f, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x4a, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74,
+	0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x56, 0x46, 0x50, 0x52, 0x75, 0x6c,
+	0x65, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x1a, 0x3d, 0x0a,
+	0x0f, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xdf, 0x01, 0x0a,
+	0x08, 0x56, 0x46, 0x50, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x2e, 0x56, 0x46, 0x50, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x30, 0x0a, 0x05,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x63,
+	0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e,
+	0x56, 0x46, 0x50, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x85,
+	0x01, 0x0a, 0x08, 0x56, 0x46, 0x50, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x66,
+	0x6c, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67,
+	0x73, 0x12, 0x33, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x56, 0x46, 0x50, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0xd2, 0x01, 0x0a, 0x0e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x34, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x3b, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x63, 0x61, 0x70, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x70, 0x63, 0x61, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x74, 0x69,
+	0x66, 0x61, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x72, 0x74, 0x69,
+	0x66, 0x61, 0x63, 0x74, 0x22, 0xd7, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43,
+	0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x6b, 0x74, 0x6d, 0x6f, 0x6e, 0x5f,
+	0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x70,
+	0x6b, 0x74, 0x6d, 0x6f, 0x6e, 0x53, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0xbb,
+	0x01, 0x0a, 0x08, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x93, 0x02, 0x0a,
+	0x0e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x38, 0x0a, 0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65,
+	0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x4d, 0x6f, 0x64, 0x69,
+	0x66, 0x69, 0x65, 0x72, 0x73, 0x52, 0x08, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12,
+	0x32, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x52, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x08, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74,
+	0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x08, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x22, 0x33, 0x0a, 0x12, 0x53, 0x74, 0x6f, 0x70, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x2d, 0x0a, 0x17, 0x44, 0x6f, 0x77, 0x6e, 0x6c,
+	0x6f, 0x61, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x38, 0x0a, 0x0f, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x5f, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e,
+	0x22, 0x61, 0x0a, 0x12, 0x56, 0x46, 0x50, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x62,
+	0x6f, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x76, 0x65, 0x72, 0x62, 0x6f,
+	0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x72, 0x61,
+	0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x52, 0x61, 0x77, 0x22, 0x3b, 0x0a, 0x0f, 0x56, 0x46, 0x50, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73,
+	0x22, 0x93, 0x02, 0x0a, 0x0f, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x38, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x31, 0x0a, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x52, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x40, 0x0a, 0x08, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x77, 0x63, 0x6e,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x43,
+	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73,
+	0x52, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x22, 0x7f, 0x0a, 0x0c, 0x50, 0x63, 0x61, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x38,
+	0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x8f, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74,
+	0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3d, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61,
+	0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x81, 0x02, 0x0a, 0x14, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x77, 0x63, 0x6e,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x43,
+	0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3a, 0x0a, 0x07, 0x63, 0x6c, 0x65, 0x61, 0x6e,
+	0x75, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x52, 0x07, 0x63, 0x6c, 0x65, 0x61,
+	0x6e, 0x75, 0x70, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x8c, 0x01,
+	0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x63, 0x6e,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x41,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x5d, 0x0a, 0x0d,
+	0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xa9, 0x01, 0x0a, 0x13,
+	0x53, 0x74, 0x6f, 0x70, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x40, 0x0a, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65,
+	0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x08, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x22, 0xa6, 0x01, 0x0a, 0x10, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x40,
+	0x0a, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73,
+	0x22, 0xb1, 0x01, 0x0a, 0x13, 0x56, 0x46, 0x50, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x88, 0x01, 0x01, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
+	0x38, 0x0a, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x2e, 0x56, 0x46, 0x50, 0x50, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65,
+	0x72, 0x73, 0x52, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x22, 0x9e, 0x01, 0x0a, 0x10, 0x56, 0x46, 0x50, 0x52, 0x75, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6f, 0x72,
+	0x74, 0x5f, 0x67, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f,
+	0x72, 0x74, 0x47, 0x75, 0x69, 0x64, 0x12, 0x33, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x56, 0x46, 0x50, 0x4c, 0x61,
+	0x79, 0x65, 0x72, 0x52, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2a, 0x29, 0x0a, 0x0a, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04,
+	0x66, 0x6c, 0x6f, 0x77, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x64, 0x72, 0x6f, 0x70, 0x10, 0x02,
+	0x2a, 0x2f, 0x0a, 0x0e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x12, 0x08, 0x0a, 0x04, 0x6e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03,
+	0x65, 0x74, 0x6c, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x70, 0x63, 0x61, 0x70, 0x6e, 0x67, 0x10,
+	0x02, 0x2a, 0x28, 0x0a, 0x09, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b,
+	0x0a, 0x07, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x72,
+	0x78, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x74, 0x78, 0x10, 0x02, 0x2a, 0x40, 0x0a, 0x0b, 0x56,
+	0x46, 0x50, 0x50, 0x6f, 0x72, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x70, 0x6f,
+	0x64, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x68, 0x6f, 0x73, 0x74,
+	0x5f, 0x76, 0x6e, 0x69, 0x63, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x65, 0x78, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x64, 0x61, 0x70, 0x74, 0x65, 0x72, 0x10, 0x02, 0x32, 0xa3, 0x07,
+	0x0a, 0x0e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x59, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65,
+	0x12, 0x21, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63,
+	0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x55, 0x0a, 0x0b, 0x43,
+	0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x50, 0x63, 0x61, 0x70, 0x12, 0x21, 0x2e, 0x77, 0x63, 0x6e,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x43,
+	0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65,
+	0x73, 0x2e, 0x50, 0x63, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x30, 0x01, 0x12, 0x5e, 0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x70, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72,
+	0x65, 0x12, 0x25, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x6f,
+	0x70, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x53, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x12, 0x18, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61,
+	0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x27, 0x2e, 0x77,
+	0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x2e, 0x77, 0x63, 0x6e,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x27, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e,
+	0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x55, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73,
+	0x12, 0x18, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x28, 0x2e, 0x77, 0x63, 0x6e,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x10, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x2a, 0x2e, 0x77, 0x63, 0x6e,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x44,
+	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x58, 0x0a, 0x0b,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x12, 0x22, 0x2e, 0x77, 0x63,
+	0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x23, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x56, 0x46, 0x50,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x12, 0x25, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x56, 0x46, 0x50,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x26, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x2e, 0x56, 0x46, 0x50, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0b, 0x47, 0x65, 0x74,
+	0x56, 0x46, 0x50, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x56, 0x46, 0x50,
+	0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x77,
+	0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x2e, 0x56, 0x46, 0x50, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x6f, 0x66, 0x74, 0x2f, 0x77, 0x63, 0x6e, 0x73,
+	0x70, 0x65, 0x63, 0x74, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_captures_proto_rawDescOnce sync.Once
+	file_captures_proto_rawDescData = file_captures_proto_rawDesc
+)
+
+func file_captures_proto_rawDescGZIP() []byte {
+	file_captures_proto_rawDescOnce.Do(func() {
+		file_captures_proto_rawDescData = protoimpl.X.CompressGZIP(file_captures_proto_rawDescData)
+	})
+	return file_captures_proto_rawDescData
+}
+
+var file_captures_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_captures_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_captures_proto_goTypes = []interface{}{
+	(PacketType)(0),                 // 0: wcnspect.captures.PacketType
+	(ArtifactFormat)(0),             // 1: wcnspect.captures.ArtifactFormat
+	(Direction)(0),                  // 2: wcnspect.captures.Direction
+	(VFPPortType)(0),                // 3: wcnspect.captures.VFPPortType
+	(*FilterPair)(nil),              // 4: wcnspect.captures.FilterPair
+	(*Filters)(nil),                 // 5: wcnspect.captures.Filters
+	(*Modifiers)(nil),               // 6: wcnspect.captures.Modifiers
+	(*Packet)(nil),                  // 7: wcnspect.captures.Packet
+	(*DropCounter)(nil),             // 8: wcnspect.captures.DropCounter
+	(*ComponentCounters)(nil),       // 9: wcnspect.captures.ComponentCounters
+	(*VFPDirectionCounters)(nil),    // 10: wcnspect.captures.VFPDirectionCounters
+	(*VFPPortCounters)(nil),         // 11: wcnspect.captures.VFPPortCounters
+	(*VFPCondition)(nil),            // 12: wcnspect.captures.VFPCondition
+	(*VFPRule)(nil),                 // 13: wcnspect.captures.VFPRule
+	(*VFPGroup)(nil),                // 14: wcnspect.captures.VFPGroup
+	(*VFPLayer)(nil),                // 15: wcnspect.captures.VFPLayer
+	(*Empty)(nil),                   // 16: wcnspect.captures.Empty
+	(*CaptureSession)(nil),          // 17: wcnspect.captures.CaptureSession
+	(*ServerCleanup)(nil),           // 18: wcnspect.captures.ServerCleanup
+	(*Artifact)(nil),                // 19: wcnspect.captures.Artifact
+	(*CaptureRequest)(nil),          // 20: wcnspect.captures.CaptureRequest
+	(*StopCaptureRequest)(nil),      // 21: wcnspect.captures.StopCaptureRequest
+	(*DownloadArtifactRequest)(nil), // 22: wcnspect.captures.DownloadArtifactRequest
+	(*CountersRequest)(nil),         // 23: wcnspect.captures.CountersRequest
+	(*VFPCountersRequest)(nil),      // 24: wcnspect.captures.VFPCountersRequest
+	(*VFPRulesRequest)(nil),         // 25: wcnspect.captures.VFPRulesRequest
+	(*CaptureResponse)(nil),         // 26: wcnspect.captures.CaptureResponse
+	(*PcapResponse)(nil),            // 27: wcnspect.captures.PcapResponse
+	(*ListCapturesResponse)(nil),    // 28: wcnspect.captures.ListCapturesResponse
+	(*ServerStatusResponse)(nil),    // 29: wcnspect.captures.ServerStatusResponse
+	(*ListArtifactsResponse)(nil),   // 30: wcnspect.captures.ListArtifactsResponse
+	(*ArtifactChunk)(nil),           // 31: wcnspect.captures.ArtifactChunk
+	(*StopCaptureResponse)(nil),     // 32: wcnspect.captures.StopCaptureResponse
+	(*CountersResponse)(nil),        // 33: wcnspect.captures.CountersResponse
+	(*VFPCountersResponse)(nil),     // 34: wcnspect.captures.VFPCountersResponse
+	(*VFPRulesResponse)(nil),        // 35: wcnspect.captures.VFPRulesResponse
+	nil,                             // 36: wcnspect.captures.VFPRule.PropertiesEntry
+	(*timestamppb.Timestamp)(nil),   // 37: google.protobuf.Timestamp
+}
+var file_captures_proto_depIdxs = []int32{
+	4,  // 0: wcnspect.captures.Filters.ip_pairs:type_name -> wcnspect.captures.FilterPair
+	4,  // 1: wcnspect.captures.Filters.port_pairs:type_name -> wcnspect.captures.FilterPair
+	4,  // 2: wcnspect.captures.Filters.mac_pairs:type_name -> wcnspect.captures.FilterPair
+	5,  // 3: wcnspect.captures.Filters.sets:type_name -> wcnspect.captures.Filters
+	0,  // 4: wcnspect.captures.Modifiers.packet_type:type_name -> wcnspect.captures.PacketType
+	37, // 5: wcnspect.captures.Packet.timestamp:type_name -> google.protobuf.Timestamp
+	2,  // 6: wcnspect.captures.Packet.direction:type_name -> wcnspect.captures.Direction
+	8,  // 7: wcnspect.captures.ComponentCounters.drops:type_name -> wcnspect.captures.DropCounter
+	8,  // 8: wcnspect.captures.VFPDirectionCounters.drops:type_name -> wcnspect.captures.DropCounter
+	3,  // 9: wcnspect.captures.VFPPortCounters.port_type:type_name -> wcnspect.captures.VFPPortType
+	10, // 10: wcnspect.captures.VFPPortCounters.directions:type_name -> wcnspect.captures.VFPDirectionCounters
+	12, // 11: wcnspect.captures.VFPRule.conditions:type_name -> wcnspect.captures.VFPCondition
+	36, // 12: wcnspect.captures.VFPRule.properties:type_name -> wcnspect.captures.VFPRule.PropertiesEntry
+	12, // 13: wcnspect.captures.VFPGroup.conditions:type_name -> wcnspect.captures.VFPCondition
+	13, // 14: wcnspect.captures.VFPGroup.rules:type_name -> wcnspect.captures.VFPRule
+	14, // 15: wcnspect.captures.VFPLayer.groups:type_name -> wcnspect.captures.VFPGroup
+	37, // 16: wcnspect.captures.CaptureSession.started:type_name -> google.protobuf.Timestamp
+	20, // 17: wcnspect.captures.CaptureSession.request:type_name -> wcnspect.captures.CaptureRequest
+	37, // 18: wcnspect.captures.ServerCleanup.time:type_name -> google.protobuf.Timestamp
+	17, // 19: wcnspect.captures.ServerCleanup.sessions:type_name -> wcnspect.captures.CaptureSession
+	37, // 20: wcnspect.captures.Artifact.modified:type_name -> google.protobuf.Timestamp
+	37, // 21: wcnspect.captures.CaptureRequest.timestamp:type_name -> google.protobuf.Timestamp
+	6,  // 22: wcnspect.captures.CaptureRequest.modifier:type_name -> wcnspect.captures.Modifiers
+	5,  // 23: wcnspect.captures.CaptureRequest.filter:type_name -> wcnspect.captures.Filters
+	1,  // 24: wcnspect.captures.CaptureRequest.artifact:type_name -> wcnspect.captures.ArtifactFormat
+	37, // 25: wcnspect.captures.CaptureResponse.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 26: wcnspect.captures.CaptureResponse.packet:type_name -> wcnspect.captures.Packet
+	9,  // 27: wcnspect.captures.CaptureResponse.counters:type_name -> wcnspect.captures.ComponentCounters
+	37, // 28: wcnspect.captures.PcapResponse.timestamp:type_name -> google.protobuf.Timestamp
+	17, // 29: wcnspect.captures.ListCapturesResponse.sessions:type_name -> wcnspect.captures.CaptureSession
+	37, // 30: wcnspect.captures.ListCapturesResponse.timestamp:type_name -> google.protobuf.Timestamp
+	37, // 31: wcnspect.captures.ServerStatusResponse.started:type_name -> google.protobuf.Timestamp
+	17, // 32: wcnspect.captures.ServerStatusResponse.sessions:type_name -> wcnspect.captures.CaptureSession
+	18, // 33: wcnspect.captures.ServerStatusResponse.cleanup:type_name -> wcnspect.captures.ServerCleanup
+	37, // 34: wcnspect.captures.ServerStatusResponse.timestamp:type_name -> google.protobuf.Timestamp
+	19, // 35: wcnspect.captures.ListArtifactsResponse.artifacts:type_name -> wcnspect.captures.Artifact
+	37, // 36: wcnspect.captures.ListArtifactsResponse.timestamp:type_name -> google.protobuf.Timestamp
+	37, // 37: wcnspect.captures.ArtifactChunk.timestamp:type_name -> google.protobuf.Timestamp
+	37, // 38: wcnspect.captures.StopCaptureResponse.timestamp:type_name -> google.protobuf.Timestamp
+	9,  // 39: wcnspect.captures.StopCaptureResponse.counters:type_name -> wcnspect.captures.ComponentCounters
+	37, // 40: wcnspect.captures.CountersResponse.timestamp:type_name -> google.protobuf.Timestamp
+	9,  // 41: wcnspect.captures.CountersResponse.counters:type_name -> wcnspect.captures.ComponentCounters
+	37, // 42: wcnspect.captures.VFPCountersResponse.timestamp:type_name -> google.protobuf.Timestamp
+	11, // 43: wcnspect.captures.VFPCountersResponse.ports:type_name -> wcnspect.captures.VFPPortCounters
+	15, // 44: wcnspect.captures.VFPRulesResponse.layers:type_name -> wcnspect.captures.VFPLayer
+	37, // 45: wcnspect.captures.VFPRulesResponse.timestamp:type_name -> google.protobuf.Timestamp
+	20, // 46: wcnspect.captures.CaptureService.StartCapture:input_type -> wcnspect.captures.CaptureRequest
+	20, // 47: wcnspect.captures.CaptureService.CapturePcap:input_type -> wcnspect.captures.CaptureRequest
+	21, // 48: wcnspect.captures.CaptureService.StopCapture:input_type -> wcnspect.captures.StopCaptureRequest
+	16, // 49: wcnspect.captures.CaptureService.ListCaptures:input_type -> wcnspect.captures.Empty
+	16, // 50: wcnspect.captures.CaptureService.GetServerStatus:input_type -> wcnspect.captures.Empty
+	16, // 51: wcnspect.captures.CaptureService.ListArtifacts:input_type -> wcnspect.captures.Empty
+	22, // 52: wcnspect.captures.CaptureService.DownloadArtifact:input_type -> wcnspect.captures.DownloadArtifactRequest
+	23, // 53: wcnspect.captures.CaptureService.GetCounters:input_type -> wcnspect.captures.CountersRequest
+	24, // 54: wcnspect.captures.CaptureService.GetVFPCounters:input_type -> wcnspect.captures.VFPCountersRequest
+	25, // 55: wcnspect.captures.CaptureService.GetVFPRules:input_type -> wcnspect.captures.VFPRulesRequest
+	26, // 56: wcnspect.captures.CaptureService.StartCapture:output_type -> wcnspect.captures.CaptureResponse
+	27, // 57: wcnspect.captures.CaptureService.CapturePcap:output_type -> wcnspect.captures.PcapResponse
+	32, // 58: wcnspect.captures.CaptureService.StopCapture:output_type -> wcnspect.captures.StopCaptureResponse
+	28, // 59: wcnspect.captures.CaptureService.ListCaptures:output_type -> wcnspect.captures.ListCapturesResponse
+	29, // 60: wcnspect.captures.CaptureService.GetServerStatus:output_type -> wcnspect.captures.ServerStatusResponse
+	30, // 61: wcnspect.captures.CaptureService.ListArtifacts:output_type -> wcnspect.captures.ListArtifactsResponse
+	31, // 62: wcnspect.captures.CaptureService.DownloadArtifact:output_type -> wcnspect.captures.ArtifactChunk
+	33, // 63: wcnspect.captures.CaptureService.GetCounters:output_type -> wcnspect.captures.CountersResponse
+	34, // 64: wcnspect.captures.CaptureService.GetVFPCounters:output_type -> wcnspect.captures.VFPCountersResponse
+	35, // 65: wcnspect.captures.CaptureService.GetVFPRules:output_type -> wcnspect.captures.VFPRulesResponse
+	56, // [56:66] is the sub-list for method output_type
+	46, // [46:56] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
+}
+
+func init() { file_captures_proto_init() }
+func file_captures_proto_init() {
+	if File_captures_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_captures_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilterPair); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -810,7 +3130,7 @@ func file_captures_proto_init() {
 			}
 		}
 		file_captures_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Modifiers); i {
+			switch v := v.(*Filters); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -822,7 +3142,7 @@ func file_captures_proto_init() {
 			}
 		}
 		file_captures_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Empty); i {
+			switch v := v.(*Modifiers); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -834,7 +3154,7 @@ func file_captures_proto_init() {
 			}
 		}
 		file_captures_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CaptureRequest); i {
+			switch v := v.(*Packet); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -846,7 +3166,7 @@ func file_captures_proto_init() {
 			}
 		}
 		file_captures_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CountersRequest); i {
+			switch v := v.(*DropCounter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -858,7 +3178,7 @@ func file_captures_proto_init() {
 			}
 		}
 		file_captures_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VFPCountersRequest); i {
+			switch v := v.(*ComponentCounters); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -870,7 +3190,7 @@ func file_captures_proto_init() {
 			}
 		}
 		file_captures_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CaptureResponse); i {
+			switch v := v.(*VFPDirectionCounters); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -882,7 +3202,7 @@ func file_captures_proto_init() {
 			}
 		}
 		file_captures_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopCaptureResponse); i {
+			switch v := v.(*VFPPortCounters); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -894,7 +3214,7 @@ func file_captures_proto_init() {
 			}
 		}
 		file_captures_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CountersResponse); i {
+			switch v := v.(*VFPCondition); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -906,6 +3226,258 @@ func file_captures_proto_init() {
 			}
 		}
 		file_captures_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VFPRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VFPGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VFPLayer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaptureSession); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerCleanup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Artifact); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaptureRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopCaptureRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadArtifactRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VFPCountersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VFPRulesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaptureResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PcapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCapturesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListArtifactsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArtifactChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopCaptureResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_captures_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*VFPCountersResponse); i {
 			case 0:
 				return &v.state
@@ -917,14 +3489,27 @@ func file_captures_proto_init() {
 				return nil
 			}
 		}
+		file_captures_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VFPRulesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_captures_proto_msgTypes[30].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_captures_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   10,
+			NumEnums:      4,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.0
+// source: captures.proto
 
 package rpc
 
@@ -19,9 +23,15 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CaptureServiceClient interface {
 	StartCapture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (CaptureService_StartCaptureClient, error)
-	StopCapture(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StopCaptureResponse, error)
+	CapturePcap(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (CaptureService_CapturePcapClient, error)
+	StopCapture(ctx context.Context, in *StopCaptureRequest, opts ...grpc.CallOption) (*StopCaptureResponse, error)
+	ListCaptures(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListCapturesResponse, error)
+	GetServerStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServerStatusResponse, error)
+	ListArtifacts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListArtifactsResponse, error)
+	DownloadArtifact(ctx context.Context, in *DownloadArtifactRequest, opts ...grpc.CallOption) (CaptureService_DownloadArtifactClient, error)
 	GetCounters(ctx context.Context, in *CountersRequest, opts ...grpc.CallOption) (*CountersResponse, error)
 	GetVFPCounters(ctx context.Context, in *VFPCountersRequest, opts ...grpc.CallOption) (*VFPCountersResponse, error)
+	GetVFPRules(ctx context.Context, in *VFPRulesRequest, opts ...grpc.CallOption) (*VFPRulesResponse, error)
 }
 
 type captureServiceClient struct {
@@ -64,7 +74,39 @@ func (x *captureServiceStartCaptureClient) Recv() (*CaptureResponse, error) {
 	return m, nil
 }
 
-func (c *captureServiceClient) StopCapture(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StopCaptureResponse, error) {
+func (c *captureServiceClient) CapturePcap(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (CaptureService_CapturePcapClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CaptureService_ServiceDesc.Streams[1], "/wcnspect.captures.CaptureService/CapturePcap", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &captureServiceCapturePcapClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CaptureService_CapturePcapClient interface {
+	Recv() (*PcapResponse, error)
+	grpc.ClientStream
+}
+
+type captureServiceCapturePcapClient struct {
+	grpc.ClientStream
+}
+
+func (x *captureServiceCapturePcapClient) Recv() (*PcapResponse, error) {
+	m := new(PcapResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *captureServiceClient) StopCapture(ctx context.Context, in *StopCaptureRequest, opts ...grpc.CallOption) (*StopCaptureResponse, error) {
 	out := new(StopCaptureResponse)
 	err := c.cc.Invoke(ctx, "/wcnspect.captures.CaptureService/StopCapture", in, out, opts...)
 	if err != nil {
@@ -73,6 +115,65 @@ func (c *captureServiceClient) StopCapture(ctx context.Context, in *Empty, opts
 	return out, nil
 }
 
+func (c *captureServiceClient) ListCaptures(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListCapturesResponse, error) {
+	out := new(ListCapturesResponse)
+	err := c.cc.Invoke(ctx, "/wcnspect.captures.CaptureService/ListCaptures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *captureServiceClient) GetServerStatus(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServerStatusResponse, error) {
+	out := new(ServerStatusResponse)
+	err := c.cc.Invoke(ctx, "/wcnspect.captures.CaptureService/GetServerStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *captureServiceClient) ListArtifacts(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListArtifactsResponse, error) {
+	out := new(ListArtifactsResponse)
+	err := c.cc.Invoke(ctx, "/wcnspect.captures.CaptureService/ListArtifacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *captureServiceClient) DownloadArtifact(ctx context.Context, in *DownloadArtifactRequest, opts ...grpc.CallOption) (CaptureService_DownloadArtifactClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CaptureService_ServiceDesc.Streams[2], "/wcnspect.captures.CaptureService/DownloadArtifact", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &captureServiceDownloadArtifactClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CaptureService_DownloadArtifactClient interface {
+	Recv() (*ArtifactChunk, error)
+	grpc.ClientStream
+}
+
+type captureServiceDownloadArtifactClient struct {
+	grpc.ClientStream
+}
+
+func (x *captureServiceDownloadArtifactClient) Recv() (*ArtifactChunk, error) {
+	m := new(ArtifactChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *captureServiceClient) GetCounters(ctx context.Context, in *CountersRequest, opts ...grpc.CallOption) (*CountersResponse, error) {
 	out := new(CountersResponse)
 	err := c.cc.Invoke(ctx, "/wcnspect.captures.CaptureService/GetCounters", in, out, opts...)
@@ -91,14 +192,29 @@ func (c *captureServiceClient) GetVFPCounters(ctx context.Context, in *VFPCounte
 	return out, nil
 }
 
+func (c *captureServiceClient) GetVFPRules(ctx context.Context, in *VFPRulesRequest, opts ...grpc.CallOption) (*VFPRulesResponse, error) {
+	out := new(VFPRulesResponse)
+	err := c.cc.Invoke(ctx, "/wcnspect.captures.CaptureService/GetVFPRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CaptureServiceServer is the server API for CaptureService service.
 // All implementations must embed UnimplementedCaptureServiceServer
 // for forward compatibility
 type CaptureServiceServer interface {
 	StartCapture(*CaptureRequest, CaptureService_StartCaptureServer) error
-	StopCapture(context.Context, *Empty) (*StopCaptureResponse, error)
+	CapturePcap(*CaptureRequest, CaptureService_CapturePcapServer) error
+	StopCapture(context.Context, *StopCaptureRequest) (*StopCaptureResponse, error)
+	ListCaptures(context.Context, *Empty) (*ListCapturesResponse, error)
+	GetServerStatus(context.Context, *Empty) (*ServerStatusResponse, error)
+	ListArtifacts(context.Context, *Empty) (*ListArtifactsResponse, error)
+	DownloadArtifact(*DownloadArtifactRequest, CaptureService_DownloadArtifactServer) error
 	GetCounters(context.Context, *CountersRequest) (*CountersResponse, error)
 	GetVFPCounters(context.Context, *VFPCountersRequest) (*VFPCountersResponse, error)
+	GetVFPRules(context.Context, *VFPRulesRequest) (*VFPRulesResponse, error)
 	mustEmbedUnimplementedCaptureServiceServer()
 }
 
@@ -109,15 +225,33 @@ type UnimplementedCaptureServiceServer struct {
 func (UnimplementedCaptureServiceServer) StartCapture(*CaptureRequest, CaptureService_StartCaptureServer) error {
 	return status.Errorf(codes.Unimplemented, "method StartCapture not implemented")
 }
-func (UnimplementedCaptureServiceServer) StopCapture(context.Context, *Empty) (*StopCaptureResponse, error) {
+func (UnimplementedCaptureServiceServer) CapturePcap(*CaptureRequest, CaptureService_CapturePcapServer) error {
+	return status.Errorf(codes.Unimplemented, "method CapturePcap not implemented")
+}
+func (UnimplementedCaptureServiceServer) StopCapture(context.Context, *StopCaptureRequest) (*StopCaptureResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopCapture not implemented")
 }
+func (UnimplementedCaptureServiceServer) ListCaptures(context.Context, *Empty) (*ListCapturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCaptures not implemented")
+}
+func (UnimplementedCaptureServiceServer) GetServerStatus(context.Context, *Empty) (*ServerStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServerStatus not implemented")
+}
+func (UnimplementedCaptureServiceServer) ListArtifacts(context.Context, *Empty) (*ListArtifactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArtifacts not implemented")
+}
+func (UnimplementedCaptureServiceServer) DownloadArtifact(*DownloadArtifactRequest, CaptureService_DownloadArtifactServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadArtifact not implemented")
+}
 func (UnimplementedCaptureServiceServer) GetCounters(context.Context, *CountersRequest) (*CountersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCounters not implemented")
 }
 func (UnimplementedCaptureServiceServer) GetVFPCounters(context.Context, *VFPCountersRequest) (*VFPCountersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVFPCounters not implemented")
 }
+func (UnimplementedCaptureServiceServer) GetVFPRules(context.Context, *VFPRulesRequest) (*VFPRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVFPRules not implemented")
+}
 func (UnimplementedCaptureServiceServer) mustEmbedUnimplementedCaptureServiceServer() {}
 
 // UnsafeCaptureServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -152,8 +286,29 @@ func (x *captureServiceStartCaptureServer) Send(m *CaptureResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _CaptureService_CapturePcap_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CaptureRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CaptureServiceServer).CapturePcap(m, &captureServiceCapturePcapServer{stream})
+}
+
+type CaptureService_CapturePcapServer interface {
+	Send(*PcapResponse) error
+	grpc.ServerStream
+}
+
+type captureServiceCapturePcapServer struct {
+	grpc.ServerStream
+}
+
+func (x *captureServiceCapturePcapServer) Send(m *PcapResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _CaptureService_StopCapture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(StopCaptureRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -165,11 +320,86 @@ func _CaptureService_StopCapture_Handler(srv interface{}, ctx context.Context, d
 		FullMethod: "/wcnspect.captures.CaptureService/StopCapture",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CaptureServiceServer).StopCapture(ctx, req.(*Empty))
+		return srv.(CaptureServiceServer).StopCapture(ctx, req.(*StopCaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaptureService_ListCaptures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaptureServiceServer).ListCaptures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wcnspect.captures.CaptureService/ListCaptures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaptureServiceServer).ListCaptures(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaptureService_GetServerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaptureServiceServer).GetServerStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wcnspect.captures.CaptureService/GetServerStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaptureServiceServer).GetServerStatus(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CaptureService_ListArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaptureServiceServer).ListArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wcnspect.captures.CaptureService/ListArtifacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaptureServiceServer).ListArtifacts(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CaptureService_DownloadArtifact_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadArtifactRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CaptureServiceServer).DownloadArtifact(m, &captureServiceDownloadArtifactServer{stream})
+}
+
+type CaptureService_DownloadArtifactServer interface {
+	Send(*ArtifactChunk) error
+	grpc.ServerStream
+}
+
+type captureServiceDownloadArtifactServer struct {
+	grpc.ServerStream
+}
+
+func (x *captureServiceDownloadArtifactServer) Send(m *ArtifactChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _CaptureService_GetCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CountersRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +436,24 @@ func _CaptureService_GetVFPCounters_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CaptureService_GetVFPRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VFPRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CaptureServiceServer).GetVFPRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wcnspect.captures.CaptureService/GetVFPRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CaptureServiceServer).GetVFPRules(ctx, req.(*VFPRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CaptureService_ServiceDesc is the grpc.ServiceDesc for CaptureService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -217,6 +465,18 @@ var CaptureService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StopCapture",
 			Handler:    _CaptureService_StopCapture_Handler,
 		},
+		{
+			MethodName: "ListCaptures",
+			Handler:    _CaptureService_ListCaptures_Handler,
+		},
+		{
+			MethodName: "GetServerStatus",
+			Handler:    _CaptureService_GetServerStatus_Handler,
+		},
+		{
+			MethodName: "ListArtifacts",
+			Handler:    _CaptureService_ListArtifacts_Handler,
+		},
 		{
 			MethodName: "GetCounters",
 			Handler:    _CaptureService_GetCounters_Handler,
@@ -225,6 +485,10 @@ var CaptureService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetVFPCounters",
 			Handler:    _CaptureService_GetVFPCounters_Handler,
 		},
+		{
+			MethodName: "GetVFPRules",
+			Handler:    _CaptureService_GetVFPRules_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -232,6 +496,16 @@ var CaptureService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _CaptureService_StartCapture_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "CapturePcap",
+			Handler:       _CaptureService_CapturePcap_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DownloadArtifact",
+			Handler:       _CaptureService_DownloadArtifact_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "captures.proto",
 }
@@ -20,6 +20,7 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// models
 type HCNType int32
 
 const (
@@ -75,6 +76,496 @@ func (HCNType) EnumDescriptor() ([]byte, []int) {
 	return file_hcn_proto_rawDescGZIP(), []int{0}
 }
 
+type HCNNetwork struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name    string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type    string   `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Subnets []string `protobuf:"bytes,4,rep,name=subnets,proto3" json:"subnets,omitempty"`
+	Flags   uint32   `protobuf:"varint,5,opt,name=flags,proto3" json:"flags,omitempty"`
+}
+
+func (x *HCNNetwork) Reset() {
+	*x = HCNNetwork{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcn_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HCNNetwork) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HCNNetwork) ProtoMessage() {}
+
+func (x *HCNNetwork) ProtoReflect() protoreflect.Message {
+	mi := &file_hcn_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HCNNetwork.ProtoReflect.Descriptor instead.
+func (*HCNNetwork) Descriptor() ([]byte, []int) {
+	return file_hcn_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HCNNetwork) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HCNNetwork) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HCNNetwork) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *HCNNetwork) GetSubnets() []string {
+	if x != nil {
+		return x.Subnets
+	}
+	return nil
+}
+
+func (x *HCNNetwork) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+type HCNEndpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	NetworkId   string   `protobuf:"bytes,3,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	NamespaceId string   `protobuf:"bytes,4,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	IpAddresses []string `protobuf:"bytes,5,rep,name=ip_addresses,json=ipAddresses,proto3" json:"ip_addresses,omitempty"`
+	MacAddress  string   `protobuf:"bytes,6,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
+	Policies    []string `protobuf:"bytes,7,rep,name=policies,proto3" json:"policies,omitempty"`
+	Flags       uint32   `protobuf:"varint,8,opt,name=flags,proto3" json:"flags,omitempty"`
+}
+
+func (x *HCNEndpoint) Reset() {
+	*x = HCNEndpoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcn_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HCNEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HCNEndpoint) ProtoMessage() {}
+
+func (x *HCNEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_hcn_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HCNEndpoint.ProtoReflect.Descriptor instead.
+func (*HCNEndpoint) Descriptor() ([]byte, []int) {
+	return file_hcn_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HCNEndpoint) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HCNEndpoint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HCNEndpoint) GetNetworkId() string {
+	if x != nil {
+		return x.NetworkId
+	}
+	return ""
+}
+
+func (x *HCNEndpoint) GetNamespaceId() string {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return ""
+}
+
+func (x *HCNEndpoint) GetIpAddresses() []string {
+	if x != nil {
+		return x.IpAddresses
+	}
+	return nil
+}
+
+func (x *HCNEndpoint) GetMacAddress() string {
+	if x != nil {
+		return x.MacAddress
+	}
+	return ""
+}
+
+func (x *HCNEndpoint) GetPolicies() []string {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *HCNEndpoint) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+type HCNPortMapping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Protocol     uint32 `protobuf:"varint,1,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	InternalPort uint32 `protobuf:"varint,2,opt,name=internal_port,json=internalPort,proto3" json:"internal_port,omitempty"`
+	ExternalPort uint32 `protobuf:"varint,3,opt,name=external_port,json=externalPort,proto3" json:"external_port,omitempty"`
+	Flags        uint32 `protobuf:"varint,4,opt,name=flags,proto3" json:"flags,omitempty"`
+}
+
+func (x *HCNPortMapping) Reset() {
+	*x = HCNPortMapping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcn_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HCNPortMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HCNPortMapping) ProtoMessage() {}
+
+func (x *HCNPortMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_hcn_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HCNPortMapping.ProtoReflect.Descriptor instead.
+func (*HCNPortMapping) Descriptor() ([]byte, []int) {
+	return file_hcn_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HCNPortMapping) GetProtocol() uint32 {
+	if x != nil {
+		return x.Protocol
+	}
+	return 0
+}
+
+func (x *HCNPortMapping) GetInternalPort() uint32 {
+	if x != nil {
+		return x.InternalPort
+	}
+	return 0
+}
+
+func (x *HCNPortMapping) GetExternalPort() uint32 {
+	if x != nil {
+		return x.ExternalPort
+	}
+	return 0
+}
+
+func (x *HCNPortMapping) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+type HCNLoadBalancer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EndpointIds  []string          `protobuf:"bytes,2,rep,name=endpoint_ids,json=endpointIds,proto3" json:"endpoint_ids,omitempty"`
+	SourceVip    string            `protobuf:"bytes,3,opt,name=source_vip,json=sourceVip,proto3" json:"source_vip,omitempty"`
+	FrontendVips []string          `protobuf:"bytes,4,rep,name=frontend_vips,json=frontendVips,proto3" json:"frontend_vips,omitempty"`
+	PortMappings []*HCNPortMapping `protobuf:"bytes,5,rep,name=port_mappings,json=portMappings,proto3" json:"port_mappings,omitempty"`
+	Flags        uint32            `protobuf:"varint,6,opt,name=flags,proto3" json:"flags,omitempty"`
+}
+
+func (x *HCNLoadBalancer) Reset() {
+	*x = HCNLoadBalancer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcn_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HCNLoadBalancer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HCNLoadBalancer) ProtoMessage() {}
+
+func (x *HCNLoadBalancer) ProtoReflect() protoreflect.Message {
+	mi := &file_hcn_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HCNLoadBalancer.ProtoReflect.Descriptor instead.
+func (*HCNLoadBalancer) Descriptor() ([]byte, []int) {
+	return file_hcn_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HCNLoadBalancer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HCNLoadBalancer) GetEndpointIds() []string {
+	if x != nil {
+		return x.EndpointIds
+	}
+	return nil
+}
+
+func (x *HCNLoadBalancer) GetSourceVip() string {
+	if x != nil {
+		return x.SourceVip
+	}
+	return ""
+}
+
+func (x *HCNLoadBalancer) GetFrontendVips() []string {
+	if x != nil {
+		return x.FrontendVips
+	}
+	return nil
+}
+
+func (x *HCNLoadBalancer) GetPortMappings() []*HCNPortMapping {
+	if x != nil {
+		return x.PortMappings
+	}
+	return nil
+}
+
+func (x *HCNLoadBalancer) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+type HCNNamespace struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	NamespaceId  uint32   `protobuf:"varint,2,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	Type         string   `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	EndpointIds  []string `protobuf:"bytes,4,rep,name=endpoint_ids,json=endpointIds,proto3" json:"endpoint_ids,omitempty"`
+	ContainerIds []string `protobuf:"bytes,5,rep,name=container_ids,json=containerIds,proto3" json:"container_ids,omitempty"`
+}
+
+func (x *HCNNamespace) Reset() {
+	*x = HCNNamespace{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcn_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HCNNamespace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HCNNamespace) ProtoMessage() {}
+
+func (x *HCNNamespace) ProtoReflect() protoreflect.Message {
+	mi := &file_hcn_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HCNNamespace.ProtoReflect.Descriptor instead.
+func (*HCNNamespace) Descriptor() ([]byte, []int) {
+	return file_hcn_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HCNNamespace) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HCNNamespace) GetNamespaceId() uint32 {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return 0
+}
+
+func (x *HCNNamespace) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *HCNNamespace) GetEndpointIds() []string {
+	if x != nil {
+		return x.EndpointIds
+	}
+	return nil
+}
+
+func (x *HCNNamespace) GetContainerIds() []string {
+	if x != nil {
+		return x.ContainerIds
+	}
+	return nil
+}
+
+type HCNObjects struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Networks      []*HCNNetwork      `protobuf:"bytes,1,rep,name=networks,proto3" json:"networks,omitempty"`
+	Endpoints     []*HCNEndpoint     `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	LoadBalancers []*HCNLoadBalancer `protobuf:"bytes,3,rep,name=load_balancers,json=loadBalancers,proto3" json:"load_balancers,omitempty"`
+	Namespaces    []*HCNNamespace    `protobuf:"bytes,4,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+}
+
+func (x *HCNObjects) Reset() {
+	*x = HCNObjects{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hcn_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HCNObjects) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HCNObjects) ProtoMessage() {}
+
+func (x *HCNObjects) ProtoReflect() protoreflect.Message {
+	mi := &file_hcn_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HCNObjects.ProtoReflect.Descriptor instead.
+func (*HCNObjects) Descriptor() ([]byte, []int) {
+	return file_hcn_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HCNObjects) GetNetworks() []*HCNNetwork {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+func (x *HCNObjects) GetEndpoints() []*HCNEndpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *HCNObjects) GetLoadBalancers() []*HCNLoadBalancer {
+	if x != nil {
+		return x.LoadBalancers
+	}
+	return nil
+}
+
+func (x *HCNObjects) GetNamespaces() []*HCNNamespace {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
 // requests
 type HCNRequest struct {
 	state         protoimpl.MessageState
@@ -88,7 +579,7 @@ type HCNRequest struct {
 func (x *HCNRequest) Reset() {
 	*x = HCNRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hcn_proto_msgTypes[0]
+		mi := &file_hcn_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -101,7 +592,7 @@ func (x *HCNRequest) String() string {
 func (*HCNRequest) ProtoMessage() {}
 
 func (x *HCNRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hcn_proto_msgTypes[0]
+	mi := &file_hcn_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -114,7 +605,7 @@ func (x *HCNRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HCNRequest.ProtoReflect.Descriptor instead.
 func (*HCNRequest) Descriptor() ([]byte, []int) {
-	return file_hcn_proto_rawDescGZIP(), []int{0}
+	return file_hcn_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *HCNRequest) GetHcntype() HCNType {
@@ -137,13 +628,14 @@ type HCNResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	HcnResult []byte `protobuf:"bytes,1,opt,name=hcn_result,json=hcnResult,proto3" json:"hcn_result,omitempty"`
+	HcnResult []byte      `protobuf:"bytes,1,opt,name=hcn_result,json=hcnResult,proto3" json:"hcn_result,omitempty"`
+	Objects   *HCNObjects `protobuf:"bytes,2,opt,name=objects,proto3" json:"objects,omitempty"`
 }
 
 func (x *HCNResponse) Reset() {
 	*x = HCNResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_hcn_proto_msgTypes[1]
+		mi := &file_hcn_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -156,7 +648,7 @@ func (x *HCNResponse) String() string {
 func (*HCNResponse) ProtoMessage() {}
 
 func (x *HCNResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hcn_proto_msgTypes[1]
+	mi := &file_hcn_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -169,7 +661,7 @@ func (x *HCNResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HCNResponse.ProtoReflect.Descriptor instead.
 func (*HCNResponse) Descriptor() ([]byte, []int) {
-	return file_hcn_proto_rawDescGZIP(), []int{1}
+	return file_hcn_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *HCNResponse) GetHcnResult() []byte {
@@ -179,33 +671,114 @@ func (x *HCNResponse) GetHcnResult() []byte {
 	return nil
 }
 
+func (x *HCNResponse) GetObjects() *HCNObjects {
+	if x != nil {
+		return x.Objects
+	}
+	return nil
+}
+
 var File_hcn_proto protoreflect.FileDescriptor
 
 var file_hcn_proto_rawDesc = []byte{
-	0x0a, 0x09, 0x68, 0x63, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x77, 0x69, 0x6e,
-	0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x68, 0x63, 0x6e, 0x22, 0x57, 0x0a, 0x0a, 0x48, 0x43, 0x4e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x68, 0x63, 0x6e, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x70,
-	0x65, 0x63, 0x74, 0x2e, 0x68, 0x63, 0x6e, 0x2e, 0x48, 0x43, 0x4e, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x07, 0x68, 0x63, 0x6e, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x62,
-	0x6f, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x76, 0x65, 0x72, 0x62, 0x6f,
-	0x73, 0x65, 0x22, 0x2c, 0x0a, 0x0b, 0x48, 0x43, 0x4e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x63, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x68, 0x63, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x2a, 0x52, 0x0a, 0x07, 0x48, 0x43, 0x4e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x61,
-	0x6c, 0x6c, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
-	0x73, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x6c, 0x6f, 0x61, 0x64, 0x62, 0x61, 0x6c, 0x61, 0x6e,
-	0x63, 0x65, 0x72, 0x73, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x73, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x73, 0x10, 0x04, 0x32, 0x51, 0x0a, 0x0a, 0x48, 0x43, 0x4e, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x48, 0x43, 0x4e, 0x4c, 0x6f, 0x67, 0x73,
-	0x12, 0x18, 0x2e, 0x77, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x68, 0x63, 0x6e, 0x2e,
-	0x48, 0x43, 0x4e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x77, 0x69, 0x6e,
-	0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x68, 0x63, 0x6e, 0x2e, 0x48, 0x43, 0x4e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x6f, 0x66, 0x74, 0x2f,
-	0x77, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x0a, 0x09, 0x68, 0x63, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x77, 0x63, 0x6e,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x68, 0x63, 0x6e, 0x22, 0x74, 0x0a, 0x0a, 0x48, 0x43, 0x4e,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61,
+	0x67, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x22,
+	0xe9, 0x01, 0x0a, 0x0b, 0x48, 0x43, 0x4e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x70, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d,
+	0x61, 0x63, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x22, 0x8c, 0x01, 0x0a, 0x0e,
+	0x48, 0x43, 0x4e, 0x50, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x50, 0x6f, 0x72, 0x74, 0x12,
+	0x23, 0x0a, 0x0d, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x70, 0x6f, 0x72, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x50, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x22, 0xe1, 0x01, 0x0a, 0x0f, 0x48,
+	0x43, 0x4e, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21,
+	0x0a, 0x0c, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x64,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x76, 0x69, 0x70, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x69, 0x70,
+	0x12, 0x23, 0x0a, 0x0d, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x5f, 0x76, 0x69, 0x70,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x64, 0x56, 0x69, 0x70, 0x73, 0x12, 0x41, 0x0a, 0x0d, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x6d, 0x61,
+	0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x77,
+	0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x68, 0x63, 0x6e, 0x2e, 0x48, 0x43, 0x4e, 0x50,
+	0x6f, 0x72, 0x74, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x0c, 0x70, 0x6f, 0x72, 0x74,
+	0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x22, 0x9d,
+	0x01, 0x0a, 0x0c, 0x48, 0x43, 0x4e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x21, 0x0a, 0x0c, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x64, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x73, 0x22, 0xfd,
+	0x01, 0x0a, 0x0a, 0x48, 0x43, 0x4e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x34, 0x0a,
+	0x08, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x68, 0x63, 0x6e, 0x2e, 0x48,
+	0x43, 0x4e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x08, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x73, 0x12, 0x37, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x2e, 0x68, 0x63, 0x6e, 0x2e, 0x48, 0x43, 0x4e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x44, 0x0a, 0x0e,
+	0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e,
+	0x68, 0x63, 0x6e, 0x2e, 0x48, 0x43, 0x4e, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x72, 0x52, 0x0d, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x72, 0x73, 0x12, 0x3a, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x2e, 0x68, 0x63, 0x6e, 0x2e, 0x48, 0x43, 0x4e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x22, 0x57,
+	0x0a, 0x0a, 0x48, 0x43, 0x4e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x07,
+	0x68, 0x63, 0x6e, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e,
+	0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x68, 0x63, 0x6e, 0x2e, 0x48, 0x43, 0x4e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x68, 0x63, 0x6e, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x22, 0x60, 0x0a, 0x0b, 0x48, 0x43, 0x4e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x63, 0x6e, 0x5f, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x68, 0x63, 0x6e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x32, 0x0a, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x2e, 0x68, 0x63, 0x6e, 0x2e, 0x48, 0x43, 0x4e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x52, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2a, 0x52, 0x0a, 0x07, 0x48, 0x43, 0x4e,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x10, 0x00, 0x12, 0x0d, 0x0a,
+	0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d,
+	0x6c, 0x6f, 0x61, 0x64, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x73, 0x10, 0x02, 0x12,
+	0x0e, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x10, 0x03, 0x12,
+	0x0c, 0x0a, 0x08, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x10, 0x04, 0x32, 0x51, 0x0a,
+	0x0a, 0x48, 0x43, 0x4e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x47,
+	0x65, 0x74, 0x48, 0x43, 0x4e, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x18, 0x2e, 0x77, 0x63, 0x6e, 0x73,
+	0x70, 0x65, 0x63, 0x74, 0x2e, 0x68, 0x63, 0x6e, 0x2e, 0x48, 0x43, 0x4e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x2e, 0x68,
+	0x63, 0x6e, 0x2e, 0x48, 0x43, 0x4e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x42, 0x23, 0x5a, 0x21, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d,
+	0x69, 0x63, 0x72, 0x6f, 0x73, 0x6f, 0x66, 0x74, 0x2f, 0x77, 0x63, 0x6e, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -221,21 +794,33 @@ func file_hcn_proto_rawDescGZIP() []byte {
 }
 
 var file_hcn_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_hcn_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_hcn_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_hcn_proto_goTypes = []interface{}{
-	(HCNType)(0),        // 0: wcnspect.hcn.HCNType
-	(*HCNRequest)(nil),  // 1: wcnspect.hcn.HCNRequest
-	(*HCNResponse)(nil), // 2: wcnspect.hcn.HCNResponse
+	(HCNType)(0),            // 0: wcnspect.hcn.HCNType
+	(*HCNNetwork)(nil),      // 1: wcnspect.hcn.HCNNetwork
+	(*HCNEndpoint)(nil),     // 2: wcnspect.hcn.HCNEndpoint
+	(*HCNPortMapping)(nil),  // 3: wcnspect.hcn.HCNPortMapping
+	(*HCNLoadBalancer)(nil), // 4: wcnspect.hcn.HCNLoadBalancer
+	(*HCNNamespace)(nil),    // 5: wcnspect.hcn.HCNNamespace
+	(*HCNObjects)(nil),      // 6: wcnspect.hcn.HCNObjects
+	(*HCNRequest)(nil),      // 7: wcnspect.hcn.HCNRequest
+	(*HCNResponse)(nil),     // 8: wcnspect.hcn.HCNResponse
 }
 var file_hcn_proto_depIdxs = []int32{
-	0, // 0: wcnspect.hcn.HCNRequest.hcntype:type_name -> wcnspect.hcn.HCNType
-	1, // 1: wcnspect.hcn.HCNService.GetHCNLogs:input_type -> wcnspect.hcn.HCNRequest
-	2, // 2: wcnspect.hcn.HCNService.GetHCNLogs:output_type -> wcnspect.hcn.HCNResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3, // 0: wcnspect.hcn.HCNLoadBalancer.port_mappings:type_name -> wcnspect.hcn.HCNPortMapping
+	1, // 1: wcnspect.hcn.HCNObjects.networks:type_name -> wcnspect.hcn.HCNNetwork
+	2, // 2: wcnspect.hcn.HCNObjects.endpoints:type_name -> wcnspect.hcn.HCNEndpoint
+	4, // 3: wcnspect.hcn.HCNObjects.load_balancers:type_name -> wcnspect.hcn.HCNLoadBalancer
+	5, // 4: wcnspect.hcn.HCNObjects.namespaces:type_name -> wcnspect.hcn.HCNNamespace
+	0, // 5: wcnspect.hcn.HCNRequest.hcntype:type_name -> wcnspect.hcn.HCNType
+	6, // 6: wcnspect.hcn.HCNResponse.objects:type_name -> wcnspect.hcn.HCNObjects
+	7, // 7: wcnspect.hcn.HCNService.GetHCNLogs:input_type -> wcnspect.hcn.HCNRequest
+	8, // 8: wcnspect.hcn.HCNService.GetHCNLogs:output_type -> wcnspect.hcn.HCNResponse
+	8, // [8:9] is the sub-list for method output_type
+	7, // [7:8] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_hcn_proto_init() }
@@ -245,7 +830,7 @@ func file_hcn_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_hcn_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HCNRequest); i {
+			switch v := v.(*HCNNetwork); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -257,6 +842,78 @@ func file_hcn_proto_init() {
 			}
 		}
 		file_hcn_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HCNEndpoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcn_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HCNPortMapping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcn_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HCNLoadBalancer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcn_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HCNNamespace); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcn_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HCNObjects); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcn_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HCNRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hcn_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HCNResponse); i {
 			case 0:
 				return &v.state
@@ -275,7 +932,7 @@ func file_hcn_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_hcn_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   2,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},